@@ -0,0 +1,159 @@
+package calendar
+
+import (
+	"time"
+
+	"github.com/jimeh/go-tyme/dur"
+	"github.com/jimeh/go-tyme/ts"
+)
+
+// maxIdleDays is the number of consecutive days without working time after
+// which a calendar is considered to have no working time at all.
+const maxIdleDays = 2 * 366
+
+// Add returns t with the business duration d added, counting only working
+// time of c, so adding 4 hours to 15:00 on a Friday with working hours of
+// 09:00-17:00 results in 11:00 on the following Monday. A result which falls
+// exactly on the end of working hours is not moved to the start of the next
+// working hours. Negative durations subtract business time. The result is in
+// the location of c. It returns ErrNoWorkingTime if c has no working time.
+func Add[T ts.Timestamp, D ts.Duration](c *Calendar, t T, d D) (T, error) {
+	tt := c.in(time.Time(t))
+	rem := time.Duration(d)
+	if rem == 0 {
+		return T(tt), nil
+	}
+
+	for day, idle := tt, 0; ; day = nextDay(day, rem > 0) {
+		intervals := c.workingTime(day)
+		if len(intervals) == 0 {
+			if idle++; idle > maxIdleDays {
+				return T(time.Time{}), ErrNoWorkingTime
+			}
+
+			continue
+		}
+		idle = 0
+
+		if rem > 0 {
+			for _, i := range intervals {
+				if !i.end.After(tt) {
+					continue
+				}
+
+				start := latest(i.start, tt)
+				avail := i.end.Sub(start)
+				if rem <= avail {
+					return T(start.Add(rem)), nil
+				}
+				rem -= avail
+			}
+
+			continue
+		}
+
+		for j := len(intervals) - 1; j >= 0; j-- {
+			i := intervals[j]
+			if !i.start.Before(tt) {
+				continue
+			}
+
+			end := earliest(i.end, tt)
+			avail := end.Sub(i.start)
+			if -rem <= avail {
+				return T(end.Add(rem)), nil
+			}
+			rem += avail
+		}
+	}
+}
+
+// AddWorkdays returns t with the given number of workdays of c added, keeping
+// the same wall clock time, so adding 3 workdays to a Thursday results in the
+// following Tuesday when weekends are Saturday and Sunday. Negative values
+// subtract workdays. The result is in the location of c. It returns
+// ErrNoWorkingTime if c has no workdays.
+func AddWorkdays[T ts.Timestamp](c *Calendar, t T, days int) (T, error) {
+	tt := c.in(time.Time(t))
+	y, m, d := tt.Date()
+
+	step := 1
+	if days < 0 {
+		step, days = -1, -days
+	}
+
+	for idle := 0; days > 0; {
+		d += step
+		if c.IsWorkday(time.Date(y, m, d, 12, 0, 0, 0, tt.Location())) {
+			days--
+			idle = 0
+		} else if idle++; idle > maxIdleDays {
+			return T(time.Time{}), ErrNoWorkingTime
+		}
+	}
+
+	h, min, sec := tt.Clock()
+
+	return T(time.Date(
+		y, m, d, h, min, sec, tt.Nanosecond(), tt.Location(),
+	)), nil
+}
+
+// Between returns the business time between from and to, counting only
+// working time of c. It is negative when to is before from.
+func Between[T ts.Timestamp](c *Calendar, from, to T) dur.Duration {
+	a, b := c.in(time.Time(from)), c.in(time.Time(to))
+
+	sign := time.Duration(1)
+	if b.Before(a) {
+		a, b, sign = b, a, -1
+	}
+
+	var total time.Duration
+	for day := a; !dateOf(day).After(dateOf(b)); day = nextDay(day, true) {
+		for _, i := range c.workingTime(day) {
+			start, end := latest(i.start, a), earliest(i.end, b)
+			if end.After(start) {
+				total += end.Sub(start)
+			}
+		}
+	}
+
+	return dur.Duration(sign * total)
+}
+
+// nextDay returns noon of the day after, or before, t in t's location, which
+// always exists regardless of daylight saving time transitions.
+func nextDay(t time.Time, forward bool) time.Time {
+	y, m, d := t.Date()
+	if forward {
+		d++
+	} else {
+		d--
+	}
+
+	return time.Date(y, m, d, 12, 0, 0, 0, t.Location())
+}
+
+// dateOf returns the date of t in t's location as midnight UTC.
+func dateOf(t time.Time) time.Time {
+	y, m, d := t.Date()
+
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func latest(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+
+	return b
+}
+
+func earliest(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,293 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jimeh/go-tyme/dur"
+	"github.com/jimeh/go-tyme/ts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func officeCalendar() *Calendar {
+	nineToFive := []TimeRange{
+		{Start: 9 * time.Hour, End: 12 * time.Hour},
+		{Start: 13 * time.Hour, End: 17 * time.Hour},
+	}
+
+	return &Calendar{
+		Holidays: []Holiday{
+			{Name: "Christmas Day", Kind: HolidayFixed, Month: 12, Day: 25},
+		},
+		Hours: map[time.Weekday][]TimeRange{
+			time.Monday:    nineToFive,
+			time.Tuesday:   nineToFive,
+			time.Wednesday: nineToFive,
+			time.Thursday:  nineToFive,
+			time.Friday:    nineToFive,
+		},
+	}
+}
+
+func at(y int, m time.Month, d, h, min int) time.Time {
+	return time.Date(y, m, d, h, min, 0, 0, time.UTC)
+}
+
+func TestAdd(t *testing.T) {
+	tests := []struct {
+		name string
+		t    time.Time
+		d    time.Duration
+		want time.Time
+	}{
+		{
+			name: "zero",
+			t:    at(2023, 10, 14, 10, 0),
+			want: at(2023, 10, 14, 10, 0),
+		},
+		{
+			name: "within working hours",
+			t:    at(2023, 10, 16, 9, 0),
+			d:    2 * time.Hour,
+			want: at(2023, 10, 16, 11, 0),
+		},
+		{
+			name: "over lunch break",
+			t:    at(2023, 10, 16, 11, 0),
+			d:    2 * time.Hour,
+			want: at(2023, 10, 16, 14, 0),
+		},
+		{
+			name: "until end of day",
+			t:    at(2023, 10, 16, 9, 0),
+			d:    7 * time.Hour,
+			want: at(2023, 10, 16, 17, 0),
+		},
+		{
+			name: "over weekend",
+			t:    at(2023, 10, 13, 15, 0),
+			d:    4 * time.Hour,
+			want: at(2023, 10, 16, 11, 0),
+		},
+		{
+			name: "from weekend",
+			t:    at(2023, 10, 14, 10, 0),
+			d:    4 * time.Hour,
+			want: at(2023, 10, 16, 14, 0),
+		},
+		{
+			name: "over holiday",
+			t:    at(2023, 12, 22, 16, 0),
+			d:    2 * time.Hour,
+			want: at(2023, 12, 26, 10, 0),
+		},
+		{
+			name: "whole weeks",
+			t:    at(2023, 10, 16, 9, 0),
+			d:    70 * time.Hour,
+			want: at(2023, 10, 27, 17, 0),
+		},
+		{
+			name: "subtract over weekend",
+			t:    at(2023, 10, 16, 11, 0),
+			d:    -4 * time.Hour,
+			want: at(2023, 10, 13, 15, 0),
+		},
+		{
+			name: "subtract over lunch break",
+			t:    at(2023, 10, 16, 14, 0),
+			d:    -2 * time.Hour,
+			want: at(2023, 10, 16, 11, 0),
+		},
+		{
+			name: "subtract until start of day",
+			t:    at(2023, 10, 16, 18, 0),
+			d:    -7 * time.Hour,
+			want: at(2023, 10, 16, 9, 0),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Add(officeCalendar(), tt.t, tt.d)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestAdd_Timestamp(t *testing.T) {
+	nyc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	c := officeCalendar()
+	c.Location = nyc
+
+	got, err := Add(
+		c, ts.Second(at(2023, 10, 13, 20, 0)), dur.Duration(2*time.Hour),
+	)
+	require.NoError(t, err)
+
+	assert.IsType(t, ts.Second{}, got)
+	assert.Equal(t, at(2023, 10, 16, 14, 0), got.Time().UTC())
+	assert.Equal(t, nyc, got.Time().Location())
+}
+
+func TestAdd_FullDays(t *testing.T) {
+	c := &Calendar{}
+
+	got, err := Add(c, at(2023, 10, 13, 12, 0), 24*time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, at(2023, 10, 16, 12, 0), got)
+
+	got, err = Add(c, at(2023, 10, 16, 12, 0), -24*time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, at(2023, 10, 13, 12, 0), got)
+}
+
+func TestAdd_NoWorkingTime(t *testing.T) {
+	c := &Calendar{Hours: map[time.Weekday][]TimeRange{}}
+
+	got, err := Add(c, at(2023, 10, 16, 12, 0), time.Hour)
+	assert.ErrorIs(t, err, ErrNoWorkingTime)
+	assert.True(t, got.IsZero())
+
+	_, err = Add(c, at(2023, 10, 16, 12, 0), -time.Hour)
+	assert.ErrorIs(t, err, ErrNoWorkingTime)
+}
+
+func TestAddWorkdays(t *testing.T) {
+	tests := []struct {
+		name string
+		t    time.Time
+		days int
+		want time.Time
+	}{
+		{
+			name: "zero",
+			t:    at(2023, 10, 14, 10, 30),
+			want: at(2023, 10, 14, 10, 30),
+		},
+		{
+			name: "over weekend",
+			t:    at(2023, 10, 12, 10, 30),
+			days: 3,
+			want: at(2023, 10, 17, 10, 30),
+		},
+		{
+			name: "from weekend",
+			t:    at(2023, 10, 14, 10, 30),
+			days: 1,
+			want: at(2023, 10, 16, 10, 30),
+		},
+		{
+			name: "over holiday",
+			t:    at(2023, 12, 22, 10, 30),
+			days: 1,
+			want: at(2023, 12, 26, 10, 30),
+		},
+		{
+			name: "subtract over weekend",
+			t:    at(2023, 10, 17, 10, 30),
+			days: -3,
+			want: at(2023, 10, 12, 10, 30),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AddWorkdays(officeCalendar(), tt.t, tt.days)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestAddWorkdays_NoWorkdays(t *testing.T) {
+	c := &Calendar{Weekend: []time.Weekday{
+		time.Sunday, time.Monday, time.Tuesday, time.Wednesday,
+		time.Thursday, time.Friday, time.Saturday,
+	}}
+
+	got, err := AddWorkdays(c, at(2023, 10, 16, 12, 0), 1)
+	assert.ErrorIs(t, err, ErrNoWorkingTime)
+	assert.True(t, got.IsZero())
+}
+
+func TestBetween(t *testing.T) {
+	tests := []struct {
+		name string
+		from time.Time
+		to   time.Time
+		want dur.Duration
+	}{
+		{
+			name: "same time",
+			from: at(2023, 10, 16, 10, 0),
+			to:   at(2023, 10, 16, 10, 0),
+		},
+		{
+			name: "within working hours",
+			from: at(2023, 10, 16, 10, 0),
+			to:   at(2023, 10, 16, 11, 30),
+			want: dur.Duration(90 * time.Minute),
+		},
+		{
+			name: "over lunch break",
+			from: at(2023, 10, 16, 11, 0),
+			to:   at(2023, 10, 16, 14, 0),
+			want: dur.Duration(2 * time.Hour),
+		},
+		{
+			name: "over weekend",
+			from: at(2023, 10, 13, 15, 0),
+			to:   at(2023, 10, 16, 11, 0),
+			want: dur.Duration(4 * time.Hour),
+		},
+		{
+			name: "reversed",
+			from: at(2023, 10, 16, 11, 0),
+			to:   at(2023, 10, 13, 15, 0),
+			want: dur.Duration(-4 * time.Hour),
+		},
+		{
+			name: "outside working hours",
+			from: at(2023, 10, 14, 0, 0),
+			to:   at(2023, 10, 16, 8, 0),
+		},
+		{
+			name: "whole week",
+			from: at(2023, 10, 16, 0, 0),
+			to:   at(2023, 10, 23, 0, 0),
+			want: dur.Duration(35 * time.Hour),
+		},
+		{
+			name: "over holiday",
+			from: at(2023, 12, 22, 16, 0),
+			to:   at(2023, 12, 26, 10, 0),
+			want: dur.Duration(2 * time.Hour),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Between(officeCalendar(), tt.from, tt.to)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestBetween_DST(t *testing.T) {
+	london, err := time.LoadLocation("Europe/London")
+	require.NoError(t, err)
+
+	c := &Calendar{Location: london, Weekend: []time.Weekday{}}
+
+	got := Between(c,
+		time.Date(2023, 10, 28, 0, 0, 0, 0, london),
+		time.Date(2023, 10, 30, 0, 0, 0, 0, london),
+	)
+
+	assert.Equal(t, dur.Duration(49*time.Hour), got)
+}
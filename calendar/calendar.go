@@ -0,0 +1,393 @@
+// Package calendar provides business calendars, describing working days,
+// holidays and working hours, for calculating business time between instants
+// and adding business durations to timestamps.
+package calendar
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jimeh/go-tyme/ts"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrNoWorkingTime is returned for calendars which have no working time at
+// all.
+var ErrNoWorkingTime = errors.New("calendar has no working time")
+
+// DefaultWeekend is the weekend used by a Calendar with a nil Weekend.
+var DefaultWeekend = []time.Weekday{time.Saturday, time.Sunday}
+
+// Calendar describes working time for business time calculations. It can be
+// unmarshaled from JSON/YAML configuration:
+//
+//	location: Europe/London
+//	weekend: [saturday, sunday]
+//	hours:
+//	  monday: ["09:00-12:00", "13:00-17:00"]
+//	  friday: ["09:00-15:00"]
+//	holidays:
+//	  - name: Christmas Day
+//	    type: fixed
+//	    month: 12
+//	    day: 25
+//
+// A day is a workday when it is neither a weekend day nor a holiday, and its
+// working time is given by Hours.
+type Calendar struct {
+	// Location is the location of the calendar's wall clock. When nil, the
+	// location of the time given to each method or function is used.
+	Location *time.Location
+
+	// Weekend lists the days of the week which are not workdays. When nil,
+	// DefaultWeekend is used, so an empty non-nil slice is needed for a
+	// calendar without weekends.
+	Weekend []time.Weekday
+
+	// Holidays lists the holidays which are not workdays.
+	Holidays []Holiday
+
+	// Hours maps days of the week to their working hours. When nil, workdays
+	// are worked in full. Days missing from a non-nil map have no working
+	// hours.
+	Hours map[time.Weekday][]TimeRange
+}
+
+type calendarConfig struct {
+	Location string `json:"location,omitempty" yaml:"location,omitempty"`
+
+	Weekend []string `json:"weekend" yaml:"weekend"`
+
+	Hours map[string][]TimeRange `json:"hours,omitempty" yaml:"hours,omitempty"`
+
+	Holidays []Holiday `json:"holidays,omitempty" yaml:"holidays,omitempty"`
+}
+
+// IsWeekend reports whether t is on a weekend day in the calendar's location.
+func (c *Calendar) IsWeekend(t time.Time) bool {
+	wd := c.in(t).Weekday()
+	for _, w := range c.weekend() {
+		if w == wd {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Holiday returns the holiday t is on in the calendar's location, and whether
+// it is on a holiday at all.
+func (c *Calendar) Holiday(t time.Time) (Holiday, bool) {
+	y, m, d := c.in(t).Date()
+	date := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+
+	for _, h := range c.Holidays {
+		// Offsets may move holidays into adjacent years.
+		for year := y - 1; year <= y+1; year++ {
+			if hd, ok := h.Date(year); ok && hd.Equal(date) {
+				return h, true
+			}
+		}
+	}
+
+	return Holiday{}, false
+}
+
+// IsHoliday reports whether t is on a holiday in the calendar's location.
+func (c *Calendar) IsHoliday(t time.Time) bool {
+	_, ok := c.Holiday(t)
+
+	return ok
+}
+
+// IsWorkday reports whether t is on a workday in the calendar's location.
+func (c *Calendar) IsWorkday(t time.Time) bool {
+	return !c.IsWeekend(t) && !c.IsHoliday(t)
+}
+
+// IsWorkingTime reports whether t is within the working hours of a workday.
+func (c *Calendar) IsWorkingTime(t time.Time) bool {
+	for _, i := range c.workingTime(c.in(t)) {
+		if !t.Before(i.start) && t.Before(i.end) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Validate returns an error if the Calendar is invalid, or has no working
+// time at all.
+func (c *Calendar) Validate() error {
+	working := false
+	for wd := time.Sunday; wd <= time.Saturday; wd++ {
+		weekend := false
+		for _, w := range c.weekend() {
+			if w < time.Sunday || w > time.Saturday {
+				return fmt.Errorf("invalid weekend day %d", w)
+			}
+			weekend = weekend || w == wd
+		}
+
+		ranges, ok := c.Hours[wd]
+		if err := validateRanges(ranges); err != nil {
+			return fmt.Errorf("invalid %s hours: %w", weekdayName(wd), err)
+		}
+		working = working || (!weekend && (c.Hours == nil || ok))
+	}
+
+	for wd := range c.Hours {
+		if wd < time.Sunday || wd > time.Saturday {
+			return fmt.Errorf("invalid hours day %d", wd)
+		}
+	}
+
+	if !working {
+		return ErrNoWorkingTime
+	}
+
+	for _, h := range c.Holidays {
+		if err := h.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (c Calendar) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.config())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, validating the
+// resulting Calendar.
+func (c *Calendar) UnmarshalJSON(data []byte) error {
+	var cc calendarConfig
+	if err := json.Unmarshal(data, &cc); err != nil {
+		return err
+	}
+
+	nc, err := cc.calendar()
+	if err != nil {
+		return err
+	}
+	*c = nc
+
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (c Calendar) MarshalYAML() (interface{}, error) {
+	return c.config(), nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface, validating the
+// resulting Calendar.
+func (c *Calendar) UnmarshalYAML(node *yaml.Node) error {
+	var cc calendarConfig
+	if err := node.Decode(&cc); err != nil {
+		return err
+	}
+
+	nc, err := cc.calendar()
+	if err != nil {
+		return &yaml.TypeError{Errors: []string{err.Error()}}
+	}
+	*c = nc
+
+	return nil
+}
+
+func (c *Calendar) config() calendarConfig {
+	cc := calendarConfig{
+		Weekend:  []string{},
+		Holidays: c.Holidays,
+	}
+	if c.Location != nil {
+		cc.Location = c.Location.String()
+	}
+
+	for _, wd := range c.weekend() {
+		cc.Weekend = append(cc.Weekend, weekdayName(wd))
+	}
+
+	if c.Hours != nil {
+		cc.Hours = make(map[string][]TimeRange, len(c.Hours))
+		for wd, ranges := range c.Hours {
+			cc.Hours[weekdayName(wd)] = ranges
+		}
+	}
+
+	return cc
+}
+
+func (cc calendarConfig) calendar() (Calendar, error) {
+	c := Calendar{Holidays: cc.Holidays}
+
+	if cc.Location != "" {
+		loc, err := time.LoadLocation(cc.Location)
+		if err != nil {
+			return Calendar{}, err
+		}
+		c.Location = loc
+	}
+
+	if cc.Weekend != nil {
+		c.Weekend = []time.Weekday{}
+		for _, s := range cc.Weekend {
+			wd, err := parseWeekday(s)
+			if err != nil {
+				return Calendar{}, err
+			}
+			c.Weekend = append(c.Weekend, wd)
+		}
+	}
+
+	if cc.Hours != nil {
+		c.Hours = make(map[time.Weekday][]TimeRange, len(cc.Hours))
+		for s, ranges := range cc.Hours {
+			wd, err := parseWeekday(s)
+			if err != nil {
+				return Calendar{}, err
+			}
+			c.Hours[wd] = ranges
+		}
+	}
+
+	if err := c.Validate(); err != nil {
+		return Calendar{}, err
+	}
+
+	return c, nil
+}
+
+func (c *Calendar) weekend() []time.Weekday {
+	if c.Weekend == nil {
+		return DefaultWeekend
+	}
+
+	return c.Weekend
+}
+
+func (c *Calendar) in(t time.Time) time.Time {
+	if c.Location != nil {
+		return t.In(c.Location)
+	}
+
+	return t
+}
+
+// interval is a half-open interval of working time.
+type interval struct {
+	start time.Time
+	end   time.Time
+}
+
+// workingTime returns the intervals of working time on the day of t in t's
+// location, in ascending order.
+func (c *Calendar) workingTime(t time.Time) []interval {
+	if !c.IsWorkday(t) {
+		return nil
+	}
+
+	if c.Hours == nil {
+		return []interval{{start: dayStart(t), end: dayEnd(t)}}
+	}
+
+	ranges := append([]TimeRange(nil), c.Hours[t.Weekday()]...)
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].Start < ranges[j].Start
+	})
+
+	intervals := make([]interval, 0, len(ranges))
+	for _, r := range ranges {
+		intervals = append(intervals, interval{
+			start: wallTime(t, r.Start),
+			end:   wallTime(t, r.End),
+		})
+	}
+
+	return intervals
+}
+
+// wallTime returns the instant at offset since midnight of t's day, in t's
+// location.
+func wallTime(t time.Time, offset time.Duration) time.Time {
+	switch offset {
+	case 0:
+		return dayStart(t)
+	case oneDay:
+		return dayEnd(t)
+	}
+
+	y, m, d := t.Date()
+	h := offset / time.Hour
+	mi := offset % time.Hour / time.Minute
+	sec := offset % time.Minute / time.Second
+	nsec := offset % time.Second
+
+	return time.Date(
+		y, m, d, int(h), int(mi), int(sec), int(nsec), t.Location(),
+	)
+}
+
+func validateRanges(ranges []TimeRange) error {
+	sorted := append([]TimeRange(nil), ranges...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Start < sorted[j].Start
+	})
+
+	for i, r := range sorted {
+		if err := r.Validate(); err != nil {
+			return err
+		}
+
+		if i > 0 && r.Start < sorted[i-1].End {
+			return fmt.Errorf("overlapping time ranges %s and %s",
+				sorted[i-1], r)
+		}
+	}
+
+	return nil
+}
+
+var weekdayNames = []string{
+	"sunday", "monday", "tuesday", "wednesday", "thursday", "friday",
+	"saturday",
+}
+
+func weekdayName(wd time.Weekday) string {
+	if wd < time.Sunday || wd > time.Saturday {
+		return wd.String()
+	}
+
+	return weekdayNames[wd]
+}
+
+// parseWeekday parses the full or three letter name of a day of the week,
+// ignoring case.
+func parseWeekday(s string) (time.Weekday, error) {
+	for i, name := range weekdayNames {
+		if strings.EqualFold(s, name) || strings.EqualFold(s, name[:3]) {
+			return time.Weekday(i), nil
+		}
+	}
+
+	return 0, fmt.Errorf("invalid weekday %q", s)
+}
+
+// dayStart returns the first instant of t's day, in t's location.
+func dayStart(t time.Time) time.Time {
+	return ts.StartOf(t, ts.PeriodDay)
+}
+
+// dayEnd returns the first instant of the day after t's day, in t's
+// location.
+func dayEnd(t time.Time) time.Time {
+	return ts.EndOf(t, ts.PeriodDay).Add(time.Nanosecond)
+}
@@ -0,0 +1,215 @@
+package calendar
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+const calendarYAML = `location: Europe/London
+weekend:
+    - saturday
+    - sunday
+hours:
+    friday:
+        - 09:00-15:00
+    monday:
+        - 09:00-12:00
+        - 13:00-17:00
+holidays:
+    - name: Christmas Day
+      type: fixed
+      month: 12
+      day: 25
+    - name: Easter Monday
+      type: easter
+      offset: 1
+`
+
+func TestCalendar_UnmarshalYAML(t *testing.T) {
+	var c Calendar
+	err := yaml.Unmarshal([]byte(calendarYAML), &c)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Europe/London", c.Location.String())
+	assert.Equal(t, []time.Weekday{time.Saturday, time.Sunday}, c.Weekend)
+	assert.Equal(t, map[time.Weekday][]TimeRange{
+		time.Monday: {
+			{Start: 9 * time.Hour, End: 12 * time.Hour},
+			{Start: 13 * time.Hour, End: 17 * time.Hour},
+		},
+		time.Friday: {{Start: 9 * time.Hour, End: 15 * time.Hour}},
+	}, c.Hours)
+	assert.Equal(t, []Holiday{
+		{Name: "Christmas Day", Kind: HolidayFixed, Month: 12, Day: 25},
+		{Name: "Easter Monday", Kind: HolidayEaster, Offset: 1},
+	}, c.Holidays)
+
+	b, err := yaml.Marshal(c)
+	require.NoError(t, err)
+	assert.Equal(t, calendarYAML, string(b))
+}
+
+func TestCalendar_MarshalUnmarshalJSON(t *testing.T) {
+	c := Calendar{
+		Weekend: []time.Weekday{},
+		Hours: map[time.Weekday][]TimeRange{
+			time.Sunday: {{Start: 10 * time.Hour, End: 16 * time.Hour}},
+		},
+	}
+
+	b, err := json.Marshal(c)
+	require.NoError(t, err)
+	assert.Equal(t,
+		`{"weekend":[],"hours":{"sunday":["10:00-16:00"]}}`, string(b),
+	)
+
+	var got Calendar
+	require.NoError(t, json.Unmarshal(b, &got))
+	assert.Equal(t, c, got)
+
+	b, err = json.Marshal(Calendar{})
+	require.NoError(t, err)
+	assert.Equal(t, `{"weekend":["saturday","sunday"]}`, string(b))
+
+	got = Calendar{}
+	require.NoError(t, json.Unmarshal([]byte(`{}`), &got))
+	assert.Equal(t, Calendar{}, got)
+}
+
+func TestCalendar_UnmarshalInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		wantErr string
+	}{
+		{
+			name:    "unknown location",
+			json:    `{"location":"Nowhere/Town"}`,
+			wantErr: "unknown time zone Nowhere/Town",
+		},
+		{
+			name:    "invalid weekend",
+			json:    `{"weekend":["caturday"]}`,
+			wantErr: `invalid weekday "caturday"`,
+		},
+		{
+			name:    "invalid hours day",
+			json:    `{"hours":{"someday":["09:00-17:00"]}}`,
+			wantErr: `invalid weekday "someday"`,
+		},
+		{
+			name:    "invalid hours",
+			json:    `{"hours":{"monday":["17:00-09:00"]}}`,
+			wantErr: `invalid time range: "17:00-09:00"`,
+		},
+		{
+			name: "overlapping hours",
+			json: `{"hours":{"monday":["09:00-12:00","11:00-17:00"]}}`,
+			wantErr: "invalid monday hours: " +
+				"overlapping time ranges 09:00-12:00 and 11:00-17:00",
+		},
+		{
+			name:    "no working days",
+			json:    `{"weekend":["sun","mon","tue","wed","thu","fri","sat"]}`,
+			wantErr: "calendar has no working time",
+		},
+		{
+			name:    "no working hours",
+			json:    `{"hours":{"saturday":["09:00-17:00"]}}`,
+			wantErr: "calendar has no working time",
+		},
+		{
+			name:    "invalid holiday",
+			json:    `{"holidays":[{"name":"X","type":"lunar"}]}`,
+			wantErr: `invalid type "lunar" of holiday "X"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var c Calendar
+			err := json.Unmarshal([]byte(tt.json), &c)
+			assert.EqualError(t, err, tt.wantErr)
+
+			err = yaml.Unmarshal([]byte(tt.json), &c)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestCalendar_Days(t *testing.T) {
+	nyc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	c := &Calendar{
+		Location: nyc,
+		Holidays: []Holiday{
+			{Name: "Independence Day", Kind: HolidayFixed, Month: 7, Day: 4},
+			{Name: "New Year's Eve", Kind: HolidayFixed, Month: 12, Day: 31},
+			{
+				Name: "Day after New Year's Eve", Kind: HolidayFixed,
+				Month: 12, Day: 31, Offset: 1,
+			},
+		},
+		Hours: map[time.Weekday][]TimeRange{
+			time.Tuesday: {{Start: 9 * time.Hour, End: 17 * time.Hour}},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		t           time.Time
+		wantWeekend bool
+		wantHoliday string
+		wantWorkday bool
+		wantWorking bool
+	}{
+		{
+			name:        "working time",
+			t:           time.Date(2023, 10, 10, 14, 0, 0, 0, time.UTC),
+			wantWorkday: true,
+			wantWorking: true,
+		},
+		{
+			name:        "before hours in calendar location",
+			t:           time.Date(2023, 10, 10, 12, 0, 0, 0, time.UTC),
+			wantWorkday: true,
+		},
+		{
+			name:        "workday without hours",
+			t:           time.Date(2023, 10, 11, 14, 0, 0, 0, time.UTC),
+			wantWorkday: true,
+		},
+		{
+			name:        "weekend in calendar location",
+			t:           time.Date(2023, 10, 16, 2, 0, 0, 0, time.UTC),
+			wantWeekend: true,
+		},
+		{
+			name:        "holiday",
+			t:           time.Date(2023, 7, 4, 14, 0, 0, 0, time.UTC),
+			wantHoliday: "Independence Day",
+		},
+		{
+			name:        "holiday offset into next year",
+			t:           time.Date(2024, 1, 1, 14, 0, 0, 0, time.UTC),
+			wantHoliday: "Day after New Year's Eve",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, ok := c.Holiday(tt.t)
+
+			assert.Equal(t, tt.wantWeekend, c.IsWeekend(tt.t))
+			assert.Equal(t, tt.wantHoliday != "", ok)
+			assert.Equal(t, tt.wantHoliday != "", c.IsHoliday(tt.t))
+			assert.Equal(t, tt.wantHoliday, h.Name)
+			assert.Equal(t, tt.wantWorkday, c.IsWorkday(tt.t))
+			assert.Equal(t, tt.wantWorking, c.IsWorkingTime(tt.t))
+		})
+	}
+}
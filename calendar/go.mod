@@ -0,0 +1,15 @@
+module github.com/jimeh/go-tyme/calendar
+
+go 1.18
+
+require (
+	github.com/jimeh/go-tyme/dur v0.0.0-20221030033507-5d31aa674303
+	github.com/jimeh/go-tyme/ts v0.1.0
+	github.com/stretchr/testify v1.8.1
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+)
@@ -0,0 +1,270 @@
+package calendar
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// HolidayKind determines how the date of a Holiday is calculated.
+type HolidayKind string
+
+const (
+	// HolidayFixed is a holiday on a fixed month and day, like Christmas Day.
+	HolidayFixed HolidayKind = "fixed"
+
+	// HolidayWeekday is a holiday on the nth weekday of a month, like
+	// Thanksgiving on the fourth Thursday of November.
+	HolidayWeekday HolidayKind = "weekday"
+
+	// HolidayEaster is a holiday relative to Western Easter Sunday, like Good
+	// Friday two days before it.
+	HolidayEaster HolidayKind = "easter"
+)
+
+// Holiday is a rule describing the date of a recurring, or one-off, holiday.
+// In JSON/YAML, weekdays are given by name:
+//
+//	holidays:
+//	  - name: Thanksgiving
+//	    type: weekday
+//	    month: 11
+//	    weekday: thursday
+//	    nth: 4
+//	  - name: Good Friday
+//	    type: easter
+//	    offset: -2
+type Holiday struct {
+	// Name is the name of the holiday.
+	Name string
+
+	// Kind determines how the date of the holiday is calculated.
+	Kind HolidayKind
+
+	// Year restricts the holiday to a single year when non-zero.
+	Year int
+
+	// Month is the month of fixed and weekday holidays.
+	Month time.Month
+
+	// Day is the day of month of fixed holidays.
+	Day int
+
+	// Weekday is the day of week of weekday holidays.
+	Weekday time.Weekday
+
+	// Nth is the occurrence of Weekday within Month of weekday holidays, from
+	// 1 to 5, or -1 to -5 counting from the end of the month.
+	Nth int
+
+	// Offset is the number of days added to the date calculated by Kind, so
+	// the Tuesday after the first Monday of November is a weekday holiday on
+	// the first Monday with an Offset of 1. For Easter holidays, it is the
+	// number of days relative to Easter Sunday.
+	Offset int
+}
+
+type holidayConfig struct {
+	Name    string      `json:"name,omitempty" yaml:"name,omitempty"`
+	Kind    HolidayKind `json:"type" yaml:"type"`
+	Year    int         `json:"year,omitempty" yaml:"year,omitempty"`
+	Month   int         `json:"month,omitempty" yaml:"month,omitempty"`
+	Day     int         `json:"day,omitempty" yaml:"day,omitempty"`
+	Weekday string      `json:"weekday,omitempty" yaml:"weekday,omitempty"`
+	Nth     int         `json:"nth,omitempty" yaml:"nth,omitempty"`
+	Offset  int         `json:"offset,omitempty" yaml:"offset,omitempty"`
+}
+
+// Date returns the date of the holiday in year as midnight UTC, and whether
+// the holiday occurs in year at all. Fixed holidays on February 29 only occur
+// in leap years, and weekday holidays on a fifth weekday only occur in months
+// which have one.
+func (h Holiday) Date(year int) (time.Time, bool) {
+	if h.Year != 0 && h.Year != year {
+		return time.Time{}, false
+	}
+
+	var date time.Time
+	switch h.Kind {
+	case HolidayFixed:
+		if h.Day > daysIn(year, h.Month) {
+			return time.Time{}, false
+		}
+		date = time.Date(year, h.Month, h.Day, 0, 0, 0, 0, time.UTC)
+	case HolidayWeekday:
+		d, ok := nthWeekday(year, h.Month, h.Weekday, h.Nth)
+		if !ok {
+			return time.Time{}, false
+		}
+		date = d
+	case HolidayEaster:
+		date = Easter(year)
+	default:
+		return time.Time{}, false
+	}
+
+	return date.AddDate(0, 0, h.Offset), true
+}
+
+// Validate returns an error if the Holiday is invalid.
+func (h Holiday) Validate() error {
+	switch h.Kind {
+	case HolidayFixed:
+		if h.Month < time.January || h.Month > time.December ||
+			h.Day < 1 || h.Day > daysIn(2000, h.Month) {
+			return fmt.Errorf("invalid date of holiday %q", h.Name)
+		}
+	case HolidayWeekday:
+		switch {
+		case h.Month < time.January || h.Month > time.December:
+			return fmt.Errorf("invalid month of holiday %q", h.Name)
+		case h.Weekday < time.Sunday || h.Weekday > time.Saturday:
+			return fmt.Errorf("invalid weekday of holiday %q", h.Name)
+		case h.Nth == 0 || h.Nth < -5 || h.Nth > 5:
+			return fmt.Errorf("invalid nth weekday of holiday %q", h.Name)
+		}
+	case HolidayEaster:
+	default:
+		return fmt.Errorf("invalid type %q of holiday %q", h.Kind, h.Name)
+	}
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (h Holiday) MarshalJSON() ([]byte, error) {
+	return json.Marshal(h.config())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, validating the
+// resulting Holiday.
+func (h *Holiday) UnmarshalJSON(data []byte) error {
+	var c holidayConfig
+	if err := json.Unmarshal(data, &c); err != nil {
+		return err
+	}
+
+	nh, err := c.holiday()
+	if err != nil {
+		return err
+	}
+	*h = nh
+
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (h Holiday) MarshalYAML() (interface{}, error) {
+	return h.config(), nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface, validating the
+// resulting Holiday.
+func (h *Holiday) UnmarshalYAML(node *yaml.Node) error {
+	var c holidayConfig
+	if err := node.Decode(&c); err != nil {
+		return err
+	}
+
+	nh, err := c.holiday()
+	if err != nil {
+		return &yaml.TypeError{Errors: []string{err.Error()}}
+	}
+	*h = nh
+
+	return nil
+}
+
+func (h Holiday) config() holidayConfig {
+	c := holidayConfig{
+		Name:   h.Name,
+		Kind:   h.Kind,
+		Year:   h.Year,
+		Month:  int(h.Month),
+		Day:    h.Day,
+		Nth:    h.Nth,
+		Offset: h.Offset,
+	}
+	if h.Kind == HolidayWeekday {
+		c.Weekday = weekdayName(h.Weekday)
+	}
+
+	return c
+}
+
+func (c holidayConfig) holiday() (Holiday, error) {
+	h := Holiday{
+		Name:   c.Name,
+		Kind:   c.Kind,
+		Year:   c.Year,
+		Month:  time.Month(c.Month),
+		Day:    c.Day,
+		Nth:    c.Nth,
+		Offset: c.Offset,
+	}
+
+	if c.Weekday != "" {
+		wd, err := parseWeekday(c.Weekday)
+		if err != nil {
+			return Holiday{}, err
+		}
+		h.Weekday = wd
+	}
+
+	if err := h.Validate(); err != nil {
+		return Holiday{}, err
+	}
+
+	return h, nil
+}
+
+// Easter returns the date of Western Easter Sunday in year as midnight UTC,
+// according to the Gregorian calendar.
+func Easter(year int) time.Time {
+	a := year % 19
+	b, c := year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+// nthWeekday returns the date of the nth weekday wd of month m in year y as
+// midnight UTC, counting from the end of the month when n is negative.
+func nthWeekday(
+	y int,
+	m time.Month,
+	wd time.Weekday,
+	n int,
+) (time.Time, bool) {
+	days := daysIn(y, m)
+
+	var d int
+	if n > 0 {
+		first := time.Date(y, m, 1, 0, 0, 0, 0, time.UTC).Weekday()
+		d = 1 + int(wd-first+7)%7 + 7*(n-1)
+	} else {
+		last := time.Date(y, m, days, 0, 0, 0, 0, time.UTC).Weekday()
+		d = days - int(last-wd+7)%7 + 7*(n+1)
+	}
+
+	if d < 1 || d > days {
+		return time.Time{}, false
+	}
+
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC), true
+}
+
+// daysIn returns the number of days in month m of year y.
+func daysIn(y int, m time.Month) int {
+	return time.Date(y, m+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
@@ -0,0 +1,271 @@
+package calendar
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestEaster(t *testing.T) {
+	tests := []struct {
+		year int
+		want time.Time
+	}{
+		{year: 1961, want: date(1961, 4, 2)},
+		{year: 2000, want: date(2000, 4, 23)},
+		{year: 2008, want: date(2008, 3, 23)},
+		{year: 2023, want: date(2023, 4, 9)},
+		{year: 2024, want: date(2024, 3, 31)},
+		{year: 2025, want: date(2025, 4, 20)},
+		{year: 2038, want: date(2038, 4, 25)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want.Format("2006"), func(t *testing.T) {
+			assert.Equal(t, tt.want, Easter(tt.year))
+		})
+	}
+}
+
+func TestHoliday_Date(t *testing.T) {
+	tests := []struct {
+		name    string
+		holiday Holiday
+		year    int
+		want    time.Time
+		wantOK  bool
+	}{
+		{
+			name:    "fixed",
+			holiday: Holiday{Kind: HolidayFixed, Month: 12, Day: 25},
+			year:    2023,
+			want:    date(2023, 12, 25),
+			wantOK:  true,
+		},
+		{
+			name:    "leap day in leap year",
+			holiday: Holiday{Kind: HolidayFixed, Month: 2, Day: 29},
+			year:    2024,
+			want:    date(2024, 2, 29),
+			wantOK:  true,
+		},
+		{
+			name:    "leap day in other year",
+			holiday: Holiday{Kind: HolidayFixed, Month: 2, Day: 29},
+			year:    2023,
+		},
+		{
+			name: "one-off in year",
+			holiday: Holiday{
+				Kind: HolidayFixed, Year: 2023, Month: 5, Day: 8,
+			},
+			year:   2023,
+			want:   date(2023, 5, 8),
+			wantOK: true,
+		},
+		{
+			name: "one-off in other year",
+			holiday: Holiday{
+				Kind: HolidayFixed, Year: 2023, Month: 5, Day: 8,
+			},
+			year: 2024,
+		},
+		{
+			name: "fourth Thursday",
+			holiday: Holiday{
+				Kind: HolidayWeekday, Month: 11, Weekday: time.Thursday, Nth: 4,
+			},
+			year:   2023,
+			want:   date(2023, 11, 23),
+			wantOK: true,
+		},
+		{
+			name: "last Monday",
+			holiday: Holiday{
+				Kind: HolidayWeekday, Month: 5, Weekday: time.Monday, Nth: -1,
+			},
+			year:   2023,
+			want:   date(2023, 5, 29),
+			wantOK: true,
+		},
+		{
+			name: "second to last Sunday",
+			holiday: Holiday{
+				Kind: HolidayWeekday, Month: 9, Weekday: time.Sunday, Nth: -2,
+			},
+			year:   2023,
+			want:   date(2023, 9, 17),
+			wantOK: true,
+		},
+		{
+			name: "missing fifth Monday",
+			holiday: Holiday{
+				Kind: HolidayWeekday, Month: 2, Weekday: time.Monday, Nth: 5,
+			},
+			year: 2023,
+		},
+		{
+			name: "weekday with offset",
+			holiday: Holiday{
+				Kind: HolidayWeekday, Month: 11, Weekday: time.Monday, Nth: 1,
+				Offset: 1,
+			},
+			year:   2023,
+			want:   date(2023, 11, 7),
+			wantOK: true,
+		},
+		{
+			name:    "Good Friday",
+			holiday: Holiday{Kind: HolidayEaster, Offset: -2},
+			year:    2023,
+			want:    date(2023, 4, 7),
+			wantOK:  true,
+		},
+		{
+			name:    "Whit Monday",
+			holiday: Holiday{Kind: HolidayEaster, Offset: 50},
+			year:    2024,
+			want:    date(2024, 5, 20),
+			wantOK:  true,
+		},
+		{
+			name:    "invalid kind",
+			holiday: Holiday{Kind: "lunar"},
+			year:    2023,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.holiday.Date(tt.year)
+
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestHoliday_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		holiday Holiday
+		wantErr string
+	}{
+		{
+			name:    "valid fixed",
+			holiday: Holiday{Kind: HolidayFixed, Month: 2, Day: 29},
+		},
+		{
+			name:    "valid easter",
+			holiday: Holiday{Kind: HolidayEaster},
+		},
+		{
+			name:    "invalid fixed day",
+			holiday: Holiday{Name: "X", Kind: HolidayFixed, Month: 4, Day: 31},
+			wantErr: `invalid date of holiday "X"`,
+		},
+		{
+			name:    "invalid fixed month",
+			holiday: Holiday{Name: "X", Kind: HolidayFixed, Month: 13, Day: 1},
+			wantErr: `invalid date of holiday "X"`,
+		},
+		{
+			name: "invalid weekday month",
+			holiday: Holiday{
+				Name: "X", Kind: HolidayWeekday, Weekday: time.Monday, Nth: 1,
+			},
+			wantErr: `invalid month of holiday "X"`,
+		},
+		{
+			name: "invalid weekday",
+			holiday: Holiday{
+				Name: "X", Kind: HolidayWeekday, Month: 1, Weekday: 7, Nth: 1,
+			},
+			wantErr: `invalid weekday of holiday "X"`,
+		},
+		{
+			name: "invalid nth",
+			holiday: Holiday{
+				Name: "X", Kind: HolidayWeekday, Month: 1, Nth: 6,
+			},
+			wantErr: `invalid nth weekday of holiday "X"`,
+		},
+		{
+			name:    "invalid kind",
+			holiday: Holiday{Name: "X", Kind: "lunar"},
+			wantErr: `invalid type "lunar" of holiday "X"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.holiday.Validate()
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestHoliday_MarshalUnmarshal(t *testing.T) {
+	holidays := []Holiday{
+		{Name: "Christmas Day", Kind: HolidayFixed, Month: 12, Day: 25},
+		{
+			Name: "Mother's Day", Kind: HolidayWeekday, Month: 5,
+			Weekday: time.Sunday, Nth: 2,
+		},
+		{Name: "Good Friday", Kind: HolidayEaster, Offset: -2},
+	}
+	wantJSON := `[` +
+		`{"name":"Christmas Day","type":"fixed","month":12,"day":25},` +
+		`{"name":"Mother's Day","type":"weekday","month":5,` +
+		`"weekday":"sunday","nth":2},` +
+		`{"name":"Good Friday","type":"easter","offset":-2}]`
+	wantYAML := `- name: Christmas Day
+  type: fixed
+  month: 12
+  day: 25
+- name: Mother's Day
+  type: weekday
+  month: 5
+  weekday: sunday
+  nth: 2
+- name: Good Friday
+  type: easter
+  offset: -2
+`
+
+	b, err := json.Marshal(holidays)
+	require.NoError(t, err)
+	assert.Equal(t, wantJSON, string(b))
+
+	var got []Holiday
+	require.NoError(t, json.Unmarshal(b, &got))
+	assert.Equal(t, holidays, got)
+
+	b, err = yaml.Marshal(holidays)
+	require.NoError(t, err)
+	assert.Equal(t, wantYAML, string(b))
+
+	got = nil
+	require.NoError(t, yaml.Unmarshal(b, &got))
+	assert.Equal(t, holidays, got)
+
+	var h Holiday
+	err = json.Unmarshal([]byte(`{"type":"weekday","weekday":"Funday"}`), &h)
+	assert.EqualError(t, err, `invalid weekday "Funday"`)
+
+	err = json.Unmarshal([]byte(`{"name":"X","type":"fixed"}`), &h)
+	assert.EqualError(t, err, `invalid date of holiday "X"`)
+
+	err = yaml.Unmarshal([]byte("name: X\ntype: lunar\n"), &h)
+	assert.ErrorContains(t, err, `invalid type "lunar" of holiday "X"`)
+}
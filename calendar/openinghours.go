@@ -0,0 +1,588 @@
+package calendar
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrInvalidOpeningHours is returned when parsing invalid opening hours.
+var ErrInvalidOpeningHours = errors.New("invalid opening hours")
+
+// openingDays lists the OpenStreetMap abbreviations of the days of the week,
+// indexed by time.Weekday.
+var openingDays = []string{"Su", "Mo", "Tu", "We", "Th", "Fr", "Sa"}
+
+// OpeningHours is a weekly schedule of opening hours. Its text form is a
+// practical subset of the OpenStreetMap opening_hours syntax, made up of
+// rules separated by ";":
+//
+//	Mo-Fr 09:00-17:30; Sa 10:00-14:00
+//	Mo-Th 09:00-12:00,13:00-17:00; Fr 09:00-12:00
+//	Fr,Sa 22:00-03:00
+//	10:00-18:00; Su off
+//	24/7
+//
+// Each rule lists days, as single days, comma-separated lists, or ranges
+// which may wrap around the end of the week like "Sa-Mo", followed by time
+// ranges, or "off" or "closed". Rules without days apply to every day, and
+// later rules replace the times of earlier rules for the days they list. Time
+// ranges which end at or before their start close after midnight on the
+// following day.
+//
+// In JSON/YAML, opening hours can be given in text form, or in an equivalent
+// structured form mapping day names to time ranges, with an optional
+// location:
+//
+//	location: Europe/Oslo
+//	monday: ["09:00-17:30"]
+//	saturday: ["10:00-14:00", "22:00-03:00"]
+//
+// Opening hours are marshaled in text form, unless they have a Location.
+type OpeningHours struct {
+	// Location is the location of the wall clock the opening hours are given
+	// in. When nil, the location of the time given to each method is used.
+	Location *time.Location
+
+	days [7][]TimeRange
+}
+
+type openingHoursConfig struct {
+	Location string
+	Days     map[string][]string
+}
+
+// ParseOpeningHours parses the text form of OpeningHours.
+func ParseOpeningHours(s string) (OpeningHours, error) {
+	var oh OpeningHours
+
+	for _, rule := range strings.Split(s, ";") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+
+		if err := oh.parseRule(rule); err != nil {
+			return OpeningHours{}, fmt.Errorf(
+				"%w %q: %s", ErrInvalidOpeningHours, s, err,
+			)
+		}
+	}
+
+	return oh, nil
+}
+
+// MustParseOpeningHours is like ParseOpeningHours but panics if s cannot be
+// parsed.
+func MustParseOpeningHours(s string) OpeningHours {
+	oh, err := ParseOpeningHours(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return oh
+}
+
+// Hours returns the time ranges opening on day wd, in ascending order. Ranges
+// which close after midnight have an End greater than 24 hours.
+func (oh OpeningHours) Hours(wd time.Weekday) []TimeRange {
+	return append([]TimeRange(nil), oh.days[wd]...)
+}
+
+// String returns the canonical text form of the opening hours, which lists
+// days Monday first, and combines days with the same time ranges.
+func (oh OpeningHours) String() string {
+	always := true
+	for _, ranges := range oh.days {
+		always = always && len(ranges) == 1 &&
+			ranges[0] == TimeRange{Start: 0, End: oneDay}
+	}
+	if always {
+		return "24/7"
+	}
+
+	var rules []string
+	done := [7]bool{}
+	for i := 0; i < 7; i++ {
+		wd := time.Weekday((i + 1) % 7)
+		if done[wd] || len(oh.days[wd]) == 0 {
+			continue
+		}
+
+		times := formatOpeningRanges(oh.days[wd])
+		var same []time.Weekday
+		for j := i; j < 7; j++ {
+			other := time.Weekday((j + 1) % 7)
+			if !done[other] &&
+				formatOpeningRanges(oh.days[other]) == times {
+				same = append(same, other)
+				done[other] = true
+			}
+		}
+
+		rules = append(rules, formatOpeningDays(same)+" "+times)
+	}
+
+	if len(rules) == 0 {
+		return "closed"
+	}
+
+	return strings.Join(rules, "; ")
+}
+
+// IsZero reports whether the opening hours are never open.
+func (oh OpeningHours) IsZero() bool {
+	for _, ranges := range oh.days {
+		if len(ranges) > 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsOpen reports whether the opening hours are open at t.
+func (oh OpeningHours) IsOpen(t time.Time) bool {
+	for _, i := range oh.intervals(t) {
+		if !t.Before(i.start) && t.Before(i.end) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NextOpen returns the earliest time at or after t at which the opening
+// hours are open, which is t itself when they are open at t. It returns the
+// zero time when they are never open.
+func (oh OpeningHours) NextOpen(t time.Time) time.Time {
+	for _, i := range oh.intervals(t) {
+		switch {
+		case !t.Before(i.start) && t.Before(i.end):
+			return t
+		case i.start.After(t):
+			return i.start
+		}
+	}
+
+	return time.Time{}
+}
+
+// NextClose returns the earliest time after t at which the opening hours
+// close, which is the end of the current opening when they are open at t. It
+// returns the zero time when they are never open, or never close.
+func (oh OpeningHours) NextClose(t time.Time) time.Time {
+	if oh.alwaysOpen() {
+		return time.Time{}
+	}
+
+	for _, i := range oh.intervals(t) {
+		if i.end.After(t) {
+			return i.end
+		}
+	}
+
+	return time.Time{}
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (oh OpeningHours) MarshalText() ([]byte, error) {
+	return []byte(oh.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (oh *OpeningHours) UnmarshalText(text []byte) error {
+	noh, err := ParseOpeningHours(string(text))
+	if err != nil {
+		return err
+	}
+	*oh = noh
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (oh OpeningHours) MarshalJSON() ([]byte, error) {
+	if oh.Location == nil {
+		return json.Marshal(oh.String())
+	}
+
+	return json.Marshal(oh.config().fields())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, accepting both the
+// text and structured forms.
+func (oh *OpeningHours) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		return oh.UnmarshalText([]byte(s))
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	var c openingHoursConfig
+	for k, v := range fields {
+		if strings.EqualFold(k, "location") {
+			if err := json.Unmarshal(v, &c.Location); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		var ranges []string
+		if err := json.Unmarshal(v, &ranges); err != nil {
+			return err
+		}
+		c.set(k, ranges)
+	}
+
+	noh, err := c.openingHours()
+	if err != nil {
+		return err
+	}
+	*oh = noh
+
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (oh OpeningHours) MarshalYAML() (interface{}, error) {
+	if oh.Location == nil {
+		return oh.String(), nil
+	}
+
+	return oh.config().fields(), nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface, accepting both the
+// text and structured forms.
+func (oh *OpeningHours) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		if err := oh.UnmarshalText([]byte(node.Value)); err != nil {
+			return &yaml.TypeError{Errors: []string{err.Error()}}
+		}
+
+		return nil
+	}
+
+	var fields map[string]yaml.Node
+	if err := node.Decode(&fields); err != nil {
+		return err
+	}
+
+	var c openingHoursConfig
+	for k, v := range fields {
+		if strings.EqualFold(k, "location") {
+			if err := v.Decode(&c.Location); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		var ranges []string
+		if err := v.Decode(&ranges); err != nil {
+			return err
+		}
+		c.set(k, ranges)
+	}
+
+	noh, err := c.openingHours()
+	if err != nil {
+		return &yaml.TypeError{Errors: []string{err.Error()}}
+	}
+	*oh = noh
+
+	return nil
+}
+
+func (oh OpeningHours) config() openingHoursConfig {
+	c := openingHoursConfig{Days: map[string][]string{}}
+	if oh.Location != nil {
+		c.Location = oh.Location.String()
+	}
+
+	for wd, ranges := range oh.days {
+		for _, r := range ranges {
+			name := weekdayName(time.Weekday(wd))
+			c.Days[name] = append(c.Days[name], formatOpeningRange(r))
+		}
+	}
+
+	return c
+}
+
+func (c openingHoursConfig) fields() map[string]interface{} {
+	fields := map[string]interface{}{"location": c.Location}
+	for k, v := range c.Days {
+		fields[k] = v
+	}
+
+	return fields
+}
+
+func (c *openingHoursConfig) set(day string, ranges []string) {
+	if c.Days == nil {
+		c.Days = map[string][]string{}
+	}
+	c.Days[day] = ranges
+}
+
+func (c openingHoursConfig) openingHours() (OpeningHours, error) {
+	var oh OpeningHours
+	if c.Location != "" {
+		loc, err := time.LoadLocation(c.Location)
+		if err != nil {
+			return OpeningHours{}, fmt.Errorf(
+				"%w: %s", ErrInvalidOpeningHours, err,
+			)
+		}
+		oh.Location = loc
+	}
+
+	for day, ranges := range c.Days {
+		wd, err := parseWeekday(day)
+		if err != nil {
+			return OpeningHours{}, fmt.Errorf(
+				"%w: %s", ErrInvalidOpeningHours, err,
+			)
+		}
+
+		times, err := parseOpeningRanges(strings.Join(ranges, ","))
+		if err != nil {
+			return OpeningHours{}, fmt.Errorf(
+				"%w: %s", ErrInvalidOpeningHours, err,
+			)
+		}
+		oh.days[wd] = times
+	}
+
+	return oh, nil
+}
+
+// parseRule parses a single rule of the text form, applying it to oh.
+func (oh *OpeningHours) parseRule(rule string) error {
+	if rule == "24/7" {
+		for wd := range oh.days {
+			oh.days[wd] = []TimeRange{{Start: 0, End: oneDay}}
+		}
+
+		return nil
+	}
+
+	days := []time.Weekday{
+		time.Sunday, time.Monday, time.Tuesday, time.Wednesday,
+		time.Thursday, time.Friday, time.Saturday,
+	}
+
+	selector, times, ok := strings.Cut(rule, " ")
+	if !ok || (selector != "" && selector[0] >= '0' && selector[0] <= '9') {
+		times = rule
+	} else {
+		var err error
+		if days, err = parseOpeningDays(selector); err != nil {
+			return err
+		}
+	}
+
+	var ranges []TimeRange
+	switch times = strings.TrimSpace(times); times {
+	case "off", "closed":
+	default:
+		var err error
+		if ranges, err = parseOpeningRanges(times); err != nil {
+			return err
+		}
+	}
+
+	for _, wd := range days {
+		oh.days[wd] = ranges
+	}
+
+	return nil
+}
+
+// intervals returns the merged intervals the opening hours are open from the
+// day before t, to two weeks after it, in ascending order.
+func (oh OpeningHours) intervals(t time.Time) []interval {
+	if oh.Location != nil {
+		t = t.In(oh.Location)
+	}
+
+	var intervals []interval
+	day := nextDay(t, false)
+	for i := 0; i < 16; i++ {
+		for _, r := range oh.days[day.Weekday()] {
+			intervals = append(intervals, interval{
+				start: wallTime(day, r.Start),
+				end:   wallTime(day, r.End),
+			})
+		}
+		day = nextDay(day, true)
+	}
+
+	sort.Slice(intervals, func(i, j int) bool {
+		return intervals[i].start.Before(intervals[j].start)
+	})
+
+	var merged []interval
+	for _, i := range intervals {
+		last := len(merged) - 1
+		if last >= 0 && !i.start.After(merged[last].end) {
+			merged[last].end = latest(merged[last].end, i.end)
+
+			continue
+		}
+		merged = append(merged, i)
+	}
+
+	return merged
+}
+
+// alwaysOpen reports whether the opening hours cover every moment of the
+// week.
+func (oh OpeningHours) alwaysOpen() bool {
+	for wd := range oh.days {
+		covered := time.Duration(0)
+		for _, prev := range oh.days[(wd+6)%7] {
+			if prev.End-oneDay > covered {
+				covered = prev.End - oneDay
+			}
+		}
+
+		for _, r := range oh.days[wd] {
+			if r.Start <= covered && r.End > covered {
+				covered = r.End
+			}
+		}
+
+		if covered < oneDay {
+			return false
+		}
+	}
+
+	return true
+}
+
+func parseOpeningDays(s string) ([]time.Weekday, error) {
+	var days []time.Weekday
+	for _, part := range strings.Split(s, ",") {
+		from, to, isRange := strings.Cut(part, "-")
+
+		first, err := parseOpeningDay(from)
+		if err != nil {
+			return nil, err
+		}
+
+		last := first
+		if isRange {
+			if last, err = parseOpeningDay(to); err != nil {
+				return nil, err
+			}
+		}
+
+		for wd := first; ; wd = (wd + 1) % 7 {
+			days = append(days, wd)
+			if wd == last {
+				break
+			}
+		}
+	}
+
+	return days, nil
+}
+
+func parseOpeningDay(s string) (time.Weekday, error) {
+	for i, name := range openingDays {
+		if s == name {
+			return time.Weekday(i), nil
+		}
+	}
+
+	return 0, fmt.Errorf("unsupported day %q", s)
+}
+
+// parseOpeningRanges parses comma-separated time ranges, where ranges ending
+// at or before their start close on the following day.
+func parseOpeningRanges(s string) ([]TimeRange, error) {
+	var ranges []TimeRange
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		start, end, ok := strings.Cut(part, "-")
+		if !ok {
+			return nil, fmt.Errorf("invalid time range %q", part)
+		}
+
+		var r TimeRange
+		var err1, err2 error
+		r.Start, err1 = parseClock(start)
+		r.End, err2 = parseClock(end)
+		if err1 != nil || err2 != nil || r.Start >= oneDay {
+			return nil, fmt.Errorf("invalid time range %q", part)
+		}
+
+		if r.End <= r.Start {
+			r.End += oneDay
+		}
+		ranges = append(ranges, r)
+	}
+
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].Start < ranges[j].Start
+	})
+
+	return ranges, nil
+}
+
+func formatOpeningRanges(ranges []TimeRange) string {
+	s := make([]string, 0, len(ranges))
+	for _, r := range ranges {
+		s = append(s, formatOpeningRange(r))
+	}
+
+	return strings.Join(s, ",")
+}
+
+func formatOpeningRange(r TimeRange) string {
+	end := r.End
+	if end > oneDay {
+		end -= oneDay
+	}
+
+	return formatClock(r.Start) + "-" + formatClock(end)
+}
+
+// formatOpeningDays formats days, given in order from Monday, combining runs
+// of three or more consecutive days into ranges.
+func formatOpeningDays(days []time.Weekday) string {
+	var parts []string
+	for i := 0; i < len(days); {
+		j := i
+		for j+1 < len(days) && days[j+1] == (days[j]+1)%7 {
+			j++
+		}
+
+		switch {
+		case j-i >= 2:
+			parts = append(parts,
+				openingDays[days[i]]+"-"+openingDays[days[j]])
+		case j > i:
+			parts = append(parts,
+				openingDays[days[i]], openingDays[days[j]])
+		default:
+			parts = append(parts, openingDays[days[i]])
+		}
+		i = j + 1
+	}
+
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,345 @@
+package calendar
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestParseOpeningHours(t *testing.T) {
+	tests := []struct {
+		s          string
+		want       string
+		wantMonday []TimeRange
+		wantErr    string
+	}{
+		{
+			s:    "Mo-Fr 09:00-17:30; Sa 10:00-14:00",
+			want: "Mo-Fr 09:00-17:30; Sa 10:00-14:00",
+			wantMonday: []TimeRange{
+				{Start: 9 * time.Hour, End: 17*time.Hour + 30*time.Minute},
+			},
+		},
+		{
+			s:    "Mo-Th 13:00-17:00, 09:00-12:00;Fr 09:00-12:00;",
+			want: "Mo-Th 09:00-12:00,13:00-17:00; Fr 09:00-12:00",
+			wantMonday: []TimeRange{
+				{Start: 9 * time.Hour, End: 12 * time.Hour},
+				{Start: 13 * time.Hour, End: 17 * time.Hour},
+			},
+		},
+		{
+			s:    "Mo,Tu 09:00-17:00; We,Fr 10:00-16:00",
+			want: "Mo,Tu 09:00-17:00; We,Fr 10:00-16:00",
+			wantMonday: []TimeRange{
+				{Start: 9 * time.Hour, End: 17 * time.Hour},
+			},
+		},
+		{
+			s:    "Sa-Mo 10:00-16:00",
+			want: "Mo,Sa,Su 10:00-16:00",
+			wantMonday: []TimeRange{
+				{Start: 10 * time.Hour, End: 16 * time.Hour},
+			},
+		},
+		{
+			s:    "Fr,Sa 22:00-03:00",
+			want: "Fr,Sa 22:00-03:00",
+		},
+		{
+			s:    "10:00-18:00; Su off",
+			want: "Mo-Sa 10:00-18:00",
+			wantMonday: []TimeRange{
+				{Start: 10 * time.Hour, End: 18 * time.Hour},
+			},
+		},
+		{
+			s:          "24/7",
+			want:       "24/7",
+			wantMonday: []TimeRange{{Start: 0, End: 24 * time.Hour}},
+		},
+		{
+			s:          "Mo-Su 00:00-24:00",
+			want:       "24/7",
+			wantMonday: []TimeRange{{Start: 0, End: 24 * time.Hour}},
+		},
+		{s: "closed", want: "closed"},
+		{s: "", want: "closed"},
+		{
+			s:       "PH off",
+			wantErr: `invalid opening hours "PH off": unsupported day "PH"`,
+		},
+		{
+			s: "Mo-Fr 9-5",
+			wantErr: `invalid opening hours "Mo-Fr 9-5": ` +
+				`invalid time range "9-5"`,
+		},
+		{
+			s: "Mo-Fr",
+			wantErr: `invalid opening hours "Mo-Fr": ` +
+				`invalid time range "Mo-Fr"`,
+		},
+		{
+			s: "Mo 24:00-02:00",
+			wantErr: `invalid opening hours "Mo 24:00-02:00": ` +
+				`invalid time range "24:00-02:00"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := ParseOpeningHours(tt.s)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.ErrorIs(t, err, ErrInvalidOpeningHours)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+			assert.Equal(t, tt.wantMonday, got.Hours(time.Monday))
+
+			again, err := ParseOpeningHours(got.String())
+			require.NoError(t, err)
+			assert.Equal(t, got, again)
+		})
+	}
+}
+
+func TestOpeningHours_IsOpenNextOpenNextClose(t *testing.T) {
+	tests := []struct {
+		name          string
+		hours         string
+		t             time.Time
+		wantOpen      bool
+		wantNextOpen  time.Time
+		wantNextClose time.Time
+	}{
+		{
+			name:          "open",
+			hours:         "Mo-Fr 09:00-17:30; Sa 10:00-14:00",
+			t:             at(2023, 10, 16, 10, 0),
+			wantOpen:      true,
+			wantNextOpen:  at(2023, 10, 16, 10, 0),
+			wantNextClose: at(2023, 10, 16, 17, 30),
+		},
+		{
+			name:          "before opening",
+			hours:         "Mo-Fr 09:00-17:30; Sa 10:00-14:00",
+			t:             at(2023, 10, 16, 8, 0),
+			wantNextOpen:  at(2023, 10, 16, 9, 0),
+			wantNextClose: at(2023, 10, 16, 17, 30),
+		},
+		{
+			name:          "at closing",
+			hours:         "Mo-Fr 09:00-17:30; Sa 10:00-14:00",
+			t:             at(2023, 10, 20, 17, 30),
+			wantNextOpen:  at(2023, 10, 21, 10, 0),
+			wantNextClose: at(2023, 10, 21, 14, 0),
+		},
+		{
+			name:          "over weekend",
+			hours:         "Mo-Fr 09:00-17:30; Sa 10:00-14:00",
+			t:             at(2023, 10, 21, 15, 0),
+			wantNextOpen:  at(2023, 10, 23, 9, 0),
+			wantNextClose: at(2023, 10, 23, 17, 30),
+		},
+		{
+			name:          "overnight before midnight",
+			hours:         "Fr,Sa 22:00-03:00",
+			t:             at(2023, 10, 20, 23, 0),
+			wantOpen:      true,
+			wantNextOpen:  at(2023, 10, 20, 23, 0),
+			wantNextClose: at(2023, 10, 21, 3, 0),
+		},
+		{
+			name:          "overnight after midnight",
+			hours:         "Fr,Sa 22:00-03:00",
+			t:             at(2023, 10, 22, 2, 0),
+			wantOpen:      true,
+			wantNextOpen:  at(2023, 10, 22, 2, 0),
+			wantNextClose: at(2023, 10, 22, 3, 0),
+		},
+		{
+			name:          "overnight closed",
+			hours:         "Fr,Sa 22:00-03:00",
+			t:             at(2023, 10, 23, 2, 0),
+			wantNextOpen:  at(2023, 10, 27, 22, 0),
+			wantNextClose: at(2023, 10, 28, 3, 0),
+		},
+		{
+			name:          "adjacent ranges",
+			hours:         "Mo 18:00-24:00; Tu 00:00-02:00",
+			t:             at(2023, 10, 16, 19, 0),
+			wantOpen:      true,
+			wantNextOpen:  at(2023, 10, 16, 19, 0),
+			wantNextClose: at(2023, 10, 17, 2, 0),
+		},
+		{
+			name:         "always open",
+			hours:        "24/7",
+			t:            at(2023, 10, 16, 19, 0),
+			wantOpen:     true,
+			wantNextOpen: at(2023, 10, 16, 19, 0),
+		},
+		{
+			name:         "always open overnight",
+			hours:        "12:00-12:00",
+			t:            at(2023, 10, 16, 19, 0),
+			wantOpen:     true,
+			wantNextOpen: at(2023, 10, 16, 19, 0),
+		},
+		{
+			name:  "never open",
+			hours: "off",
+			t:     at(2023, 10, 16, 19, 0),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oh := MustParseOpeningHours(tt.hours)
+
+			assert.Equal(t, tt.wantOpen, oh.IsOpen(tt.t))
+			assert.Equal(t, tt.wantNextOpen, oh.NextOpen(tt.t))
+			assert.Equal(t, tt.wantNextClose, oh.NextClose(tt.t))
+		})
+	}
+}
+
+func TestOpeningHours_Location(t *testing.T) {
+	nyc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	oh := MustParseOpeningHours("Mo-Fr 09:00-17:00")
+	oh.Location = nyc
+
+	assert.True(t, oh.IsOpen(at(2023, 10, 16, 13, 30)))
+	assert.False(t, oh.IsOpen(at(2023, 10, 16, 12, 30)))
+	assert.True(t,
+		at(2023, 10, 16, 21, 0).Equal(oh.NextClose(at(2023, 10, 16, 13, 30))),
+	)
+	assert.True(t,
+		at(2023, 10, 17, 13, 0).Equal(oh.NextOpen(at(2023, 10, 16, 22, 0))),
+	)
+}
+
+func TestOpeningHours_LocationOvernight(t *testing.T) {
+	nyc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	oh := MustParseOpeningHours("Fr,Sa 22:00-03:00")
+	oh.Location = nyc
+
+	// Saturday night spans the end of daylight saving time, so it closes
+	// six hours after opening, at 03:00 EST.
+	tests := []struct {
+		name          string
+		t             time.Time
+		wantOpen      bool
+		wantNextOpen  time.Time
+		wantNextClose time.Time
+	}{
+		{
+			name:          "before midnight",
+			t:             at(2023, 11, 5, 3, 0),
+			wantOpen:      true,
+			wantNextOpen:  at(2023, 11, 5, 3, 0),
+			wantNextClose: at(2023, 11, 5, 8, 0),
+		},
+		{
+			name:          "after midnight",
+			t:             at(2023, 11, 5, 7, 30),
+			wantOpen:      true,
+			wantNextOpen:  at(2023, 11, 5, 7, 30),
+			wantNextClose: at(2023, 11, 5, 8, 0),
+		},
+		{
+			name:          "after closing",
+			t:             at(2023, 11, 5, 8, 0),
+			wantNextOpen:  at(2023, 11, 11, 3, 0),
+			wantNextClose: at(2023, 11, 11, 8, 0),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantOpen, oh.IsOpen(tt.t))
+			assert.True(t, tt.wantNextOpen.Equal(oh.NextOpen(tt.t)))
+			assert.True(t, tt.wantNextClose.Equal(oh.NextClose(tt.t)))
+		})
+	}
+}
+
+func TestMustParseOpeningHours(t *testing.T) {
+	assert.PanicsWithError(t,
+		`invalid opening hours "Xy 09:00-17:00": unsupported day "Xy"`,
+		func() { MustParseOpeningHours("Xy 09:00-17:00") },
+	)
+}
+
+func TestOpeningHours_MarshalUnmarshal(t *testing.T) {
+	type store struct {
+		Hours OpeningHours `json:"hours" yaml:"hours"`
+	}
+
+	var s store
+	err := json.Unmarshal([]byte(`{"hours":"Mo-Fr 09:00-17:30"}`), &s)
+	require.NoError(t, err)
+	assert.Equal(t, "Mo-Fr 09:00-17:30", s.Hours.String())
+
+	b, err := json.Marshal(s)
+	require.NoError(t, err)
+	assert.Equal(t, `{"hours":"Mo-Fr 09:00-17:30"}`, string(b))
+
+	b, err = yaml.Marshal(s)
+	require.NoError(t, err)
+	assert.Equal(t, "hours: Mo-Fr 09:00-17:30\n", string(b))
+
+	s = store{}
+	err = yaml.Unmarshal([]byte(`hours:
+  location: America/New_York
+  monday: ["09:00-17:00"]
+  sat: ["22:00-03:00"]
+`), &s)
+	require.NoError(t, err)
+	assert.Equal(t, "America/New_York", s.Hours.Location.String())
+	assert.Equal(t, "Mo 09:00-17:00; Sa 22:00-03:00", s.Hours.String())
+
+	b, err = json.Marshal(s)
+	require.NoError(t, err)
+	want := `{"hours":{"location":"America/New_York",` +
+		`"monday":["09:00-17:00"],"saturday":["22:00-03:00"]}}`
+	assert.Equal(t, want, string(b))
+
+	var got store
+	require.NoError(t, json.Unmarshal(b, &got))
+	assert.Equal(t, s, got)
+
+	b, err = yaml.Marshal(s)
+	require.NoError(t, err)
+	assert.Equal(t, `hours:
+    location: America/New_York
+    monday:
+        - 09:00-17:00
+    saturday:
+        - 22:00-03:00
+`, string(b))
+
+	err = json.Unmarshal([]byte(`{"hours":"Mo-Fr 9-5"}`), &s)
+	assert.ErrorIs(t, err, ErrInvalidOpeningHours)
+
+	err = json.Unmarshal([]byte(`{"hours":{"someday":["09:00-17:00"]}}`), &s)
+	assert.EqualError(t, err,
+		`invalid opening hours: invalid weekday "someday"`,
+	)
+
+	err = yaml.Unmarshal([]byte("hours: {monday: [9-5]}\n"), &s)
+	assert.ErrorContains(t, err, `invalid time range "9-5"`)
+
+	err = yaml.Unmarshal([]byte("hours: Mo 9-5\n"), &s)
+	assert.ErrorContains(t, err, `invalid time range "9-5"`)
+}
@@ -0,0 +1,146 @@
+package calendar
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrInvalidTimeRange is returned when parsing an invalid time range.
+var ErrInvalidTimeRange = errors.New("invalid time range")
+
+const oneDay = 24 * time.Hour
+
+// TimeRange is a range of wall clock time within a day, including Start and
+// excluding End, both given as the time since midnight. Its text form is
+// "HH:MM-HH:MM", with "24:00" as the end of the day:
+//
+//	09:00-17:30
+type TimeRange struct {
+	Start time.Duration
+	End   time.Duration
+}
+
+// ParseTimeRange parses a TimeRange from its text form.
+func ParseTimeRange(s string) (TimeRange, error) {
+	start, end, ok := strings.Cut(strings.TrimSpace(s), "-")
+	if !ok {
+		return TimeRange{}, fmt.Errorf("%w: %q", ErrInvalidTimeRange, s)
+	}
+
+	var r TimeRange
+	var err error
+	if r.Start, err = parseClock(start); err != nil {
+		return TimeRange{}, fmt.Errorf("%w: %q", ErrInvalidTimeRange, s)
+	}
+	if r.End, err = parseClock(end); err != nil {
+		return TimeRange{}, fmt.Errorf("%w: %q", ErrInvalidTimeRange, s)
+	}
+
+	if err := r.Validate(); err != nil {
+		return TimeRange{}, err
+	}
+
+	return r, nil
+}
+
+// String returns the text form of the TimeRange.
+func (r TimeRange) String() string {
+	return formatClock(r.Start) + "-" + formatClock(r.End)
+}
+
+// Duration returns the length of the TimeRange.
+func (r TimeRange) Duration() time.Duration {
+	return r.End - r.Start
+}
+
+// Validate returns an error if the TimeRange does not start before it ends,
+// or is not within a single day.
+func (r TimeRange) Validate() error {
+	if r.Start < 0 || r.End > oneDay || r.Start >= r.End {
+		return fmt.Errorf("%w: %q", ErrInvalidTimeRange, r.String())
+	}
+
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (r TimeRange) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (r *TimeRange) UnmarshalText(text []byte) error {
+	nr, err := ParseTimeRange(string(text))
+	if err != nil {
+		return err
+	}
+	*r = nr
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (r TimeRange) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (r *TimeRange) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	return r.UnmarshalText([]byte(s))
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (r TimeRange) MarshalYAML() (interface{}, error) {
+	return r.String(), nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (r *TimeRange) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.ScalarNode {
+		return &yaml.TypeError{Errors: []string{ErrInvalidTimeRange.Error()}}
+	}
+
+	if err := r.UnmarshalText([]byte(node.Value)); err != nil {
+		return &yaml.TypeError{Errors: []string{err.Error()}}
+	}
+
+	return nil
+}
+
+// parseClock parses a "HH:MM" wall clock time into the time since midnight.
+func parseClock(s string) (time.Duration, error) {
+	hs, ms, ok := strings.Cut(s, ":")
+	if !ok || len(hs) != 2 || len(ms) != 2 {
+		return 0, errors.New("invalid clock time")
+	}
+
+	h, err := strconv.Atoi(hs)
+	if err != nil {
+		return 0, err
+	}
+	m, err := strconv.Atoi(ms)
+	if err != nil {
+		return 0, err
+	}
+
+	if h < 0 || m < 0 || m > 59 || h > 24 || (h == 24 && m > 0) {
+		return 0, errors.New("invalid clock time")
+	}
+
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute, nil
+}
+
+func formatClock(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", d/time.Hour, d%time.Hour/time.Minute)
+}
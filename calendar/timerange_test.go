@@ -0,0 +1,97 @@
+package calendar
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestParseTimeRange(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    TimeRange
+		wantErr string
+	}{
+		{
+			s: "09:00-17:30",
+			want: TimeRange{
+				Start: 9 * time.Hour,
+				End:   17*time.Hour + 30*time.Minute,
+			},
+		},
+		{
+			s:    "00:00-24:00",
+			want: TimeRange{Start: 0, End: 24 * time.Hour},
+		},
+		{
+			s: " 13:15-14:45 ",
+			want: TimeRange{
+				Start: 13*time.Hour + 15*time.Minute,
+				End:   14*time.Hour + 45*time.Minute,
+			},
+		},
+		{s: "17:00-09:00", wantErr: `invalid time range: "17:00-09:00"`},
+		{s: "09:00-09:00", wantErr: `invalid time range: "09:00-09:00"`},
+		{s: "9:00-17:00", wantErr: `invalid time range: "9:00-17:00"`},
+		{s: "09:00", wantErr: `invalid time range: "09:00"`},
+		{s: "09:60-10:00", wantErr: `invalid time range: "09:60-10:00"`},
+		{s: "09:00-24:30", wantErr: `invalid time range: "09:00-24:30"`},
+		{s: "", wantErr: `invalid time range: ""`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := ParseTimeRange(tt.s)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.ErrorIs(t, err, ErrInvalidTimeRange)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestTimeRange_String(t *testing.T) {
+	r := TimeRange{Start: 8*time.Hour + 5*time.Minute, End: 24 * time.Hour}
+
+	assert.Equal(t, "08:05-24:00", r.String())
+	assert.Equal(t, 15*time.Hour+55*time.Minute, r.Duration())
+}
+
+func TestTimeRange_MarshalUnmarshal(t *testing.T) {
+	type hours struct {
+		Open TimeRange `json:"open" yaml:"open"`
+	}
+	want := hours{Open: TimeRange{Start: 9 * time.Hour, End: 17 * time.Hour}}
+
+	b, err := json.Marshal(want)
+	require.NoError(t, err)
+	assert.Equal(t, `{"open":"09:00-17:00"}`, string(b))
+
+	var got hours
+	require.NoError(t, json.Unmarshal(b, &got))
+	assert.Equal(t, want, got)
+
+	b, err = yaml.Marshal(want)
+	require.NoError(t, err)
+	assert.Equal(t, "open: 09:00-17:00\n", string(b))
+
+	got = hours{}
+	require.NoError(t, yaml.Unmarshal(b, &got))
+	assert.Equal(t, want, got)
+
+	err = json.Unmarshal([]byte(`{"open":"17:00-09:00"}`), &got)
+	assert.ErrorIs(t, err, ErrInvalidTimeRange)
+
+	err = yaml.Unmarshal([]byte("open: 17:00-09:00\n"), &got)
+	assert.ErrorContains(t, err, "invalid time range")
+
+	err = yaml.Unmarshal([]byte("open: [1]\n"), &got)
+	assert.ErrorContains(t, err, "invalid time range")
+}
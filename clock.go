@@ -0,0 +1,357 @@
+package tyme
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock provides the current time and timers, allowing code which depends on
+// the passage of time to be tested with a FakeClock.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// Since returns the time elapsed since t.
+	Since(t time.Time) time.Duration
+
+	// After waits for the duration to elapse and then sends the current time
+	// on the returned channel.
+	After(d time.Duration) <-chan time.Time
+
+	// NewTimer creates a new Timer that will send the current time on its
+	// channel after at least duration d.
+	NewTimer(d time.Duration) Timer
+
+	// NewTicker returns a new Ticker that sends the current time on its
+	// channel every period d. It panics if d is not greater than zero.
+	NewTicker(d time.Duration) Ticker
+
+	// Sleep pauses the current goroutine for at least the duration d.
+	Sleep(d time.Duration)
+}
+
+// Timer is a single event timer created by a Clock, equivalent to
+// time.Timer.
+type Timer interface {
+	// C returns the channel on which the time is delivered.
+	C() <-chan time.Time
+
+	// Stop prevents the Timer from firing. It returns true if the call stops
+	// the timer, false if the timer has already expired or been stopped.
+	Stop() bool
+
+	// Reset changes the timer to expire after duration d. It returns true if
+	// the timer had been active, false if the timer had expired or been
+	// stopped.
+	Reset(d time.Duration) bool
+}
+
+// Ticker delivers ticks at intervals, created by a Clock, equivalent to
+// time.Ticker.
+type Ticker interface {
+	// C returns the channel on which the ticks are delivered.
+	C() <-chan time.Time
+
+	// Stop turns off the ticker.
+	Stop()
+
+	// Reset stops the ticker and resets its period to the duration d.
+	Reset(d time.Duration)
+}
+
+// RealClock is a Clock backed by the time package.
+type RealClock struct{}
+
+var _ Clock = RealClock{}
+
+// Now returns time.Now.
+func (RealClock) Now() time.Time {
+	return time.Now()
+}
+
+// Since returns time.Since.
+func (RealClock) Since(t time.Time) time.Duration {
+	return time.Since(t)
+}
+
+// After returns time.After.
+func (RealClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// NewTimer returns a Timer backed by time.NewTimer.
+func (RealClock) NewTimer(d time.Duration) Timer {
+	return realTimer{time.NewTimer(d)}
+}
+
+// NewTicker returns a Ticker backed by time.NewTicker.
+func (RealClock) NewTicker(d time.Duration) Ticker {
+	return realTicker{time.NewTicker(d)}
+}
+
+// Sleep calls time.Sleep.
+func (RealClock) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+type realTimer struct {
+	*time.Timer
+}
+
+func (t realTimer) C() <-chan time.Time {
+	return t.Timer.C
+}
+
+type realTicker struct {
+	*time.Ticker
+}
+
+func (t realTicker) C() <-chan time.Time {
+	return t.Ticker.C
+}
+
+// NowAs returns the current time of clk as a Timestamp of type T. When clk is
+// nil, RealClock is used.
+//
+//	ms := tyme.NowAs[ts.Millisecond](clk)
+func NowAs[T Timestamp](clk Clock) T {
+	if clk == nil {
+		clk = RealClock{}
+	}
+
+	return T(clk.Now())
+}
+
+type clockContextKey struct{}
+
+// WithClock returns a copy of ctx carrying clk, which can be retrieved with
+// ClockFromContext.
+func WithClock(ctx context.Context, clk Clock) context.Context {
+	return context.WithValue(ctx, clockContextKey{}, clk)
+}
+
+// ClockFromContext returns the Clock carried by ctx, or RealClock if ctx does
+// not carry a Clock.
+func ClockFromContext(ctx context.Context) Clock {
+	if clk, ok := ctx.Value(clockContextKey{}).(Clock); ok && clk != nil {
+		return clk
+	}
+
+	return RealClock{}
+}
+
+// FakeClock is a Clock whose time only changes when Advance or Set is called.
+// Timers, tickers and sleepers fire synchronously during Advance and Set, in
+// order of their deadlines, making tests deterministic. It is safe for
+// concurrent use.
+type FakeClock struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	now    time.Time
+	seq    int
+	timers []*fakeTimer
+}
+
+var _ Clock = (*FakeClock)(nil)
+
+// NewFakeClock returns a FakeClock with its current time set to now.
+func NewFakeClock(now time.Time) *FakeClock {
+	c := &FakeClock{now: now}
+	c.cond = sync.NewCond(&c.mu)
+
+	return c
+}
+
+// Now returns the current time of the fake clock.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// Since returns the time elapsed since t according to the fake clock.
+func (c *FakeClock) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
+
+// After returns a channel which receives the fake clock's time once it has
+// been advanced by at least d.
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	return c.NewTimer(d).C()
+}
+
+// NewTimer returns a Timer which fires once the fake clock has been advanced
+// by at least d. Timers with a duration of zero or less fire immediately.
+func (c *FakeClock) NewTimer(d time.Duration) Timer {
+	t := &fakeTimer{clock: c, c: make(chan time.Time, 1)}
+	t.Reset(d)
+
+	return t
+}
+
+// NewTicker returns a Ticker which fires every time the fake clock has been
+// advanced by d. It panics if d is not greater than zero.
+func (c *FakeClock) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("tyme: non-positive interval for FakeClock.NewTicker")
+	}
+
+	t := &fakeTimer{clock: c, c: make(chan time.Time, 1), period: d}
+	t.Reset(d)
+
+	return fakeTicker{t}
+}
+
+// Sleep blocks until the fake clock has been advanced by at least d.
+func (c *FakeClock) Sleep(d time.Duration) {
+	<-c.After(d)
+}
+
+// Advance moves the fake clock forward by d, firing all timers, tickers and
+// sleepers which become due, in order of their deadlines.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.advanceTo(c.now.Add(d))
+}
+
+// Set moves the fake clock to t, firing all timers, tickers and sleepers which
+// become due. Moving the clock backwards does not fire anything.
+func (c *FakeClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.advanceTo(t)
+}
+
+// BlockUntil blocks until at least n timers, tickers or sleepers are waiting
+// on the fake clock. It is useful to ensure a goroutine is sleeping before
+// calling Advance.
+func (c *FakeClock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for len(c.timers) < n {
+		c.cond.Wait()
+	}
+}
+
+func (c *FakeClock) advanceTo(target time.Time) {
+	for len(c.timers) > 0 && !c.timers[0].when.After(target) {
+		t := c.timers[0]
+		if t.when.After(c.now) {
+			c.now = t.when
+		}
+
+		select {
+		case t.c <- c.now:
+		default:
+		}
+
+		if t.period > 0 {
+			t.when = t.when.Add(t.period)
+			c.sortTimers()
+		} else {
+			c.removeTimer(t)
+		}
+	}
+
+	c.now = target
+}
+
+func (c *FakeClock) addTimer(t *fakeTimer) {
+	c.seq++
+	t.seq = c.seq
+	c.timers = append(c.timers, t)
+	c.sortTimers()
+	c.cond.Broadcast()
+}
+
+func (c *FakeClock) removeTimer(t *fakeTimer) bool {
+	for i, v := range c.timers {
+		if v == t {
+			c.timers = append(c.timers[:i], c.timers[i+1:]...)
+			c.cond.Broadcast()
+
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *FakeClock) sortTimers() {
+	sort.SliceStable(c.timers, func(i, j int) bool {
+		if c.timers[i].when.Equal(c.timers[j].when) {
+			return c.timers[i].seq < c.timers[j].seq
+		}
+
+		return c.timers[i].when.Before(c.timers[j].when)
+	})
+}
+
+type fakeTimer struct {
+	clock  *FakeClock
+	c      chan time.Time
+	when   time.Time
+	period time.Duration
+	seq    int
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	return t.clock.removeTimer(t)
+}
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	c := t.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	active := c.removeTimer(t)
+	t.when = c.now.Add(d)
+	if d > 0 {
+		c.addTimer(t)
+	} else {
+		select {
+		case t.c <- c.now:
+		default:
+		}
+	}
+
+	return active
+}
+
+type fakeTicker struct {
+	t *fakeTimer
+}
+
+func (t fakeTicker) C() <-chan time.Time {
+	return t.t.c
+}
+
+func (t fakeTicker) Stop() {
+	t.t.Stop()
+}
+
+func (t fakeTicker) Reset(d time.Duration) {
+	if d <= 0 {
+		panic("tyme: non-positive interval for FakeClock.Ticker.Reset")
+	}
+
+	t.t.clock.mu.Lock()
+	t.t.period = d
+	t.t.clock.mu.Unlock()
+
+	t.t.Reset(d)
+}
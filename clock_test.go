@@ -0,0 +1,167 @@
+package tyme
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jimeh/go-tyme/ts"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRealClock(t *testing.T) {
+	var clk Clock = RealClock{}
+
+	before := time.Now()
+	now := clk.Now()
+
+	assert.False(t, now.Before(before))
+	assert.GreaterOrEqual(t, clk.Since(before), time.Duration(0))
+
+	timer := clk.NewTimer(time.Millisecond)
+	<-timer.C()
+	assert.False(t, timer.Stop())
+
+	ticker := clk.NewTicker(time.Millisecond)
+	<-ticker.C()
+	ticker.Stop()
+
+	<-clk.After(time.Millisecond)
+	clk.Sleep(time.Millisecond)
+}
+
+func TestNowAs(t *testing.T) {
+	clk := NewFakeClock(utc8)
+
+	got := NowAs[ts.Millisecond](clk)
+
+	assert.IsType(t, ts.Millisecond{}, got)
+	assert.Equal(t, utc8, got.Time())
+
+	before := time.Now()
+	real := NowAs[Time](nil)
+
+	assert.False(t, time.Time(real).Before(before))
+}
+
+func TestClockContext(t *testing.T) {
+	ctx := context.Background()
+
+	assert.Equal(t, RealClock{}, ClockFromContext(ctx))
+
+	clk := NewFakeClock(utc)
+	ctx = WithClock(ctx, clk)
+
+	assert.Same(t, clk, ClockFromContext(ctx))
+	assert.Equal(t, RealClock{}, ClockFromContext(WithClock(ctx, nil)))
+}
+
+func TestFakeClock_Advance(t *testing.T) {
+	clk := NewFakeClock(utc)
+
+	clk.Advance(time.Hour)
+
+	assert.Equal(t, utc.Add(time.Hour), clk.Now())
+	assert.Equal(t, 30*time.Minute, clk.Since(utc.Add(30*time.Minute)))
+
+	clk.Set(utc)
+
+	assert.Equal(t, utc, clk.Now())
+}
+
+func TestFakeClock_Timer(t *testing.T) {
+	clk := NewFakeClock(utc)
+	timer := clk.NewTimer(time.Minute)
+
+	clk.Advance(59 * time.Second)
+	assertNoTick(t, timer.C())
+
+	clk.Advance(2 * time.Second)
+	assert.Equal(t, utc.Add(time.Minute), <-timer.C())
+	assert.Equal(t, utc.Add(61*time.Second), clk.Now())
+	assert.False(t, timer.Stop())
+
+	assert.False(t, timer.Reset(time.Second))
+	assert.True(t, timer.Reset(time.Minute))
+	assert.True(t, timer.Stop())
+
+	clk.Advance(time.Hour)
+	assertNoTick(t, timer.C())
+}
+
+func TestFakeClock_TimerZeroDuration(t *testing.T) {
+	clk := NewFakeClock(utc)
+
+	assert.Equal(t, utc, <-clk.NewTimer(0).C())
+	assert.Equal(t, utc, <-clk.After(-time.Second))
+}
+
+func TestFakeClock_TimersFireInOrder(t *testing.T) {
+	clk := NewFakeClock(utc)
+	fired := make(chan int, 3)
+
+	for i, d := range []time.Duration{3, 1, 2} {
+		timer := clk.NewTimer(d * time.Second)
+		go func(i int) {
+			<-timer.C()
+			fired <- i
+		}(i)
+	}
+
+	clk.Advance(time.Second)
+	assert.Equal(t, 1, <-fired)
+	clk.Advance(time.Second)
+	assert.Equal(t, 2, <-fired)
+	clk.Advance(time.Second)
+	assert.Equal(t, 0, <-fired)
+}
+
+func TestFakeClock_Ticker(t *testing.T) {
+	clk := NewFakeClock(utc)
+	ticker := clk.NewTicker(10 * time.Second)
+
+	clk.Advance(10 * time.Second)
+	assert.Equal(t, utc.Add(10*time.Second), <-ticker.C())
+
+	// Ticks are dropped when the channel is full, like time.Ticker.
+	clk.Advance(30 * time.Second)
+	assert.Equal(t, utc.Add(20*time.Second), <-ticker.C())
+	assertNoTick(t, ticker.C())
+
+	ticker.Reset(time.Minute)
+	clk.Advance(59 * time.Second)
+	assertNoTick(t, ticker.C())
+	clk.Advance(time.Second)
+	assert.Equal(t, utc.Add(100*time.Second), <-ticker.C())
+
+	ticker.Stop()
+	clk.Advance(time.Hour)
+	assertNoTick(t, ticker.C())
+
+	assert.Panics(t, func() { clk.NewTicker(0) })
+}
+
+func TestFakeClock_Sleep(t *testing.T) {
+	clk := NewFakeClock(utc)
+	done := make(chan time.Time)
+
+	go func() {
+		clk.Sleep(time.Minute)
+		done <- clk.Now()
+	}()
+
+	clk.BlockUntil(1)
+	clk.Advance(time.Minute)
+
+	assert.Equal(t, utc.Add(time.Minute), <-done)
+}
+
+func assertNoTick(t *testing.T, c <-chan time.Time) {
+	t.Helper()
+
+	select {
+	case v := <-c:
+		t.Errorf("unexpected tick at %s", v)
+	default:
+	}
+}
@@ -0,0 +1,75 @@
+package tyme
+
+import (
+	"time"
+
+	"github.com/jimeh/go-tyme/dur"
+	"github.com/jimeh/go-tyme/ts"
+)
+
+// Timestamp is a type constraint that matches against Time, TimeRFC3339,
+// TimeSecond, TimeMilli, TimeMicro, TimeNano, and all types matched by
+// ts.Timestamp, including time.Time.
+type Timestamp interface {
+	ts.Timestamp |
+		Time | TimeRFC3339 | TimeSecond | TimeMilli | TimeMicro | TimeNano
+}
+
+// Convert returns the Timestamp from converted to the Timestamp type To,
+// retaining the same instant and location.
+//
+//	ms := tyme.Convert[ts.Millisecond](tyme.Time(t))
+func Convert[To, From Timestamp](from From) To {
+	return To(time.Time(from))
+}
+
+// Now returns the current local time as a Timestamp of type T.
+func Now[T Timestamp]() T {
+	return T(time.Now())
+}
+
+// Since returns the dur.Duration elapsed since the Timestamp t.
+func Since[T Timestamp](t T) dur.Duration {
+	return dur.Duration(time.Since(time.Time(t)))
+}
+
+// Until returns the dur.Duration until the Timestamp t.
+func Until[T Timestamp](t T) dur.Duration {
+	return dur.Duration(time.Until(time.Time(t)))
+}
+
+// Min returns the earliest of the given Timestamps.
+func Min[T Timestamp](t T, more ...T) T {
+	for _, u := range more {
+		if time.Time(u).Before(time.Time(t)) {
+			t = u
+		}
+	}
+
+	return t
+}
+
+// Max returns the latest of the given Timestamps.
+func Max[T Timestamp](t T, more ...T) T {
+	for _, u := range more {
+		if time.Time(u).After(time.Time(t)) {
+			t = u
+		}
+	}
+
+	return t
+}
+
+// Compare compares the instants of the Timestamps t and u, which may be of
+// different types. It returns -1 if t is before u, +1 if t is after u, and 0
+// if they represent the same instant.
+func Compare[T, U Timestamp](t T, u U) int {
+	switch tt, ut := time.Time(t), time.Time(u); {
+	case tt.Before(ut):
+		return -1
+	case tt.After(ut):
+		return 1
+	default:
+		return 0
+	}
+}
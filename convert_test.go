@@ -0,0 +1,93 @@
+package tyme
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jimeh/go-tyme/dur"
+	"github.com/jimeh/go-tyme/ts"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvert(t *testing.T) {
+	ms := Convert[ts.Millisecond](Time(utc8))
+
+	assert.IsType(t, ts.Millisecond{}, ms)
+	assert.Equal(t, utc8, ms.Time())
+
+	rt := Convert[TimeRFC3339](ms)
+
+	assert.IsType(t, TimeRFC3339{}, rt)
+	assert.Equal(t, utc8, time.Time(rt))
+
+	tt := Convert[time.Time](TimeMilli(utc))
+
+	assert.Equal(t, utc, tt)
+}
+
+func TestNow(t *testing.T) {
+	before := time.Now()
+	got := Now[ts.Second]()
+	after := time.Now()
+
+	assert.IsType(t, ts.Second{}, got)
+	assert.False(t, got.Time().Before(before))
+	assert.False(t, got.Time().After(after))
+}
+
+func TestSinceUntil(t *testing.T) {
+	past := TimeRFC3339(time.Now().Add(-time.Hour))
+	future := ts.Nanosecond(time.Now().Add(time.Hour))
+
+	since := Since(past)
+	until := Until(future)
+
+	assert.IsType(t, dur.Duration(0), since)
+	assert.InDelta(t, time.Hour, time.Duration(since), float64(time.Minute))
+	assert.InDelta(t, time.Hour, time.Duration(until), float64(time.Minute))
+}
+
+func TestMinMax(t *testing.T) {
+	a := Time(utc)
+	b := Time(utc.Add(time.Hour))
+	c := Time(utc.Add(-time.Hour))
+
+	assert.Equal(t, a, Min(a))
+	assert.Equal(t, a, Max(a))
+	assert.Equal(t, c, Min(a, b, c))
+	assert.Equal(t, b, Max(a, b, c))
+
+	equal := Time(utc8)
+
+	assert.Equal(t, a, Min(a, equal))
+	assert.Equal(t, a, Max(a, equal))
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name string
+		got  int
+		want int
+	}{
+		{
+			name: "before",
+			got:  Compare(Time(utc), ts.Second(utc.Add(time.Second))),
+			want: -1,
+		},
+		{
+			name: "after",
+			got:  Compare(TimeNano(utc), utc.Add(-time.Nanosecond)),
+			want: 1,
+		},
+		{
+			name: "equal in different locations",
+			got:  Compare(TimeRFC3339(utc), ts.FileTime(utc8)),
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.got)
+		})
+	}
+}
@@ -0,0 +1,625 @@
+package tyme
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jimeh/go-tyme/dur"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrInvalidCron is returned when parsing an invalid cron expression.
+var ErrInvalidCron = errors.New("invalid cron expression")
+
+// cronSearchYears is the number of years Cron.Next and Cron.Prev search for
+// an occurrence before giving up and returning the zero time.
+const cronSearchYears = 10
+
+// cronShift is the largest change in UTC offset caused by daylight saving
+// time transitions which Cron accounts for.
+const cronShift = 3 * time.Hour
+
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+type cronField struct {
+	name  string
+	min   int
+	max   int
+	top   int
+	names []string
+}
+
+var (
+	cronSecond = cronField{name: "second", min: 0, max: 59}
+	cronMinute = cronField{name: "minute", min: 0, max: 59}
+	cronHour   = cronField{name: "hour", min: 0, max: 23}
+	cronDom    = cronField{name: "day of month", min: 1, max: 31}
+	cronMonth  = cronField{
+		name: "month", min: 1, max: 12,
+		names: []string{
+			"", "JAN", "FEB", "MAR", "APR", "MAY", "JUN",
+			"JUL", "AUG", "SEP", "OCT", "NOV", "DEC",
+		},
+	}
+	cronDow = cronField{
+		name: "day of week", min: 0, max: 7, top: 6,
+		names: []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"},
+	}
+)
+
+// Cron is a schedule described by a cron expression. It supports standard
+// 5-field expressions (minute, hour, day of month, month, day of week), and
+// 6-field expressions with a leading seconds field. Fields support "*", "?",
+// lists, ranges, steps, and month and weekday names. Day of week 7 is an
+// alias for Sunday. As in most cron implementations, when both day of month
+// and day of week are restricted, a day matches if either of them match.
+//
+// The descriptors @yearly, @annually, @monthly, @weekly, @daily, @midnight
+// and @hourly are also supported, as is "@every <duration>", which parses the
+// duration with dur.Parse, and matches every multiple of it since the Unix
+// epoch.
+//
+// Expressions are evaluated against the wall clock of the location given by
+// an optional "CRON_TZ=<zone>" or "TZ=<zone>" prefix, or the location of the
+// time passed to Next or Prev otherwise. Daylight saving time transitions are
+// handled like Vixie cron: when the hour field is "*", occurrences follow the
+// wall clock, so skipped wall times do not occur, and repeated wall times
+// occur twice. Otherwise, occurrences at skipped wall times happen once at the
+// instant of the transition, and repeated wall times only occur the first
+// time.
+//
+// It marshals to and unmarshals from a JSON/YAML string, validating the
+// expression on unmarshal.
+type Cron struct {
+	expr     string
+	loc      *time.Location
+	every    time.Duration
+	second   uint64
+	minute   uint64
+	hour     uint64
+	dom      uint64
+	month    uint64
+	dow      uint64
+	domStar  bool
+	dowStar  bool
+	hourStar bool
+}
+
+// ParseCron parses a cron expression. It returns an error wrapping
+// ErrInvalidCron if the expression is invalid, or can never match.
+func ParseCron(expr string) (Cron, error) {
+	c := Cron{expr: strings.TrimSpace(expr)}
+	s := c.expr
+
+	if strings.HasPrefix(s, "CRON_TZ=") || strings.HasPrefix(s, "TZ=") {
+		i := strings.IndexAny(s, " \t")
+		if i < 0 {
+			return Cron{}, cronError(expr, "missing schedule after time zone")
+		}
+
+		name := s[strings.IndexByte(s, '=')+1 : i]
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			return Cron{}, cronError(expr, "unknown time zone "+name)
+		}
+		c.loc = loc
+		s = strings.TrimSpace(s[i:])
+	}
+
+	if strings.HasPrefix(s, "@every ") {
+		d, err := dur.Parse(strings.TrimSpace(s[len("@every "):]))
+		if err != nil || d <= 0 {
+			return Cron{}, cronError(expr, "invalid @every duration")
+		}
+		c.every = time.Duration(d)
+
+		return c, nil
+	}
+
+	if strings.HasPrefix(s, "@") {
+		d, ok := cronDescriptors[strings.ToLower(s)]
+		if !ok {
+			return Cron{}, cronError(expr, "unknown descriptor "+s)
+		}
+		s = d
+	}
+
+	fields := strings.Fields(s)
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return Cron{}, cronError(
+			expr, fmt.Sprintf("expected 5 or 6 fields, got %d", len(fields)),
+		)
+	}
+
+	var err error
+	for i, p := range []struct {
+		f   cronField
+		dst *uint64
+	}{
+		{cronSecond, &c.second},
+		{cronMinute, &c.minute},
+		{cronHour, &c.hour},
+		{cronDom, &c.dom},
+		{cronMonth, &c.month},
+		{cronDow, &c.dow},
+	} {
+		*p.dst, err = p.f.parse(fields[i])
+		if err != nil {
+			return Cron{}, cronError(expr, err.Error())
+		}
+	}
+
+	// Sunday may be given as either 0 or 7.
+	if c.dow&(1<<7) != 0 {
+		c.dow = c.dow&^(1<<7) | 1
+	}
+
+	c.domStar = fields[3][0] == '*' || fields[3][0] == '?'
+	c.dowStar = fields[5][0] == '*' || fields[5][0] == '?'
+	c.hourStar = c.hour == 1<<24-1
+
+	if c.dowStar && !c.anyDate() {
+		return Cron{}, cronError(expr, "never matches any date")
+	}
+
+	return c, nil
+}
+
+// MustParseCron is like ParseCron, but panics if the expression is invalid.
+func MustParseCron(expr string) Cron {
+	c, err := ParseCron(expr)
+	if err != nil {
+		panic(err)
+	}
+
+	return c
+}
+
+// String returns the cron expression.
+func (c Cron) String() string {
+	return c.expr
+}
+
+// Location returns the location given by the expression's time zone prefix,
+// or nil if it has none.
+func (c Cron) Location() *time.Location {
+	return c.loc
+}
+
+// IsZero reports whether c is the zero value, which never matches.
+func (c Cron) IsZero() bool {
+	return c.expr == ""
+}
+
+// Next returns the earliest occurrence strictly after t, in the schedule's
+// location. It returns the zero time if there is no occurrence within ten
+// years.
+func (c Cron) Next(t time.Time) time.Time {
+	if c.IsZero() {
+		return time.Time{}
+	}
+
+	t = t.In(c.location(t))
+	if c.every > 0 {
+		return everyFloor(t, c.every).Add(c.every).In(t.Location())
+	}
+
+	loc := t.Location()
+	w0 := cronWall(t)
+
+	var next time.Time
+	c.walls(w0.Add(time.Second), w0.AddDate(cronSearchYears, 0, 0),
+		func(w time.Time) bool {
+			next = firstAfter(c.resolve(w, loc), t)
+
+			return next.IsZero()
+		},
+	)
+
+	// When a repeated wall clock period follows t, wall times before t may
+	// occur again after it.
+	if cronOffset(t) > cronOffset(t.Add(cronShift)) {
+		c.walls(w0.Add(-cronShift), w0, func(w time.Time) bool {
+			i := firstAfter(c.resolve(w, loc), t)
+			if !i.IsZero() && (next.IsZero() || i.Before(next)) {
+				next = i
+			}
+
+			return true
+		})
+	}
+
+	return next
+}
+
+// Prev returns the latest occurrence strictly before t, in the schedule's
+// location. It returns the zero time if there is no occurrence within ten
+// years.
+func (c Cron) Prev(t time.Time) time.Time {
+	if c.IsZero() {
+		return time.Time{}
+	}
+
+	t = t.In(c.location(t))
+	if c.every > 0 {
+		p := everyFloor(t, c.every)
+		if p.Equal(t) {
+			p = p.Add(-c.every)
+		}
+
+		return p.In(t.Location())
+	}
+
+	loc := t.Location()
+	w0 := cronWall(t)
+
+	// When a repeated wall clock period precedes t, wall times after t may
+	// have occurred before it.
+	repeated := cronOffset(t.Add(-cronShift)) > cronOffset(t)
+
+	start := w0
+	if repeated {
+		start = w0.Add(-time.Second)
+	}
+
+	var prev time.Time
+	c.walls(start, w0.AddDate(-cronSearchYears, 0, 0),
+		func(w time.Time) bool {
+			prev = lastBefore(c.resolve(w, loc), t)
+
+			return prev.IsZero()
+		},
+	)
+
+	if repeated {
+		c.walls(w0, w0.Add(cronShift), func(w time.Time) bool {
+			if i := lastBefore(c.resolve(w, loc), t); i.After(prev) {
+				prev = i
+			}
+
+			return true
+		})
+	}
+
+	return prev
+}
+
+// Times returns an iterator over all occurrences strictly after from, in
+// ascending order. It stops when no further occurrence is found within ten
+// years.
+func (c Cron) Times(from time.Time) iter.Seq[time.Time] {
+	return func(yield func(time.Time) bool) {
+		for t := c.Next(from); !t.IsZero(); t = c.Next(t) {
+			if !yield(t) {
+				return
+			}
+		}
+	}
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (c Cron) MarshalText() ([]byte, error) {
+	return []byte(c.expr), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (c *Cron) UnmarshalText(text []byte) error {
+	nc, err := ParseCron(string(text))
+	if err != nil {
+		return err
+	}
+	*c = nc
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (c Cron) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.expr)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (c *Cron) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	return c.UnmarshalText([]byte(s))
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (c Cron) MarshalYAML() (interface{}, error) {
+	return c.expr, nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *Cron) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.ScalarNode || node.Tag != "!!str" {
+		return &yaml.TypeError{Errors: []string{ErrInvalidCron.Error()}}
+	}
+
+	if err := c.UnmarshalText([]byte(node.Value)); err != nil {
+		return &yaml.TypeError{Errors: []string{err.Error()}}
+	}
+
+	return nil
+}
+
+func (c Cron) location(t time.Time) *time.Location {
+	if c.loc != nil {
+		return c.loc
+	}
+
+	return t.Location()
+}
+
+// walls calls fn for each wall clock time from from to to, inclusive, which
+// matches the expression, until fn returns false. Wall clock times are
+// represented as UTC times, and visited in descending order when to is before
+// from.
+func (c Cron) walls(from, to time.Time, fn func(time.Time) bool) {
+	step := time.Second
+	next := c.nextWall
+	if to.Before(from) {
+		step = -time.Second
+		next = c.prevWall
+	}
+
+	for w, ok := next(from, to); ok; w, ok = next(w.Add(step), to) {
+		if !fn(w) {
+			return
+		}
+	}
+}
+
+// nextWall returns the earliest wall clock time at or after w which matches
+// the expression, as long as it is not after limit.
+func (c Cron) nextWall(w, limit time.Time) (time.Time, bool) {
+	for !w.After(limit) {
+		y, m, d := w.Date()
+		switch {
+		case !hasBit(c.month, int(m)):
+			w = time.Date(y, m+1, 1, 0, 0, 0, 0, time.UTC)
+		case !c.dayMatches(w):
+			w = time.Date(y, m, d+1, 0, 0, 0, 0, time.UTC)
+		case !hasBit(c.hour, w.Hour()):
+			w = w.Truncate(time.Hour).Add(time.Hour)
+		case !hasBit(c.minute, w.Minute()):
+			w = w.Truncate(time.Minute).Add(time.Minute)
+		case !hasBit(c.second, w.Second()):
+			w = w.Add(time.Second)
+		default:
+			return w, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// prevWall returns the latest wall clock time at or before w which matches
+// the expression, as long as it is not before limit.
+func (c Cron) prevWall(w, limit time.Time) (time.Time, bool) {
+	for !w.Before(limit) {
+		y, m, d := w.Date()
+		switch {
+		case !hasBit(c.month, int(m)):
+			w = time.Date(y, m, 1, 0, 0, 0, 0, time.UTC).Add(-time.Second)
+		case !c.dayMatches(w):
+			w = time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Add(-time.Second)
+		case !hasBit(c.hour, w.Hour()):
+			w = w.Truncate(time.Hour).Add(-time.Second)
+		case !hasBit(c.minute, w.Minute()):
+			w = w.Truncate(time.Minute).Add(-time.Second)
+		case !hasBit(c.second, w.Second()):
+			w = w.Add(-time.Second)
+		default:
+			return w, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+func (c Cron) dayMatches(w time.Time) bool {
+	domMatch := hasBit(c.dom, w.Day())
+	dowMatch := hasBit(c.dow, int(w.Weekday()))
+	if c.domStar || c.dowStar {
+		return domMatch && dowMatch
+	}
+
+	return domMatch || dowMatch
+}
+
+// anyDate reports whether the month and day of month fields match at least
+// one date.
+func (c Cron) anyDate() bool {
+	for m := 1; m <= 12; m++ {
+		// February has 29 days in leap years.
+		days := time.Date(2000, time.Month(m)+1, 0, 0, 0, 0, 0, time.UTC).Day()
+		for d := 1; d <= days; d++ {
+			if hasBit(c.month, m) && hasBit(c.dom, d) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// resolve returns the instants in loc at which the wall clock time w occurs,
+// in ascending order, according to the daylight saving time rules described
+// on Cron.
+func (c Cron) resolve(w time.Time, loc *time.Location) []time.Time {
+	y, m, d := w.Date()
+	h, min, sec := w.Clock()
+	t := time.Date(y, m, d, h, min, sec, 0, loc)
+
+	var instants []time.Time
+	for _, probe := range []time.Time{t.Add(-cronShift), t, t.Add(cronShift)} {
+		i := w.Add(-time.Duration(cronOffset(probe)) * time.Second).In(loc)
+		if !cronWall(i).Equal(w) {
+			continue
+		}
+
+		switch {
+		case len(instants) == 0 || i.After(instants[len(instants)-1]):
+			instants = append(instants, i)
+		case i.Before(instants[0]):
+			instants = append([]time.Time{i}, instants...)
+		}
+	}
+
+	if c.hourStar {
+		return instants
+	}
+
+	if len(instants) == 0 {
+		// The wall clock time is skipped, so occur at the transition.
+		start, end := t.ZoneBounds()
+		if cronWall(t).After(w) {
+			return []time.Time{start}
+		}
+
+		return []time.Time{end}
+	}
+
+	return instants[:1]
+}
+
+func (f cronField) parse(s string) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(s, ",") {
+		rng, stepStr, hasStep := strings.Cut(part, "/")
+
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepStr)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid %s step %q", f.name, stepStr)
+			}
+			step = n
+		}
+
+		// Wildcards and steps without an explicit range end at top, to avoid
+		// matching aliases like day of week 7.
+		lo, hi := f.min, f.max
+		if f.top > 0 {
+			hi = f.top
+		}
+
+		if rng != "*" && rng != "?" {
+			loStr, hiStr, isRange := strings.Cut(rng, "-")
+
+			var err error
+			if lo, err = f.value(loStr); err != nil {
+				return 0, err
+			}
+
+			switch {
+			case isRange:
+				if hi, err = f.value(hiStr); err != nil {
+					return 0, err
+				}
+			case !hasStep:
+				hi = lo
+			}
+
+			if lo > hi {
+				return 0, fmt.Errorf("invalid %s range %q", f.name, rng)
+			}
+		}
+
+		for i := lo; i <= hi; i += step {
+			bits |= 1 << uint(i)
+		}
+	}
+
+	return bits, nil
+}
+
+func (f cronField) value(s string) (int, error) {
+	for i, name := range f.names {
+		if name != "" && strings.EqualFold(s, name) {
+			return i, nil
+		}
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil || n < f.min || n > f.max {
+		return 0, fmt.Errorf("invalid %s %q", f.name, s)
+	}
+
+	return n, nil
+}
+
+func cronError(expr, reason string) error {
+	return fmt.Errorf("%w %q: %s", ErrInvalidCron, expr, reason)
+}
+
+// cronWall returns the wall clock time of t, truncated to the second, as a UTC
+// time.
+func cronWall(t time.Time) time.Time {
+	y, m, d := t.Date()
+	h, min, sec := t.Clock()
+
+	return time.Date(y, m, d, h, min, sec, 0, time.UTC)
+}
+
+func cronOffset(t time.Time) int {
+	_, offset := t.Zone()
+
+	return offset
+}
+
+func firstAfter(instants []time.Time, t time.Time) time.Time {
+	for _, i := range instants {
+		if i.After(t) {
+			return i
+		}
+	}
+
+	return time.Time{}
+}
+
+func lastBefore(instants []time.Time, t time.Time) time.Time {
+	for j := len(instants) - 1; j >= 0; j-- {
+		if instants[j].Before(t) {
+			return instants[j]
+		}
+	}
+
+	return time.Time{}
+}
+
+func hasBit(bits uint64, i int) bool {
+	return bits&(1<<uint(i)) != 0
+}
+
+// everyFloor returns the latest multiple of d since the Unix epoch which is
+// not after t.
+func everyFloor(t time.Time, d time.Duration) time.Time {
+	n := new(big.Int).Mul(big.NewInt(t.Unix()), big.NewInt(int64(time.Second)))
+	n.Add(n, big.NewInt(int64(t.Nanosecond())))
+	n.Mod(n, big.NewInt(int64(d)))
+
+	return t.Round(0).Add(-time.Duration(n.Int64()))
+}
@@ -0,0 +1,347 @@
+package tyme
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestParseCron_Invalid(t *testing.T) {
+	tests := []struct {
+		expr    string
+		wantErr string
+	}{
+		{
+			expr: "",
+			wantErr: `invalid cron expression "": ` +
+				"expected 5 or 6 fields, got 0",
+		},
+		{
+			expr: "* * * *",
+			wantErr: `invalid cron expression "* * * *": ` +
+				"expected 5 or 6 fields, got 4",
+		},
+		{
+			expr: "60 * * * *",
+			wantErr: `invalid cron expression "60 * * * *": ` +
+				`invalid minute "60"`,
+		},
+		{
+			expr: "* * * * * * *",
+			wantErr: `invalid cron expression "* * * * * * *": ` +
+				"expected 5 or 6 fields, got 7",
+		},
+		{
+			expr: "0 5-1 * * *",
+			wantErr: `invalid cron expression "0 5-1 * * *": ` +
+				`invalid hour range "5-1"`,
+		},
+		{
+			expr: "*/0 * * * *",
+			wantErr: `invalid cron expression "*/0 * * * *": ` +
+				`invalid minute step "0"`,
+		},
+		{
+			expr: "0 0 * FOO *",
+			wantErr: `invalid cron expression "0 0 * FOO *": ` +
+				`invalid month "FOO"`,
+		},
+		{
+			expr: "0 0 30 2 *",
+			wantErr: `invalid cron expression "0 0 30 2 *": ` +
+				"never matches any date",
+		},
+		{
+			expr: "@reboot",
+			wantErr: `invalid cron expression "@reboot": ` +
+				"unknown descriptor @reboot",
+		},
+		{
+			expr: "@every -5m",
+			wantErr: `invalid cron expression "@every -5m": ` +
+				"invalid @every duration",
+		},
+		{
+			expr: "@every soon",
+			wantErr: `invalid cron expression "@every soon": ` +
+				"invalid @every duration",
+		},
+		{
+			expr: "CRON_TZ=Nowhere/Town 0 0 * * *",
+			wantErr: `invalid cron expression ` +
+				`"CRON_TZ=Nowhere/Town 0 0 * * *": ` +
+				"unknown time zone Nowhere/Town",
+		},
+		{
+			expr: "TZ=UTC",
+			wantErr: `invalid cron expression "TZ=UTC": ` +
+				"missing schedule after time zone",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := ParseCron(tt.expr)
+
+			assert.EqualError(t, err, tt.wantErr)
+			assert.ErrorIs(t, err, ErrInvalidCron)
+		})
+	}
+}
+
+func TestCron_NextPrev(t *testing.T) {
+	nyc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	date := func(y int, m time.Month, d, h, min, sec int) time.Time {
+		return time.Date(y, m, d, h, min, sec, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		expr     string
+		t        time.Time
+		wantNext time.Time
+		wantPrev time.Time
+	}{
+		{
+			name:     "step minutes",
+			expr:     "*/15 * * * *",
+			t:        date(2023, 10, 11, 10, 7, 30),
+			wantNext: date(2023, 10, 11, 10, 15, 0),
+			wantPrev: date(2023, 10, 11, 10, 0, 0),
+		},
+		{
+			name:     "exact match is excluded",
+			expr:     "*/15 * * * *",
+			t:        date(2023, 10, 11, 10, 15, 0),
+			wantNext: date(2023, 10, 11, 10, 30, 0),
+			wantPrev: date(2023, 10, 11, 10, 0, 0),
+		},
+		{
+			name:     "weekdays by name",
+			expr:     "0 9 * * mon-FRI",
+			t:        date(2023, 10, 13, 10, 0, 0),
+			wantNext: date(2023, 10, 16, 9, 0, 0),
+			wantPrev: date(2023, 10, 13, 9, 0, 0),
+		},
+		{
+			name:     "Sunday as 7",
+			expr:     "0 0 * * 7",
+			t:        date(2023, 10, 11, 0, 0, 0),
+			wantNext: date(2023, 10, 15, 0, 0, 0),
+			wantPrev: date(2023, 10, 8, 0, 0, 0),
+		},
+		{
+			name:     "day of month or day of week",
+			expr:     "0 0 1,15 * FRI",
+			t:        date(2023, 10, 2, 0, 0, 0),
+			wantNext: date(2023, 10, 6, 0, 0, 0),
+			wantPrev: date(2023, 10, 1, 0, 0, 0),
+		},
+		{
+			name:     "seconds field",
+			expr:     "*/20 30 12 * * ?",
+			t:        date(2023, 10, 11, 12, 30, 25),
+			wantNext: date(2023, 10, 11, 12, 30, 40),
+			wantPrev: date(2023, 10, 11, 12, 30, 20),
+		},
+		{
+			name:     "leap day",
+			expr:     "0 0 12 29 FEB *",
+			t:        date(2023, 10, 11, 0, 0, 0),
+			wantNext: date(2024, 2, 29, 12, 0, 0),
+			wantPrev: date(2020, 2, 29, 12, 0, 0),
+		},
+		{
+			name:     "month step",
+			expr:     "0 0 1 */3 *",
+			t:        date(2023, 10, 11, 0, 0, 0),
+			wantNext: date(2024, 1, 1, 0, 0, 0),
+			wantPrev: date(2023, 10, 1, 0, 0, 0),
+		},
+		{
+			name:     "hourly descriptor",
+			expr:     "@hourly",
+			t:        date(2023, 10, 11, 10, 7, 30),
+			wantNext: date(2023, 10, 11, 11, 0, 0),
+			wantPrev: date(2023, 10, 11, 10, 0, 0),
+		},
+		{
+			name:     "every descriptor",
+			expr:     "@every 90s",
+			t:        date(2023, 10, 11, 10, 0, 0),
+			wantNext: date(2023, 10, 11, 10, 1, 30),
+			wantPrev: date(2023, 10, 11, 9, 58, 30),
+		},
+		{
+			name:     "time zone prefix",
+			expr:     "CRON_TZ=America/New_York 0 9 * * *",
+			t:        date(2023, 10, 11, 12, 0, 0),
+			wantNext: date(2023, 10, 11, 13, 0, 0),
+			wantPrev: date(2023, 10, 10, 13, 0, 0),
+		},
+		{
+			name:     "skipped time occurs at transition",
+			expr:     "30 2 * * *",
+			t:        time.Date(2023, 3, 12, 0, 0, 0, 0, nyc),
+			wantNext: date(2023, 3, 12, 7, 0, 0),
+			wantPrev: date(2023, 3, 11, 7, 30, 0),
+		},
+		{
+			name:     "after skipped time",
+			expr:     "30 2 * * *",
+			t:        date(2023, 3, 12, 7, 0, 0).In(nyc),
+			wantNext: date(2023, 3, 13, 6, 30, 0),
+			wantPrev: date(2023, 3, 11, 7, 30, 0),
+		},
+		{
+			name:     "skipped time with wildcard hour",
+			expr:     "30 * * * *",
+			t:        time.Date(2023, 3, 12, 1, 45, 0, 0, nyc),
+			wantNext: date(2023, 3, 12, 7, 30, 0),
+			wantPrev: date(2023, 3, 12, 6, 30, 0),
+		},
+		{
+			name:     "repeated time occurs once",
+			expr:     "30 1 * * *",
+			t:        date(2023, 11, 5, 6, 0, 0).In(nyc),
+			wantNext: date(2023, 11, 6, 6, 30, 0),
+			wantPrev: date(2023, 11, 5, 5, 30, 0),
+		},
+		{
+			name:     "repeated time with wildcard hour",
+			expr:     "30 * * * *",
+			t:        date(2023, 11, 5, 5, 45, 0).In(nyc),
+			wantNext: date(2023, 11, 5, 6, 30, 0),
+			wantPrev: date(2023, 11, 5, 5, 30, 0),
+		},
+		{
+			name:     "repeated hour start with wildcard hour",
+			expr:     "*/20 * * * *",
+			t:        date(2023, 11, 5, 5, 50, 0).In(nyc),
+			wantNext: date(2023, 11, 5, 6, 0, 0),
+			wantPrev: date(2023, 11, 5, 5, 40, 0),
+		},
+		{
+			name:     "second repeated hour with wildcard hour",
+			expr:     "30 * * * *",
+			t:        date(2023, 11, 5, 6, 10, 0).In(nyc),
+			wantNext: date(2023, 11, 5, 6, 30, 0),
+			wantPrev: date(2023, 11, 5, 5, 30, 0),
+		},
+		{
+			name:     "after repeated hour",
+			expr:     "50 * * * *",
+			t:        date(2023, 11, 5, 7, 0, 0).In(nyc),
+			wantNext: date(2023, 11, 5, 7, 50, 0),
+			wantPrev: date(2023, 11, 5, 6, 50, 0),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := ParseCron(tt.expr)
+			require.NoError(t, err)
+
+			next := c.Next(tt.t)
+			prev := c.Prev(tt.t)
+
+			assert.True(t, tt.wantNext.Equal(next), "next: %s", next)
+			assert.True(t, tt.wantPrev.Equal(prev), "prev: %s", prev)
+		})
+	}
+}
+
+func TestCron_NextLocation(t *testing.T) {
+	c := MustParseCron("CRON_TZ=America/New_York 0 9 * * *")
+
+	assert.Equal(t, "America/New_York", c.Location().String())
+	assert.Equal(t, c.Location(), c.Next(utc).Location())
+	assert.Equal(t, loc, MustParseCron("0 9 * * *").Next(utc8).Location())
+	assert.Nil(t, MustParseCron("@daily").Location())
+}
+
+func TestCron_TimesAcrossDST(t *testing.T) {
+	nyc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	c := MustParseCron("*/10 * * * *")
+	start := time.Date(2023, 11, 5, 0, 0, 0, 0, nyc)
+
+	var times []time.Time
+	for next := range c.Times(start) {
+		times = append(times, next)
+		if len(times) == 40 {
+			break
+		}
+	}
+
+	prev := start
+	for i, next := range times {
+		assert.Equal(t, 10*time.Minute, next.Sub(prev), next.String())
+		if i > 0 {
+			assert.True(t, times[i-1].Equal(c.Prev(next)), next.String())
+		}
+		prev = next
+	}
+}
+
+func TestCron_DayOfMonthOrDayOfWeek(t *testing.T) {
+	c := MustParseCron("0 0 29 2 1")
+
+	assert.Equal(t,
+		time.Date(2023, 2, 6, 0, 0, 0, 0, time.UTC), c.Next(utc),
+	)
+	assert.Equal(t,
+		time.Date(2022, 2, 28, 0, 0, 0, 0, time.UTC), c.Prev(utc),
+	)
+}
+
+func TestCron_IsZero(t *testing.T) {
+	assert.True(t, Cron{}.IsZero())
+	assert.False(t, MustParseCron("@daily").IsZero())
+	assert.True(t, Cron{}.Next(utc).IsZero())
+	assert.True(t, Cron{}.Prev(utc).IsZero())
+}
+
+func TestMustParseCron(t *testing.T) {
+	assert.PanicsWithError(t,
+		`invalid cron expression "0 0 31 4,6 *": never matches any date`,
+		func() { MustParseCron("0 0 31 4,6 *") },
+	)
+}
+
+func TestCron_MarshalUnmarshal(t *testing.T) {
+	type job struct {
+		Schedule Cron `json:"schedule" yaml:"schedule"`
+	}
+
+	var j job
+	err := json.Unmarshal([]byte(`{"schedule":"@every 5m"}`), &j)
+	require.NoError(t, err)
+	assert.Equal(t, "@every 5m", j.Schedule.String())
+
+	b, err := json.Marshal(j)
+	require.NoError(t, err)
+	assert.Equal(t, `{"schedule":"@every 5m"}`, string(b))
+
+	err = yaml.Unmarshal([]byte("schedule: 0 9 * * MON\n"), &j)
+	require.NoError(t, err)
+	assert.Equal(t, "0 9 * * MON", j.Schedule.String())
+
+	b, err = yaml.Marshal(j)
+	require.NoError(t, err)
+	assert.Equal(t, "schedule: 0 9 * * MON\n", string(b))
+
+	err = json.Unmarshal([]byte(`{"schedule":"61 * * * *"}`), &j)
+	assert.ErrorIs(t, err, ErrInvalidCron)
+
+	err = yaml.Unmarshal([]byte("schedule: 61 * * * *\n"), &j)
+	assert.ErrorContains(t, err, `invalid minute "61"`)
+
+	err = yaml.Unmarshal([]byte("schedule: [1, 2]\n"), &j)
+	assert.ErrorContains(t, err, "invalid cron expression")
+}
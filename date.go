@@ -0,0 +1,94 @@
+package tyme
+
+import (
+	"time"
+
+	"github.com/jimeh/go-tyme/ts"
+)
+
+// Overflow determines how AddDate and AddMonths handle results which fall on
+// a day that does not exist in the resulting month, like adding one month to
+// January 31.
+type Overflow int
+
+const (
+	// OverflowClamp clamps the day to the last day of the resulting month, so
+	// January 31 plus one month is February 28, or February 29 in leap years.
+	OverflowClamp Overflow = iota
+
+	// OverflowRoll rolls excess days over into the following month, like
+	// time.Time.AddDate, so January 31 plus one month is March 3, or March 2
+	// in leap years.
+	OverflowRoll
+)
+
+// AddDate returns t with the given number of years, months and days added to
+// its date in t's location, keeping the same wall clock time. Years and months
+// are added before days, with overflow determining how days which do not
+// exist in the resulting month are handled.
+func AddDate[T Timestamp](
+	t T,
+	years, months, days int,
+	overflow Overflow,
+) T {
+	tt := time.Time(t)
+	y, m, d := tt.Date()
+	h, min, sec := tt.Clock()
+
+	m += time.Month(12*years + months)
+	if overflow == OverflowClamp {
+		if last := daysIn(y, m); d > last {
+			d = last
+		}
+	}
+
+	return T(time.Date(
+		y, m, d+days, h, min, sec, tt.Nanosecond(), tt.Location(),
+	))
+}
+
+// AddMonths returns t with the given number of months added to its date in t's
+// location, keeping the same wall clock time. It is shorthand for AddDate with
+// zero years and days.
+func AddMonths[T Timestamp](t T, months int, overflow Overflow) T {
+	return AddDate(t, 0, months, 0, overflow)
+}
+
+// AddWallClock returns t with d added to its wall clock time in t's location,
+// rather than to the absolute instant like ts.Add. Adding 24 hours across a
+// daylight saving time transition hence results in the same wall clock time
+// on the following day, which is 23 or 25 hours later in absolute time.
+func AddWallClock[T Timestamp, D ts.Duration](t T, d D) T {
+	tt := time.Time(t)
+	y, m, day := tt.Date()
+	h, min, sec := tt.Clock()
+
+	wall := time.Date(y, m, day, h, min, sec, tt.Nanosecond(), time.UTC).
+		Add(time.Duration(d))
+	y, m, day = wall.Date()
+	h, min, sec = wall.Clock()
+
+	return T(time.Date(
+		y, m, day, h, min, sec, wall.Nanosecond(), tt.Location(),
+	))
+}
+
+// StartOf returns the first instant of the calendar period p containing t, in
+// t's location. It behaves like ts.StartOf, but also accepts the types in this
+// package, and panics under the same conditions.
+func StartOf[T Timestamp](t T, p ts.Period, opts ...ts.PeriodOption) T {
+	return T(ts.StartOf(time.Time(t), p, opts...))
+}
+
+// EndOf returns the last instant of the calendar period p containing t, in
+// t's location. It behaves like ts.EndOf, but also accepts the types in this
+// package, and panics under the same conditions.
+func EndOf[T Timestamp](t T, p ts.Period, opts ...ts.PeriodOption) T {
+	return T(ts.EndOf(time.Time(t), p, opts...))
+}
+
+// daysIn returns the number of days in month m of year y, normalizing months
+// outside of the range January to December.
+func daysIn(y int, m time.Month) int {
+	return time.Date(y, m+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
@@ -0,0 +1,207 @@
+package tyme
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jimeh/go-tyme/dur"
+	"github.com/jimeh/go-tyme/ts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddDate(t *testing.T) {
+	nyc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		t        time.Time
+		years    int
+		months   int
+		days     int
+		overflow Overflow
+		want     time.Time
+	}{
+		{
+			name:     "one month from Jan 31 clamped",
+			t:        time.Date(2023, 1, 31, 10, 0, 0, 0, time.UTC),
+			months:   1,
+			overflow: OverflowClamp,
+			want:     time.Date(2023, 2, 28, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "one month from Jan 31 clamped in leap year",
+			t:        time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC),
+			months:   1,
+			overflow: OverflowClamp,
+			want:     time.Date(2024, 2, 29, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "one month from Jan 31 rolled",
+			t:        time.Date(2023, 1, 31, 10, 0, 0, 0, time.UTC),
+			months:   1,
+			overflow: OverflowRoll,
+			want:     time.Date(2023, 3, 3, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "one year from leap day clamped",
+			t:        time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+			years:    1,
+			overflow: OverflowClamp,
+			want:     time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "negative months across year clamped",
+			t:        time.Date(2023, 3, 31, 0, 0, 0, 0, time.UTC),
+			months:   -13,
+			overflow: OverflowClamp,
+			want:     time.Date(2022, 2, 28, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "days added after clamping",
+			t:        time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC),
+			months:   1,
+			days:     1,
+			overflow: OverflowClamp,
+			want:     time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "day across DST keeps wall clock",
+			t:        time.Date(2023, 3, 11, 12, 0, 0, 0, nyc),
+			days:     1,
+			overflow: OverflowClamp,
+			want:     time.Date(2023, 3, 12, 12, 0, 0, 0, nyc),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := AddDate(
+				TimeRFC3339(tt.t), tt.years, tt.months, tt.days, tt.overflow,
+			)
+
+			assert.IsType(t, TimeRFC3339{}, got)
+			assert.Equal(t, tt.want, time.Time(got))
+		})
+	}
+}
+
+func TestAddMonths(t *testing.T) {
+	got := AddMonths(ts.Millisecond(utc8), 4, OverflowRoll)
+
+	assert.IsType(t, ts.Millisecond{}, got)
+	assert.Equal(t, utc8.AddDate(0, 4, 0), got.Time())
+
+	eom := Time(time.Date(2023, 5, 31, 0, 0, 0, 0, loc))
+
+	assert.Equal(t,
+		time.Date(2023, 6, 30, 0, 0, 0, 0, loc),
+		time.Time(AddMonths(eom, 1, OverflowClamp)),
+	)
+	assert.Equal(t,
+		time.Date(2023, 7, 1, 0, 0, 0, 0, loc),
+		time.Time(AddMonths(eom, 1, OverflowRoll)),
+	)
+}
+
+func TestAddWallClock(t *testing.T) {
+	nyc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		t       time.Time
+		d       time.Duration
+		want    time.Time
+		wantAbs time.Duration
+	}{
+		{
+			name:    "across DST start",
+			t:       time.Date(2023, 3, 11, 12, 0, 0, 0, nyc),
+			d:       24 * time.Hour,
+			want:    time.Date(2023, 3, 12, 12, 0, 0, 0, nyc),
+			wantAbs: 23 * time.Hour,
+		},
+		{
+			name:    "across DST end",
+			t:       time.Date(2023, 11, 4, 12, 0, 0, 0, nyc),
+			d:       24 * time.Hour,
+			want:    time.Date(2023, 11, 5, 12, 0, 0, 0, nyc),
+			wantAbs: 25 * time.Hour,
+		},
+		{
+			name:    "backwards",
+			t:       time.Date(2023, 3, 12, 12, 0, 0, 0, nyc),
+			d:       -24 * time.Hour,
+			want:    time.Date(2023, 3, 11, 12, 0, 0, 0, nyc),
+			wantAbs: -23 * time.Hour,
+		},
+		{
+			name:    "no transition",
+			t:       time.Date(2023, 6, 1, 12, 0, 0, 5, nyc),
+			d:       90 * time.Minute,
+			want:    time.Date(2023, 6, 1, 13, 30, 0, 5, nyc),
+			wantAbs: 90 * time.Minute,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := AddWallClock(ts.Second(tt.t), dur.Duration(tt.d))
+
+			assert.IsType(t, ts.Second{}, got)
+			assert.Equal(t, tt.want, got.Time())
+			assert.Equal(t, tt.wantAbs, got.Time().Sub(tt.t))
+		})
+	}
+}
+
+func TestStartOfEndOf(t *testing.T) {
+	nyc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	in := Time(time.Date(2023, 3, 15, 14, 30, 0, 0, nyc))
+
+	tests := []struct {
+		name      string
+		p         ts.Period
+		opts      []ts.PeriodOption
+		wantStart time.Time
+		wantEnd   time.Time
+	}{
+		{
+			name:      "day",
+			p:         ts.PeriodDay,
+			wantStart: time.Date(2023, 3, 15, 0, 0, 0, 0, nyc),
+			wantEnd:   time.Date(2023, 3, 15, 23, 59, 59, 999999999, nyc),
+		},
+		{
+			name:      "week starting sunday",
+			p:         ts.PeriodWeek,
+			opts:      []ts.PeriodOption{ts.WithWeekStart(time.Sunday)},
+			wantStart: time.Date(2023, 3, 12, 0, 0, 0, 0, nyc),
+			wantEnd:   time.Date(2023, 3, 18, 23, 59, 59, 999999999, nyc),
+		},
+		{
+			name:      "month",
+			p:         ts.PeriodMonth,
+			wantStart: time.Date(2023, 3, 1, 0, 0, 0, 0, nyc),
+			wantEnd:   time.Date(2023, 3, 31, 23, 59, 59, 999999999, nyc),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := StartOf(in, tt.p, tt.opts...)
+			end := EndOf(in, tt.p, tt.opts...)
+
+			assert.IsType(t, Time{}, start)
+			assert.True(t, tt.wantStart.Equal(time.Time(start)))
+			assert.True(t, tt.wantEnd.Equal(time.Time(end)))
+		})
+	}
+}
+
+func TestStartOf_InvalidPeriod(t *testing.T) {
+	assert.PanicsWithValue(t, "ts: invalid period Period(42)", func() {
+		StartOf(Time(time.Now()), ts.Period(42))
+	})
+}
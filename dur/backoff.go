@@ -0,0 +1,350 @@
+package dur
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// JitterStrategy determines how a Backoff randomizes its delays.
+type JitterStrategy string
+
+const (
+	// JitterProportional randomizes each delay by up to plus or minus the
+	// Backoff's Jitter fraction of it. It is used when no strategy is set.
+	JitterProportional JitterStrategy = "proportional"
+
+	// JitterFull picks each delay at random between zero and the
+	// exponential delay.
+	JitterFull JitterStrategy = "full"
+
+	// JitterEqual keeps half of the exponential delay, and picks the other
+	// half at random.
+	JitterEqual JitterStrategy = "equal"
+
+	// JitterDecorrelated picks each delay at random between the initial
+	// delay and three times the previous delay, ignoring the Backoff's
+	// Multiplier and Jitter.
+	JitterDecorrelated JitterStrategy = "decorrelated"
+)
+
+// defaultMultiplier is the multiplier used by a Backoff without one.
+const defaultMultiplier = 2
+
+// Backoff is an exponential backoff policy for retrying operations. It can be
+// unmarshaled from JSON/YAML configuration, and is validated when
+// unmarshaled:
+//
+//	initial: 100ms
+//	max: 30s
+//	multiplier: 2
+//	jitter: 0.2
+//	max_elapsed: 5m
+//
+// A Backoff is safe for concurrent use, as long as its Rand is not shared.
+type Backoff struct {
+	// Initial is the delay before the first retry.
+	Initial Duration `json:"initial" yaml:"initial"`
+
+	// Max caps each delay. When zero, delays are not capped.
+	Max Duration `json:"max,omitempty" yaml:"max,omitempty"`
+
+	// Multiplier is the factor each delay grows by. When zero, 2 is used.
+	Multiplier float64 `json:"multiplier,omitempty" yaml:"multiplier,omitempty"`
+
+	// Jitter is the fraction, between 0 and 1, by which JitterProportional
+	// randomizes delays.
+	Jitter float64 `json:"jitter,omitempty" yaml:"jitter,omitempty"`
+
+	// Strategy determines how delays are randomized. When empty,
+	// JitterProportional is used.
+	Strategy JitterStrategy `json:"strategy,omitempty" yaml:"strategy,omitempty"`
+
+	// MaxElapsed limits the total time spent retrying. When zero, the time
+	// spent is not limited.
+	MaxElapsed Duration `json:"max_elapsed,omitempty" yaml:"max_elapsed,omitempty"`
+
+	// MaxAttempts limits the number of attempts, including the first one.
+	// When zero, the number of attempts is not limited.
+	MaxAttempts int `json:"max_attempts,omitempty" yaml:"max_attempts,omitempty"`
+
+	// Rand is the source of randomness for jitter, which allows delays to be
+	// made deterministic by seeding it. When nil, the global source of the
+	// math/rand package is used. A *rand.Rand is not safe for concurrent
+	// use.
+	Rand *rand.Rand `json:"-" yaml:"-"`
+
+	// Clock is the source of the current time and timers used by Retry,
+	// which allows retries to be tested without waiting. When nil, the time
+	// package is used.
+	Clock Clock `json:"-" yaml:"-"`
+}
+
+// Clock provides the current time and timers used by Backoff.Retry. It is
+// implemented by the clocks of the tyme package, including tyme.FakeClock.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// After waits for the duration d to elapse, and then sends the current
+	// time on the returned channel.
+	After(d time.Duration) <-chan time.Time
+}
+
+// RetryError is returned by Backoff.Retry when it gives up due to its
+// context, wrapping the last error of the retried function. It also matches
+// the context's error with errors.Is.
+type RetryError struct {
+	// Err is the last error returned by the retried function.
+	Err error
+
+	// ContextErr is the error of the context, or context.DeadlineExceeded
+	// when its deadline would pass before the next attempt.
+	ContextErr error
+}
+
+// Error implements the error interface.
+func (e *RetryError) Error() string {
+	return e.Err.Error() + ": " + e.ContextErr.Error()
+}
+
+// Unwrap returns the last error returned by the retried function.
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the context's error matches target.
+func (e *RetryError) Is(target error) bool {
+	return errors.Is(e.ContextErr, target)
+}
+
+// backoffConfig has the fields of Backoff without its methods, for decoding.
+type backoffConfig Backoff
+
+// Validate returns an error if the Backoff is invalid.
+func (b Backoff) Validate() error {
+	switch {
+	case b.Initial <= 0:
+		return errors.New("backoff initial delay must be positive")
+	case b.Max < 0:
+		return errors.New("backoff max delay must not be negative")
+	case b.Max > 0 && b.Max < b.Initial:
+		return errors.New("backoff max delay must not be less than initial")
+	case b.Multiplier != 0 && !(b.Multiplier >= 1) ||
+		math.IsInf(b.Multiplier, 1):
+		return errors.New("backoff multiplier must be at least 1")
+	case !(b.Jitter >= 0 && b.Jitter <= 1):
+		return errors.New("backoff jitter must be between 0 and 1")
+	case b.MaxElapsed < 0:
+		return errors.New("backoff max elapsed must not be negative")
+	case b.MaxAttempts < 0:
+		return errors.New("backoff max attempts must not be negative")
+	}
+
+	switch b.Strategy {
+	case "", JitterProportional, JitterFull, JitterEqual, JitterDecorrelated:
+	default:
+		return fmt.Errorf("invalid backoff jitter strategy %q", b.Strategy)
+	}
+
+	return nil
+}
+
+// Delays calls fn with each delay between attempts in turn, until fn returns
+// false, MaxAttempts is reached, or the sum of the delays would exceed
+// MaxElapsed. Each call randomizes delays anew.
+func (b Backoff) Delays(fn func(d Duration) bool) {
+	s := backoffState{b: b}
+	for {
+		d, ok := s.next()
+		if !ok || !fn(Duration(d)) {
+			return
+		}
+	}
+}
+
+// Retry calls fn until it returns nil, waiting for the delays of the Backoff
+// between attempts, and returns the last error when the Backoff gives up. It
+// also gives up when the time spent retrying would exceed MaxElapsed.
+//
+// When ctx is done, or its deadline would pass before the next attempt, Retry
+// returns a *RetryError wrapping the last error of fn and the context's error.
+func (b Backoff) Retry(
+	ctx context.Context,
+	fn func(context.Context) error,
+) error {
+	start := b.now()
+	s := backoffState{b: b}
+
+	for {
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return &RetryError{Err: err, ContextErr: ctxErr}
+		}
+
+		d, ok := s.next()
+		if !ok {
+			return err
+		}
+
+		elapsed := b.now().Sub(start) + d
+		if b.MaxElapsed > 0 && elapsed > time.Duration(b.MaxElapsed) {
+			return err
+		}
+
+		deadline, ok := ctx.Deadline()
+		if ok && deadline.Sub(b.now()) < d {
+			return &RetryError{
+				Err:        err,
+				ContextErr: context.DeadlineExceeded,
+			}
+		}
+
+		if !b.wait(ctx, d) {
+			return &RetryError{Err: err, ContextErr: ctx.Err()}
+		}
+	}
+}
+
+// now returns the current time of the Backoff's Clock.
+func (b Backoff) now() time.Time {
+	if b.Clock != nil {
+		return b.Clock.Now()
+	}
+
+	return time.Now()
+}
+
+// wait waits for the duration d on the Backoff's Clock, and returns false if
+// ctx is done first.
+func (b Backoff) wait(ctx context.Context, d time.Duration) bool {
+	var c <-chan time.Time
+	if b.Clock != nil {
+		c = b.Clock.After(d)
+	} else {
+		t := time.NewTimer(d)
+		defer t.Stop()
+		c = t.C
+	}
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-c:
+		return true
+	}
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, validating the
+// resulting Backoff.
+func (b *Backoff) UnmarshalJSON(data []byte) error {
+	var c backoffConfig
+	if err := json.Unmarshal(data, &c); err != nil {
+		return err
+	}
+
+	if err := Backoff(c).Validate(); err != nil {
+		return err
+	}
+	*b = Backoff(c)
+
+	return nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface, validating the
+// resulting Backoff.
+func (b *Backoff) UnmarshalYAML(node *yaml.Node) error {
+	var c backoffConfig
+	if err := node.Decode(&c); err != nil {
+		return err
+	}
+
+	if err := Backoff(c).Validate(); err != nil {
+		return &yaml.TypeError{Errors: []string{err.Error()}}
+	}
+	*b = Backoff(c)
+
+	return nil
+}
+
+// backoffState tracks the delays of a Backoff across attempts.
+type backoffState struct {
+	b        Backoff
+	attempts int
+	base     float64
+	prev     time.Duration
+	total    time.Duration
+}
+
+// next returns the delay before the next attempt, and false when the Backoff
+// gives up.
+func (s *backoffState) next() (time.Duration, bool) {
+	b := s.b
+	s.attempts++
+	if b.MaxAttempts > 0 && s.attempts >= b.MaxAttempts {
+		return 0, false
+	}
+
+	limit := time.Duration(math.MaxInt64)
+	if b.Max > 0 {
+		limit = time.Duration(b.Max)
+	}
+
+	if s.base == 0 {
+		s.base = float64(b.Initial)
+	} else {
+		mult := b.Multiplier
+		if mult == 0 {
+			mult = defaultMultiplier
+		}
+		s.base = math.Min(s.base*mult, float64(limit))
+	}
+
+	var d float64
+	switch b.Strategy {
+	case JitterFull:
+		d = s.base * s.float()
+	case JitterEqual:
+		d = s.base/2 + s.base/2*s.float()
+	case JitterDecorrelated:
+		d = float64(b.Initial)
+		if s.prev > 0 {
+			d += (float64(s.prev)*3 - d) * s.float()
+		}
+	default:
+		d = s.base
+		if b.Jitter > 0 {
+			d *= 1 + b.Jitter*(2*s.float()-1)
+		}
+	}
+
+	delay := limit
+	if d < float64(limit) {
+		delay = time.Duration(d)
+	}
+	if b.MaxElapsed > 0 && s.total+delay > time.Duration(b.MaxElapsed) {
+		return 0, false
+	}
+	s.prev = delay
+	s.total += delay
+
+	return delay, true
+}
+
+func (s *backoffState) float() float64 {
+	if s.b.Rand != nil {
+		return s.b.Rand.Float64()
+	}
+
+	return rand.Float64() //nolint:gosec // Jitter needs no secure random.
+}
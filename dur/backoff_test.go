@@ -0,0 +1,544 @@
+package dur
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func collectDelays(b Backoff) []Duration {
+	var delays []Duration
+	b.Delays(func(d Duration) bool {
+		delays = append(delays, d)
+
+		return true
+	})
+
+	return delays
+}
+
+// fakeClock is a Clock whose After returns immediately, advancing its time by
+// the duration waited for.
+type fakeClock struct {
+	now   time.Time
+	waits []time.Duration
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.now = c.now.Add(d)
+	c.waits = append(c.waits, d)
+
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+
+	return ch
+}
+
+func minDuration(a, b time.Duration) time.Duration {
+	if a < b {
+		return a
+	}
+
+	return b
+}
+
+func TestBackoff_Unmarshal(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		want    Backoff
+		wantErr string
+	}{
+		{
+			name: "full config",
+			yaml: `initial: 100ms
+max: 30s
+multiplier: 1.5
+jitter: 0.2
+strategy: proportional
+max_elapsed: 5m
+max_attempts: 10
+`,
+			want: Backoff{
+				Initial:     Duration(100 * time.Millisecond),
+				Max:         Duration(30 * time.Second),
+				Multiplier:  1.5,
+				Jitter:      0.2,
+				Strategy:    JitterProportional,
+				MaxElapsed:  Duration(5 * time.Minute),
+				MaxAttempts: 10,
+			},
+		},
+		{
+			name: "numeric durations",
+			yaml: "initial: 0.5\nmax: 60\nstrategy: decorrelated\n",
+			want: Backoff{
+				Initial:  Duration(500 * time.Millisecond),
+				Max:      Duration(time.Minute),
+				Strategy: JitterDecorrelated,
+			},
+		},
+		{
+			name:    "missing initial",
+			yaml:    "max: 30s\n",
+			wantErr: "backoff initial delay must be positive",
+		},
+		{
+			name:    "negative initial",
+			yaml:    "initial: -1s\n",
+			wantErr: "backoff initial delay must be positive",
+		},
+		{
+			name:    "negative max",
+			yaml:    "initial: 1s\nmax: -1s\n",
+			wantErr: "backoff max delay must not be negative",
+		},
+		{
+			name:    "max less than initial",
+			yaml:    "initial: 1s\nmax: 500ms\n",
+			wantErr: "backoff max delay must not be less than initial",
+		},
+		{
+			name:    "multiplier less than one",
+			yaml:    "initial: 1s\nmultiplier: 0.5\n",
+			wantErr: "backoff multiplier must be at least 1",
+		},
+		{
+			name:    "negative jitter",
+			yaml:    "initial: 1s\njitter: -0.1\n",
+			wantErr: "backoff jitter must be between 0 and 1",
+		},
+		{
+			name:    "jitter above one",
+			yaml:    "initial: 1s\njitter: 1.5\n",
+			wantErr: "backoff jitter must be between 0 and 1",
+		},
+		{
+			name:    "negative max elapsed",
+			yaml:    "initial: 1s\nmax_elapsed: -1m\n",
+			wantErr: "backoff max elapsed must not be negative",
+		},
+		{
+			name:    "negative max attempts",
+			yaml:    "initial: 1s\nmax_attempts: -1\n",
+			wantErr: "backoff max attempts must not be negative",
+		},
+		{
+			name:    "invalid strategy",
+			yaml:    "initial: 1s\nstrategy: wobbly\n",
+			wantErr: `invalid backoff jitter strategy "wobbly"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Backoff
+			err := yaml.Unmarshal([]byte(tt.yaml), &got)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err,
+					"yaml: unmarshal errors:\n  "+tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+
+			var node map[string]interface{}
+			require.NoError(t, yaml.Unmarshal([]byte(tt.yaml), &node))
+			b, err := json.Marshal(node)
+			require.NoError(t, err)
+
+			got = Backoff{}
+			err = json.Unmarshal(b, &got)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestBackoff_Validate_NonFinite(t *testing.T) {
+	tests := []struct {
+		name    string
+		b       Backoff
+		wantErr string
+	}{
+		{
+			name:    "NaN multiplier",
+			b:       Backoff{Initial: 1, Multiplier: math.NaN()},
+			wantErr: "backoff multiplier must be at least 1",
+		},
+		{
+			name:    "infinite multiplier",
+			b:       Backoff{Initial: 1, Multiplier: math.Inf(1)},
+			wantErr: "backoff multiplier must be at least 1",
+		},
+		{
+			name:    "NaN jitter",
+			b:       Backoff{Initial: 1, Jitter: math.NaN()},
+			wantErr: "backoff jitter must be between 0 and 1",
+		},
+		{
+			name:    "infinite jitter",
+			b:       Backoff{Initial: 1, Jitter: math.Inf(1)},
+			wantErr: "backoff jitter must be between 0 and 1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, tt.b.Validate(), tt.wantErr)
+		})
+	}
+
+	var got Backoff
+	err := yaml.Unmarshal([]byte("initial: 1s\njitter: .nan\n"), &got)
+	assert.ErrorContains(t, err, "backoff jitter must be between 0 and 1")
+}
+
+func TestBackoff_Marshal(t *testing.T) {
+	b := Backoff{
+		Initial:    Duration(100 * time.Millisecond),
+		Max:        Duration(30 * time.Second),
+		Multiplier: 2,
+		Jitter:     0.2,
+		Strategy:   JitterEqual,
+		MaxElapsed: Duration(5 * time.Minute),
+		Rand:       rand.New(rand.NewSource(1)),
+	}
+
+	got, err := json.Marshal(b)
+	require.NoError(t, err)
+	assert.Equal(t,
+		`{"initial":"100ms","max":"30s","multiplier":2,"jitter":0.2,`+
+			`"strategy":"equal","max_elapsed":"5m0s"}`,
+		string(got),
+	)
+
+	got, err = yaml.Marshal(b)
+	require.NoError(t, err)
+	assert.Equal(t, `initial: 100ms
+max: 30s
+multiplier: 2
+jitter: 0.2
+strategy: equal
+max_elapsed: 5m0s
+`, string(got))
+
+	got, err = yaml.Marshal(Backoff{Initial: Duration(time.Second)})
+	require.NoError(t, err)
+	assert.Equal(t, "initial: 1s\n", string(got))
+}
+
+func TestBackoff_Delays(t *testing.T) {
+	ms := func(n ...int) []Duration {
+		ds := make([]Duration, 0, len(n))
+		for _, v := range n {
+			ds = append(ds, Duration(time.Duration(v)*time.Millisecond))
+		}
+
+		return ds
+	}
+
+	tests := []struct {
+		name string
+		b    Backoff
+		want []Duration
+	}{
+		{
+			name: "max attempts",
+			b: Backoff{
+				Initial:     Duration(100 * time.Millisecond),
+				Max:         Duration(time.Second),
+				MaxAttempts: 7,
+			},
+			want: ms(100, 200, 400, 800, 1000, 1000),
+		},
+		{
+			name: "single attempt",
+			b: Backoff{
+				Initial:     Duration(100 * time.Millisecond),
+				MaxAttempts: 1,
+			},
+			want: nil,
+		},
+		{
+			name: "multiplier",
+			b: Backoff{
+				Initial:     Duration(100 * time.Millisecond),
+				Multiplier:  3,
+				MaxAttempts: 5,
+			},
+			want: ms(100, 300, 900, 2700),
+		},
+		{
+			name: "max elapsed",
+			b: Backoff{
+				Initial:    Duration(time.Second),
+				MaxElapsed: Duration(10 * time.Second),
+			},
+			want: ms(1000, 2000, 4000),
+		},
+		{
+			name: "constant",
+			b: Backoff{
+				Initial:     Duration(time.Second),
+				Multiplier:  1,
+				MaxAttempts: 4,
+			},
+			want: ms(1000, 1000, 1000),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := collectDelays(tt.b)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestBackoff_Delays_Unbounded(t *testing.T) {
+	b := Backoff{Initial: Duration(time.Hour)}
+
+	n := 0
+	var last Duration
+	b.Delays(func(d Duration) bool {
+		assert.GreaterOrEqual(t, d, last)
+		last = d
+		n++
+
+		return n < 100
+	})
+	assert.Equal(t, 100, n)
+	assert.Equal(t, Duration(1<<63-1), last)
+}
+
+func TestBackoff_Delays_Jitter(t *testing.T) {
+	base := []time.Duration{
+		100 * time.Millisecond,
+		200 * time.Millisecond,
+		400 * time.Millisecond,
+		800 * time.Millisecond,
+		time.Second,
+		time.Second,
+	}
+
+	tests := []struct {
+		name     string
+		strategy JitterStrategy
+		jitter   float64
+		bounds   func(i int, prev time.Duration) (lo, hi time.Duration)
+	}{
+		{
+			name:   "proportional",
+			jitter: 0.2,
+			bounds: func(i int, _ time.Duration) (lo, hi time.Duration) {
+				return base[i] * 8 / 10, minDuration(base[i]*12/10, time.Second)
+			},
+		},
+		{
+			name:     "full",
+			strategy: JitterFull,
+			bounds: func(i int, _ time.Duration) (lo, hi time.Duration) {
+				return 0, base[i]
+			},
+		},
+		{
+			name:     "equal",
+			strategy: JitterEqual,
+			bounds: func(i int, _ time.Duration) (lo, hi time.Duration) {
+				return base[i] / 2, base[i]
+			},
+		},
+		{
+			name:     "decorrelated",
+			strategy: JitterDecorrelated,
+			bounds: func(i int, prev time.Duration) (lo, hi time.Duration) {
+				if i == 0 {
+					return base[0], base[0]
+				}
+
+				return base[0], minDuration(prev*3, time.Second)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := Backoff{
+				Initial:     Duration(100 * time.Millisecond),
+				Max:         Duration(time.Second),
+				Jitter:      tt.jitter,
+				Strategy:    tt.strategy,
+				MaxAttempts: len(base) + 1,
+			}
+
+			for seed := int64(0); seed < 100; seed++ {
+				b.Rand = rand.New(rand.NewSource(seed))
+				got := collectDelays(b)
+				require.Len(t, got, len(base))
+
+				var prev time.Duration
+				for i, d := range got {
+					lo, hi := tt.bounds(i, prev)
+					assert.GreaterOrEqual(t, time.Duration(d), lo)
+					assert.LessOrEqual(t, time.Duration(d), hi)
+					prev = time.Duration(d)
+				}
+
+				b.Rand = rand.New(rand.NewSource(seed))
+				assert.Equal(t, got, collectDelays(b),
+					"seeded delays are not deterministic")
+			}
+		})
+	}
+}
+
+func TestBackoff_Retry(t *testing.T) {
+	errFail := errors.New("fail")
+
+	b := Backoff{
+		Initial:     Duration(time.Millisecond),
+		MaxAttempts: 5,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		calls := 0
+		err := b.Retry(context.Background(), func(context.Context) error {
+			if calls++; calls < 3 {
+				return errFail
+			}
+
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("max attempts", func(t *testing.T) {
+		calls := 0
+		err := b.Retry(context.Background(), func(context.Context) error {
+			calls++
+
+			return errFail
+		})
+
+		assert.Equal(t, errFail, err)
+		assert.Equal(t, 5, calls)
+	})
+
+	t.Run("max elapsed", func(t *testing.T) {
+		clk := &fakeClock{now: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)}
+		b := Backoff{
+			Initial:    Duration(time.Second),
+			Multiplier: 1,
+			MaxElapsed: Duration(time.Minute),
+			Clock:      clk,
+		}
+
+		calls := 0
+		err := b.Retry(context.Background(), func(context.Context) error {
+			calls++
+			clk.now = clk.now.Add(9 * time.Second)
+
+			return errFail
+		})
+
+		// Each attempt takes 9 seconds, followed by a 1 second delay, so an
+		// eighth attempt would start after 70 seconds.
+		assert.Equal(t, errFail, err)
+		assert.Equal(t, 7, calls)
+		assert.Len(t, clk.waits, 6)
+	})
+
+	t.Run("clock", func(t *testing.T) {
+		clk := &fakeClock{now: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)}
+		b := Backoff{Initial: Duration(time.Hour), MaxAttempts: 4, Clock: clk}
+
+		calls := 0
+		err := b.Retry(context.Background(), func(context.Context) error {
+			calls++
+
+			return errFail
+		})
+
+		assert.Equal(t, errFail, err)
+		assert.Equal(t, 4, calls)
+		assert.Equal(t,
+			[]time.Duration{time.Hour, 2 * time.Hour, 4 * time.Hour},
+			clk.waits,
+		)
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		calls := 0
+		err := b.Retry(ctx, func(context.Context) error {
+			if calls++; calls == 2 {
+				cancel()
+			}
+
+			return errFail
+		})
+
+		assert.ErrorIs(t, err, errFail)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.EqualError(t, err, "fail: context canceled")
+		assert.Equal(t, 2, calls)
+
+		var retryErr *RetryError
+		require.ErrorAs(t, err, &retryErr)
+		assert.Equal(t, errFail, retryErr.Err)
+		assert.Equal(t, context.Canceled, retryErr.ContextErr)
+	})
+
+	t.Run("canceled while waiting", func(t *testing.T) {
+		b := Backoff{Initial: Duration(time.Hour)}
+		ctx, cancel := context.WithCancel(context.Background())
+
+		calls := 0
+		err := b.Retry(ctx, func(context.Context) error {
+			calls++
+			time.AfterFunc(10*time.Millisecond, cancel)
+
+			return errFail
+		})
+
+		assert.ErrorIs(t, err, errFail)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("deadline before next attempt", func(t *testing.T) {
+		b := Backoff{Initial: Duration(time.Hour)}
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+
+		calls := 0
+		start := time.Now()
+		err := b.Retry(ctx, func(context.Context) error {
+			calls++
+
+			return errFail
+		})
+
+		assert.ErrorIs(t, err, errFail)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, 1, calls)
+		assert.Less(t, time.Since(start), time.Minute)
+	})
+}
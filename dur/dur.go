@@ -8,4 +8,8 @@
 //
 // Marshaling always outputs a string, using the standard time.Duration format,
 // by calling time.Duration(d).String().
+//
+// The package also provides configuration types built on Duration: Backoff
+// for retry policies, Range for randomized durations, and Rate for event
+// rates.
 package dur
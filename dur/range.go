@@ -0,0 +1,274 @@
+package dur
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrInvalidRange is returned when parsing an invalid duration range.
+var ErrInvalidRange = errors.New("invalid duration range")
+
+// Range is a range of durations, including both Min and Max, for picking
+// randomized durations to spread out cache expiries, poll intervals and the
+// like. Its text form is either "5s-10s", a single duration like "30s", or a
+// duration with a spread given as a percentage or duration:
+//
+//	30s±10%
+//	30s±3s
+//
+// which are both equivalent to "27s-33s". "+/-" may be used in place of "±".
+// When unmarshaling JSON and YAML, objects with min and max keys, and
+// numbers of seconds, are also supported.
+type Range struct {
+	Min Duration `json:"min" yaml:"min"`
+	Max Duration `json:"max" yaml:"max"`
+}
+
+// rangeConfig has the fields of Range without its methods, for decoding.
+type rangeConfig Range
+
+// ParseRange parses a Range from its text form.
+func ParseRange(s string) (Range, error) {
+	s = strings.TrimSpace(s)
+
+	var r Range
+	var err error
+
+	if base, spread, ok := cutSpread(s); ok {
+		r, err = parseSpread(base, spread)
+	} else if i := rangeSeparator(s); i >= 0 {
+		r.Min, err = parseRangeDuration(s[:i])
+		if err == nil {
+			r.Max, err = parseRangeDuration(s[i+1:])
+		}
+	} else {
+		r.Min, err = parseRangeDuration(s)
+		r.Max = r.Min
+	}
+	if err != nil {
+		return Range{}, fmt.Errorf("%w: %q", ErrInvalidRange, s)
+	}
+
+	if err := r.Validate(); err != nil {
+		return Range{}, err
+	}
+
+	return r, nil
+}
+
+// String returns the text form of the Range, as "Min-Max", or just Min when
+// Min and Max are equal.
+func (r Range) String() string {
+	if r.Min == r.Max {
+		return time.Duration(r.Min).String()
+	}
+
+	return time.Duration(r.Min).String() + "-" + time.Duration(r.Max).String()
+}
+
+// Validate returns an error if Min is negative or greater than Max.
+func (r Range) Validate() error {
+	if r.Min < 0 || r.Min > r.Max {
+		return fmt.Errorf("%w: %q", ErrInvalidRange, r.String())
+	}
+
+	return nil
+}
+
+// Rand returns a random duration between Min and Max, inclusive, using rng.
+// When rng is nil, the global source of the math/rand package is used. A
+// seeded rng makes the result reproducible. Min is returned when Max is not
+// greater than Min.
+func (r Range) Rand(rng *rand.Rand) Duration {
+	if r.Max <= r.Min {
+		return r.Min
+	}
+
+	randInt63n, randUint64 := rand.Int63n, rand.Uint64 //nolint:gosec
+	if rng != nil {
+		randInt63n, randUint64 = rng.Int63n, rng.Uint64
+	}
+
+	// The span between Min and Max may not fit in an int64, in which case
+	// random values outside of it are rejected, which is at most half of
+	// them.
+	span := uint64(r.Max) - uint64(r.Min)
+	if span < math.MaxInt64 {
+		return r.Min + Duration(randInt63n(int64(span)+1))
+	}
+
+	for {
+		if n := randUint64(); n <= span {
+			return Duration(uint64(r.Min) + n)
+		}
+	}
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (r Range) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (r *Range) UnmarshalText(text []byte) error {
+	nr, err := ParseRange(string(text))
+	if err != nil {
+		return err
+	}
+	*r = nr
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface, returning the Range
+// in its text form.
+func (r Range) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. Supports strings
+// in the text form, numbers of seconds, and objects with min and max keys.
+func (r *Range) UnmarshalJSON(b []byte) error {
+	var x interface{}
+	if err := json.Unmarshal(b, &x); err != nil {
+		return err
+	}
+
+	switch v := x.(type) {
+	case string:
+		return r.UnmarshalText([]byte(v))
+	case float64:
+		d, err := Parse(v)
+		if err != nil {
+			return err
+		}
+
+		return r.set(Range{Min: d, Max: d})
+	case map[string]interface{}:
+		var c rangeConfig
+		if err := json.Unmarshal(b, &c); err != nil {
+			return err
+		}
+
+		return r.set(Range(c))
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidRange, b)
+	}
+}
+
+// MarshalYAML implements the yaml.Marshaler interface, returning the Range
+// in its text form.
+func (r Range) MarshalYAML() (interface{}, error) {
+	return r.String(), nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface. Supports strings
+// in the text form, int and float numbers of seconds, and mappings with min
+// and max keys.
+func (r *Range) UnmarshalYAML(node *yaml.Node) error {
+	var err error
+
+	switch {
+	case node.Kind == yaml.MappingNode:
+		var c rangeConfig
+		if err = node.Decode(&c); err == nil {
+			err = r.set(Range(c))
+		}
+	case node.Tag == "!!str":
+		err = r.UnmarshalText([]byte(node.Value))
+	case node.Tag == "!!int" || node.Tag == "!!float":
+		var d Duration
+		if err = node.Decode(&d); err == nil {
+			err = r.set(Range{Min: d, Max: d})
+		}
+	default:
+		err = ErrInvalidRange
+	}
+	if err != nil {
+		return &yaml.TypeError{Errors: []string{err.Error()}}
+	}
+
+	return nil
+}
+
+func (r *Range) set(nr Range) error {
+	if err := nr.Validate(); err != nil {
+		return err
+	}
+	*r = nr
+
+	return nil
+}
+
+// cutSpread splits s around "±" or "+/-".
+func cutSpread(s string) (base, spread string, ok bool) {
+	if base, spread, ok = strings.Cut(s, "±"); ok {
+		return base, spread, true
+	}
+
+	return strings.Cut(s, "+/-")
+}
+
+// parseSpread parses a Range from a base duration and a spread, given as
+// either a percentage of base, or a duration.
+func parseSpread(base, spread string) (Range, error) {
+	b, err := parseRangeDuration(base)
+	if err != nil {
+		return Range{}, err
+	}
+
+	var d Duration
+	spread = strings.TrimSpace(spread)
+	if strings.HasSuffix(spread, "%") {
+		pct := strings.TrimSpace(strings.TrimSuffix(spread, "%"))
+		p, err := strconv.ParseFloat(pct, 64)
+		if err != nil || !(p >= 0) {
+			return Range{}, ErrInvalidRange
+		}
+
+		// Also rejects infinite percentages.
+		f := float64(b) * p / 100
+		if f >= math.MaxInt64 {
+			return Range{}, ErrInvalidRange
+		}
+		d = Duration(f)
+	} else {
+		d, err = parseRangeDuration(spread)
+		if err != nil || d < 0 {
+			return Range{}, ErrInvalidRange
+		}
+	}
+
+	if b > math.MaxInt64-d || b < math.MinInt64+d {
+		return Range{}, ErrInvalidRange
+	}
+
+	return Range{Min: b - d, Max: b + d}, nil
+}
+
+// rangeSeparator returns the index of the "-" separating Min and Max in the
+// text form of a Range, ignoring a leading sign, or -1 if there is none.
+func rangeSeparator(s string) int {
+	if s == "" {
+		return -1
+	}
+
+	i := strings.Index(s[1:], "-")
+	if i < 0 {
+		return -1
+	}
+
+	return i + 1
+}
+
+func parseRangeDuration(s string) (Duration, error) {
+	return Parse(strings.TrimSpace(s))
+}
@@ -0,0 +1,340 @@
+package dur
+
+import (
+	"encoding/json"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func newRange(lo, hi time.Duration) Range {
+	return Range{Min: Duration(lo), Max: Duration(hi)}
+}
+
+func TestParseRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    Range
+		wantErr string
+	}{
+		{
+			name: "min and max",
+			s:    "5s-10s",
+			want: newRange(5*time.Second, 10*time.Second),
+		},
+		{
+			name: "min and max with spaces",
+			s:    " 1m30s - 2h ",
+			want: newRange(90*time.Second, 2*time.Hour),
+		},
+		{
+			name: "single duration",
+			s:    "30s",
+			want: newRange(30*time.Second, 30*time.Second),
+		},
+		{
+			name: "percentage spread",
+			s:    "30s±10%",
+			want: newRange(27*time.Second, 33*time.Second),
+		},
+		{
+			name: "fractional percentage spread",
+			s:    "1m ± 2.5%",
+			want: Range{
+				Min: Duration(58500 * time.Millisecond),
+				Max: Duration(61500 * time.Millisecond),
+			},
+		},
+		{
+			name: "duration spread",
+			s:    "30s±3s",
+			want: newRange(27*time.Second, 33*time.Second),
+		},
+		{
+			name: "ASCII spread",
+			s:    "1h+/-50%",
+			want: newRange(30*time.Minute, 90*time.Minute),
+		},
+		{
+			name: "zero",
+			s:    "0s",
+			want: Range{},
+		},
+		{name: "empty", s: "", wantErr: `invalid duration range: ""`},
+		{name: "invalid", s: "soon", wantErr: `invalid duration range: "soon"`},
+		{
+			name:    "invalid max",
+			s:       "5s-",
+			wantErr: `invalid duration range: "5s-"`,
+		},
+		{
+			name:    "min greater than max",
+			s:       "10s-5s",
+			wantErr: `invalid duration range: "10s-5s"`,
+		},
+		{
+			name:    "negative",
+			s:       "-5s",
+			wantErr: `invalid duration range: "-5s"`,
+		},
+		{
+			name:    "negative min",
+			s:       "-5s-5s",
+			wantErr: `invalid duration range: "-5s-5s"`,
+		},
+		{
+			name:    "spread larger than duration",
+			s:       "10s±20s",
+			wantErr: `invalid duration range: "-10s-30s"`,
+		},
+		{
+			name:    "negative percentage",
+			s:       "10s±-5%",
+			wantErr: `invalid duration range: "10s±-5%"`,
+		},
+		{
+			name:    "invalid percentage",
+			s:       "10s±x%",
+			wantErr: `invalid duration range: "10s±x%"`,
+		},
+		{
+			name:    "NaN percentage",
+			s:       "30s±NaN%",
+			wantErr: `invalid duration range: "30s±NaN%"`,
+		},
+		{
+			name:    "infinite percentage",
+			s:       "30s±Inf%",
+			wantErr: `invalid duration range: "30s±Inf%"`,
+		},
+		{
+			name:    "overflowing percentage",
+			s:       "30s±1e300%",
+			wantErr: `invalid duration range: "30s±1e300%"`,
+		},
+		{
+			name:    "overflowing spread",
+			s:       "2562047h±2562047h",
+			wantErr: `invalid duration range: "2562047h±2562047h"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRange(tt.s)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.ErrorIs(t, err, ErrInvalidRange)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRange_String(t *testing.T) {
+	tests := []struct {
+		name string
+		r    Range
+		want string
+	}{
+		{name: "zero", r: Range{}, want: "0s"},
+		{
+			name: "single duration",
+			r:    newRange(time.Minute, time.Minute),
+			want: "1m0s",
+		},
+		{
+			name: "min and max",
+			r: Range{
+				Min: Duration(1500 * time.Millisecond),
+				Max: Duration(2 * time.Hour),
+			},
+			want: "1.5s-2h0m0s",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.r.String())
+
+			got, err := ParseRange(tt.want)
+			require.NoError(t, err)
+			assert.Equal(t, tt.r, got)
+		})
+	}
+}
+
+func TestRange_Rand(t *testing.T) {
+	r := newRange(5*time.Second, 10*time.Second)
+
+	rng := rand.New(rand.NewSource(42))
+	got := make([]Duration, 0, 100)
+	for i := 0; i < 100; i++ {
+		d := r.Rand(rng)
+		assert.GreaterOrEqual(t, d, r.Min)
+		assert.LessOrEqual(t, d, r.Max)
+		got = append(got, d)
+	}
+
+	rng = rand.New(rand.NewSource(42))
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, got[i], r.Rand(rng))
+	}
+
+	for i := 0; i < 100; i++ {
+		d := r.Rand(nil)
+		assert.GreaterOrEqual(t, d, r.Min)
+		assert.LessOrEqual(t, d, r.Max)
+	}
+
+	fixed := newRange(time.Second, time.Second)
+	assert.Equal(t, Duration(time.Second), fixed.Rand(rng))
+	assert.Equal(t, Duration(time.Second), fixed.Rand(nil))
+
+	tiny := Range{Min: 1, Max: 2}
+	seen := map[Duration]bool{}
+	for i := 0; i < 100; i++ {
+		seen[tiny.Rand(rng)] = true
+	}
+	assert.Equal(t, map[Duration]bool{1: true, 2: true}, seen)
+
+	for _, wide := range []Range{
+		{Min: 0, Max: math.MaxInt64},
+		{Min: math.MinInt64, Max: math.MaxInt64},
+	} {
+		for i := 0; i < 100; i++ {
+			d := wide.Rand(rng)
+			assert.GreaterOrEqual(t, d, wide.Min)
+			assert.LessOrEqual(t, d, wide.Max)
+		}
+	}
+
+	inverted := Range{Min: 2, Max: 1}
+	assert.Equal(t, Duration(2), inverted.Rand(rng))
+}
+
+func TestRange_MarshalUnmarshal(t *testing.T) {
+	type config struct {
+		TTL Range `json:"ttl" yaml:"ttl"`
+	}
+
+	tests := []struct {
+		name     string
+		json     string
+		yaml     string
+		want     Range
+		wantJSON string
+		wantYAML string
+		wantErr  string
+	}{
+		{
+			name:     "string",
+			json:     `{"ttl":"5s-10s"}`,
+			yaml:     "ttl: 5s-10s\n",
+			want:     newRange(5*time.Second, 10*time.Second),
+			wantJSON: `{"ttl":"5s-10s"}`,
+			wantYAML: "ttl: 5s-10s\n",
+		},
+		{
+			name:     "spread",
+			json:     `{"ttl":"30s±10%"}`,
+			yaml:     "ttl: 30s±10%\n",
+			want:     newRange(27*time.Second, 33*time.Second),
+			wantJSON: `{"ttl":"27s-33s"}`,
+			wantYAML: "ttl: 27s-33s\n",
+		},
+		{
+			name:     "object",
+			json:     `{"ttl":{"min":"1m","max":90}}`,
+			yaml:     "ttl:\n  min: 1m\n  max: 90\n",
+			want:     newRange(time.Minute, 90*time.Second),
+			wantJSON: `{"ttl":"1m0s-1m30s"}`,
+			wantYAML: "ttl: 1m0s-1m30s\n",
+		},
+		{
+			name:     "seconds",
+			json:     `{"ttl":1.5}`,
+			yaml:     "ttl: 1.5\n",
+			want:     newRange(1500*time.Millisecond, 1500*time.Millisecond),
+			wantJSON: `{"ttl":"1.5s"}`,
+			wantYAML: "ttl: 1.5s\n",
+		},
+		{
+			name:    "object with min greater than max",
+			json:    `{"ttl":{"min":"10s","max":"5s"}}`,
+			yaml:    "ttl: {min: 10s, max: 5s}\n",
+			wantErr: `invalid duration range: "10s-5s"`,
+		},
+		{
+			name:    "object without max",
+			json:    `{"ttl":{"min":"10s"}}`,
+			yaml:    "ttl: {min: 10s}\n",
+			wantErr: `invalid duration range: "10s-0s"`,
+		},
+		{
+			name:    "invalid string",
+			json:    `{"ttl":"soon"}`,
+			yaml:    "ttl: soon\n",
+			wantErr: `invalid duration range: "soon"`,
+		},
+		{
+			name:    "invalid type",
+			json:    `{"ttl":[1,2]}`,
+			yaml:    "ttl: [1, 2]\n",
+			wantErr: "invalid duration range",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got config
+			err := json.Unmarshal([]byte(tt.json), &got)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got.TTL)
+
+				b, err := json.Marshal(got)
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantJSON, string(b))
+			}
+
+			got = config{}
+			err = yaml.Unmarshal([]byte(tt.yaml), &got)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.TTL)
+
+			b, err := yaml.Marshal(got)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantYAML, string(b))
+		})
+	}
+}
+
+func TestRange_MarshalUnmarshalText(t *testing.T) {
+	r := newRange(5*time.Second, 10*time.Second)
+
+	b, err := r.MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, "5s-10s", string(b))
+
+	var got Range
+	require.NoError(t, got.UnmarshalText([]byte("5s±5s")))
+	assert.Equal(t, Range{Max: Duration(10 * time.Second)}, got)
+
+	err = got.UnmarshalText([]byte("10s-5s"))
+	assert.EqualError(t, err, `invalid duration range: "10s-5s"`)
+}
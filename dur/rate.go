@@ -0,0 +1,273 @@
+package dur
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrInvalidRate is returned when parsing an invalid rate.
+var ErrInvalidRate = errors.New("invalid rate")
+
+// rateSuffixes maps shorthand rate suffixes to their period.
+var rateSuffixes = map[string]time.Duration{
+	"rps": time.Second,
+	"rpm": time.Minute,
+	"rph": time.Hour,
+}
+
+// rateUnits maps spelled out units to their duration.
+var rateUnits = map[string]time.Duration{
+	"sec":     time.Second,
+	"second":  time.Second,
+	"seconds": time.Second,
+	"min":     time.Minute,
+	"minute":  time.Minute,
+	"minutes": time.Minute,
+	"hr":      time.Hour,
+	"hour":    time.Hour,
+	"hours":   time.Hour,
+}
+
+// Rate is a number of events per period of time, for configuring rate
+// limiters, sampling and the like. It parses from a range of text forms:
+//
+//	100/s
+//	5/1m
+//	1 per 250ms
+//	5 per minute
+//	10rps
+//
+// Its canonical text form, used when marshaling, is "<count>/<period>", with
+// periods of one hour, minute, second or millisecond given by their unit
+// alone, like "100/s" and "1/250ms". When unmarshaling JSON and YAML, numbers
+// are interpreted as events per second.
+type Rate struct {
+	// Count is the number of events per Period.
+	Count float64
+
+	// Period is the period of time Count events happen in.
+	Period Duration
+}
+
+// ParseRate parses a Rate from its text form.
+func ParseRate(s string) (Rate, error) {
+	r, err := parseRate(strings.ToLower(strings.TrimSpace(s)))
+	if err != nil {
+		return Rate{}, fmt.Errorf("%w: %q", ErrInvalidRate, s)
+	}
+
+	if err := r.Validate(); err != nil {
+		return Rate{}, err
+	}
+
+	return r, nil
+}
+
+// String returns the canonical text form of the Rate.
+func (r Rate) String() string {
+	count := strconv.FormatFloat(r.Count, 'f', -1, 64)
+
+	switch time.Duration(r.Period) {
+	case time.Hour:
+		return count + "/h"
+	case time.Minute:
+		return count + "/m"
+	case time.Second:
+		return count + "/s"
+	case time.Millisecond:
+		return count + "/ms"
+	default:
+		return count + "/" + time.Duration(r.Period).String()
+	}
+}
+
+// Per returns the number of events in the duration d.
+func (r Rate) Per(d Duration) float64 {
+	if r.Period == 0 {
+		return 0
+	}
+
+	return r.Count * float64(d) / float64(r.Period)
+}
+
+// Interval returns the duration between events, or zero if Count is zero.
+// Intervals too long to be represented as a Duration, due to a tiny Count,
+// are capped to the longest representable Duration.
+func (r Rate) Interval() Duration {
+	if r.Count == 0 || math.IsNaN(r.Count) {
+		return 0
+	}
+
+	i := float64(r.Period) / r.Count
+	switch {
+	case i >= math.MaxInt64:
+		return math.MaxInt64
+	case i <= math.MinInt64:
+		return math.MinInt64
+	}
+
+	return Duration(i)
+}
+
+// Validate returns an error if Count or Period are not positive.
+func (r Rate) Validate() error {
+	if !(r.Count > 0) || math.IsInf(r.Count, 0) || r.Period <= 0 {
+		return fmt.Errorf("%w: %q", ErrInvalidRate, r.String())
+	}
+
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (r Rate) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (r *Rate) UnmarshalText(text []byte) error {
+	nr, err := ParseRate(string(text))
+	if err != nil {
+		return err
+	}
+	*r = nr
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface, returning the Rate in
+// its canonical text form.
+func (r Rate) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. Supports strings
+// in any text form, and numbers of events per second.
+func (r *Rate) UnmarshalJSON(b []byte) error {
+	var x interface{}
+	if err := json.Unmarshal(b, &x); err != nil {
+		return err
+	}
+
+	switch v := x.(type) {
+	case string:
+		return r.UnmarshalText([]byte(v))
+	case float64:
+		return r.set(Rate{Count: v, Period: Duration(time.Second)})
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidRate, b)
+	}
+}
+
+// MarshalYAML implements the yaml.Marshaler interface, returning the Rate in
+// its canonical text form.
+func (r Rate) MarshalYAML() (interface{}, error) {
+	return r.String(), nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface. Supports strings
+// in any text form, and int and float numbers of events per second.
+func (r *Rate) UnmarshalYAML(node *yaml.Node) error {
+	var err error
+
+	switch node.Tag {
+	case "!!str":
+		err = r.UnmarshalText([]byte(node.Value))
+	case "!!int", "!!float":
+		var count float64
+		if err = node.Decode(&count); err == nil {
+			err = r.set(Rate{Count: count, Period: Duration(time.Second)})
+		}
+	default:
+		err = ErrInvalidRate
+	}
+	if err != nil {
+		return &yaml.TypeError{Errors: []string{err.Error()}}
+	}
+
+	return nil
+}
+
+func (r *Rate) set(nr Rate) error {
+	if err := nr.Validate(); err != nil {
+		return err
+	}
+	*r = nr
+
+	return nil
+}
+
+func parseRate(s string) (Rate, error) {
+	for suffix, period := range rateSuffixes {
+		if strings.HasSuffix(s, suffix) {
+			return newRate(strings.TrimSuffix(s, suffix), Duration(period))
+		}
+	}
+
+	count, period, ok := strings.Cut(s, "/")
+	if !ok {
+		count, period, ok = strings.Cut(s, " per ")
+	}
+	if !ok {
+		return Rate{}, ErrInvalidRate
+	}
+
+	p, err := parseRatePeriod(strings.TrimSpace(period))
+	if err != nil {
+		return Rate{}, err
+	}
+
+	return newRate(count, p)
+}
+
+func newRate(count string, period Duration) (Rate, error) {
+	c, err := strconv.ParseFloat(strings.TrimSpace(count), 64)
+	if err != nil {
+		return Rate{}, err
+	}
+
+	return Rate{Count: c, Period: period}, nil
+}
+
+// parseRatePeriod parses a period given as a duration like "250ms", a unit
+// alone like "s" or "minute", or a number and a spelled out unit like
+// "2 minutes".
+func parseRatePeriod(s string) (Duration, error) {
+	if n, unit, ok := strings.Cut(s, " "); ok {
+		d, known := rateUnits[strings.TrimSpace(unit)]
+		if !known {
+			return 0, ErrInvalidRate
+		}
+
+		f, err := strconv.ParseFloat(n, 64)
+		if err != nil {
+			return 0, err
+		}
+
+		// Also rejects NaN and infinite periods.
+		p := f * float64(d)
+		if !(p > math.MinInt64 && p < math.MaxInt64) {
+			return 0, ErrInvalidRate
+		}
+
+		return Duration(p), nil
+	}
+
+	if d, ok := rateUnits[s]; ok {
+		return Duration(d), nil
+	}
+
+	if r, _ := utf8.DecodeRuneInString(s); unicode.IsLetter(r) {
+		s = "1" + s
+	}
+
+	return Parse(s)
+}
@@ -0,0 +1,334 @@
+package dur
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestParseRate(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    Rate
+		wantStr string
+		wantErr string
+	}{
+		{
+			name:    "per second",
+			s:       "100/s",
+			want:    Rate{Count: 100, Period: Duration(time.Second)},
+			wantStr: "100/s",
+		},
+		{
+			name:    "per duration",
+			s:       "5/1m",
+			want:    Rate{Count: 5, Period: Duration(time.Minute)},
+			wantStr: "5/m",
+		},
+		{
+			name:    "per odd duration",
+			s:       "3/1m30s",
+			want:    Rate{Count: 3, Period: Duration(90 * time.Second)},
+			wantStr: "3/1m30s",
+		},
+		{
+			name:    "per with duration",
+			s:       "1 per 250ms",
+			want:    Rate{Count: 1, Period: Duration(250 * time.Millisecond)},
+			wantStr: "1/250ms",
+		},
+		{
+			name:    "per with unit",
+			s:       "5 per minute",
+			want:    Rate{Count: 5, Period: Duration(time.Minute)},
+			wantStr: "5/m",
+		},
+		{
+			name:    "per with number and unit",
+			s:       "5 per 2 minutes",
+			want:    Rate{Count: 5, Period: Duration(2 * time.Minute)},
+			wantStr: "5/2m0s",
+		},
+		{
+			name:    "slash with unit",
+			s:       "60 / hour",
+			want:    Rate{Count: 60, Period: Duration(time.Hour)},
+			wantStr: "60/h",
+		},
+		{
+			name:    "milliseconds",
+			s:       "1/ms",
+			want:    Rate{Count: 1, Period: Duration(time.Millisecond)},
+			wantStr: "1/ms",
+		},
+		{
+			name:    "microseconds",
+			s:       "2/µs",
+			want:    Rate{Count: 2, Period: Duration(time.Microsecond)},
+			wantStr: "2/1µs",
+		},
+		{
+			name:    "requests per second",
+			s:       "10rps",
+			want:    Rate{Count: 10, Period: Duration(time.Second)},
+			wantStr: "10/s",
+		},
+		{
+			name:    "requests per minute",
+			s:       "2.5 RPM",
+			want:    Rate{Count: 2.5, Period: Duration(time.Minute)},
+			wantStr: "2.5/m",
+		},
+		{
+			name:    "requests per hour",
+			s:       "1000rph",
+			want:    Rate{Count: 1000, Period: Duration(time.Hour)},
+			wantStr: "1000/h",
+		},
+		{
+			name:    "fractional count",
+			s:       "0.5/s",
+			want:    Rate{Count: 0.5, Period: Duration(time.Second)},
+			wantStr: "0.5/s",
+		},
+		{name: "empty", s: "", wantErr: `invalid rate: ""`},
+		{name: "no period", s: "100", wantErr: `invalid rate: "100"`},
+		{name: "no count", s: "/s", wantErr: `invalid rate: "/s"`},
+		{name: "bad count", s: "x/s", wantErr: `invalid rate: "x/s"`},
+		{
+			name:    "bad period",
+			s:       "5/fortnight",
+			wantErr: `invalid rate: "5/fortnight"`,
+		},
+		{
+			name:    "bad spelled out period",
+			s:       "5 per 2 fortnights",
+			wantErr: `invalid rate: "5 per 2 fortnights"`,
+		},
+		{name: "zero count", s: "0/s", wantErr: `invalid rate: "0/s"`},
+		{name: "negative count", s: "-1/s", wantErr: `invalid rate: "-1/s"`},
+		{name: "zero period", s: "1/0s", wantErr: `invalid rate: "1/0s"`},
+		{name: "negative period", s: "1/-1s", wantErr: `invalid rate: "1/-1s"`},
+		{name: "NaN count", s: "NaN/s", wantErr: `invalid rate: "NaN/s"`},
+		{name: "infinite count", s: "inf/s", wantErr: `invalid rate: "+Inf/s"`},
+		{
+			name:    "NaN period",
+			s:       "5 per NaN minutes",
+			wantErr: `invalid rate: "5 per NaN minutes"`,
+		},
+		{
+			name:    "infinite period",
+			s:       "5 per Inf minutes",
+			wantErr: `invalid rate: "5 per Inf minutes"`,
+		},
+		{
+			name:    "overflowing period",
+			s:       "5 per 1e300 hours",
+			wantErr: `invalid rate: "5 per 1e300 hours"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRate(tt.s)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.ErrorIs(t, err, ErrInvalidRate)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantStr, got.String())
+
+			reparsed, err := ParseRate(got.String())
+			require.NoError(t, err)
+			assert.Equal(t, got, reparsed)
+		})
+	}
+}
+
+func TestRate_PerAndInterval(t *testing.T) {
+	tests := []struct {
+		name         string
+		r            Rate
+		perSecond    float64
+		perMinute    float64
+		wantInterval Duration
+	}{
+		{
+			name:         "per second",
+			r:            Rate{Count: 100, Period: Duration(time.Second)},
+			perSecond:    100,
+			perMinute:    6000,
+			wantInterval: Duration(10 * time.Millisecond),
+		},
+		{
+			name:         "per minute",
+			r:            Rate{Count: 5, Period: Duration(time.Minute)},
+			perSecond:    5.0 / 60,
+			perMinute:    5,
+			wantInterval: Duration(12 * time.Second),
+		},
+		{
+			name: "per 250ms",
+			r: Rate{
+				Count:  1,
+				Period: Duration(250 * time.Millisecond),
+			},
+			perSecond:    4,
+			perMinute:    240,
+			wantInterval: Duration(250 * time.Millisecond),
+		},
+		{
+			name:         "fractional",
+			r:            Rate{Count: 0.5, Period: Duration(time.Second)},
+			perSecond:    0.5,
+			perMinute:    30,
+			wantInterval: Duration(2 * time.Second),
+		},
+		{
+			name:         "tiny count",
+			r:            Rate{Count: 1e-300, Period: Duration(time.Hour)},
+			perSecond:    0,
+			perMinute:    0,
+			wantInterval: Duration(math.MaxInt64),
+		},
+		{name: "zero"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.perSecond,
+				tt.r.Per(Duration(time.Second)), 1e-9)
+			assert.InDelta(t, tt.perMinute,
+				tt.r.Per(Duration(time.Minute)), 1e-9)
+			assert.Equal(t, tt.wantInterval, tt.r.Interval())
+		})
+	}
+}
+
+func TestRate_MarshalUnmarshal(t *testing.T) {
+	type config struct {
+		Limit Rate `json:"limit" yaml:"limit"`
+	}
+
+	tests := []struct {
+		name     string
+		json     string
+		yaml     string
+		want     Rate
+		wantJSON string
+		wantYAML string
+		wantErr  string
+	}{
+		{
+			name:     "canonical",
+			json:     `{"limit":"100/s"}`,
+			yaml:     "limit: 100/s\n",
+			want:     Rate{Count: 100, Period: Duration(time.Second)},
+			wantJSON: `{"limit":"100/s"}`,
+			wantYAML: "limit: 100/s\n",
+		},
+		{
+			name:     "spelled out",
+			json:     `{"limit":"5 per minute"}`,
+			yaml:     "limit: 5 per minute\n",
+			want:     Rate{Count: 5, Period: Duration(time.Minute)},
+			wantJSON: `{"limit":"5/m"}`,
+			wantYAML: "limit: 5/m\n",
+		},
+		{
+			name:     "shorthand",
+			json:     `{"limit":"10rps"}`,
+			yaml:     "limit: 10rps\n",
+			want:     Rate{Count: 10, Period: Duration(time.Second)},
+			wantJSON: `{"limit":"10/s"}`,
+			wantYAML: "limit: 10/s\n",
+		},
+		{
+			name:     "integer per second",
+			json:     `{"limit":20}`,
+			yaml:     "limit: 20\n",
+			want:     Rate{Count: 20, Period: Duration(time.Second)},
+			wantJSON: `{"limit":"20/s"}`,
+			wantYAML: "limit: 20/s\n",
+		},
+		{
+			name:     "float per second",
+			json:     `{"limit":0.25}`,
+			yaml:     "limit: 0.25\n",
+			want:     Rate{Count: 0.25, Period: Duration(time.Second)},
+			wantJSON: `{"limit":"0.25/s"}`,
+			wantYAML: "limit: 0.25/s\n",
+		},
+		{
+			name:    "zero per second",
+			json:    `{"limit":0}`,
+			yaml:    "limit: 0\n",
+			wantErr: `invalid rate: "0/s"`,
+		},
+		{
+			name:    "invalid string",
+			json:    `{"limit":"lots"}`,
+			yaml:    "limit: lots\n",
+			wantErr: `invalid rate: "lots"`,
+		},
+		{
+			name:    "invalid type",
+			json:    `{"limit":[1]}`,
+			yaml:    "limit: [1]\n",
+			wantErr: "invalid rate",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got config
+			err := json.Unmarshal([]byte(tt.json), &got)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got.Limit)
+
+				b, err := json.Marshal(got)
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantJSON, string(b))
+			}
+
+			got = config{}
+			err = yaml.Unmarshal([]byte(tt.yaml), &got)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.Limit)
+
+			b, err := yaml.Marshal(got)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantYAML, string(b))
+		})
+	}
+}
+
+func TestRate_MarshalUnmarshalText(t *testing.T) {
+	r := Rate{Count: 1, Period: Duration(250 * time.Millisecond)}
+
+	b, err := r.MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, "1/250ms", string(b))
+
+	var got Rate
+	require.NoError(t, got.UnmarshalText([]byte("1 per 250ms")))
+	assert.Equal(t, r, got)
+
+	err = got.UnmarshalText([]byte("1 per"))
+	assert.EqualError(t, err, `invalid rate: "1 per"`)
+}
@@ -0,0 +1,270 @@
+package tyme
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jimeh/go-tyme/dur"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrInvalidExpiry is returned when parsing an invalid expiry.
+var ErrInvalidExpiry = errors.New("invalid expiry")
+
+// ExpiryForm is the form an Expiry is marshaled in.
+type ExpiryForm int
+
+const (
+	// ExpiryFormTime marshals an Expiry as the absolute time it expires at,
+	// in RFC 3339 format.
+	ExpiryFormTime ExpiryForm = iota
+
+	// ExpiryFormDuration marshals an Expiry as the time remaining until it
+	// expires, in whole seconds, formatted like time.Duration.String().
+	ExpiryFormDuration
+
+	// ExpiryFormSeconds marshals an Expiry as the number of whole seconds
+	// remaining until it expires.
+	ExpiryFormSeconds
+)
+
+// Expiry is the time at which something, like a token or cache entry,
+// expires. It unmarshals from JSON and YAML as either a relative duration,
+// given as a number of seconds or a duration string, or an absolute time:
+//
+//	expires_in: 3600
+//	expires_in: "1h"
+//	expires_at: "2026-10-18T10:00:00Z"
+//
+// Relative values are resolved against the Expiry's Clock when unmarshaling,
+// which is set with WithClock before unmarshaling into it, and defaults to
+// RealClock. Unmarshaling records the form of the value, which is used when
+// marshaling, and can be changed with WithForm. The zero Expiry never expires,
+// and marshals as null.
+type Expiry struct {
+	at    time.Time
+	clock Clock
+	form  ExpiryForm
+}
+
+// ExpiresAt returns an Expiry which expires at t.
+func ExpiresAt(t time.Time) Expiry {
+	return Expiry{at: t}
+}
+
+// ExpiresIn returns an Expiry which expires d after now.
+func ExpiresIn(now time.Time, d time.Duration) Expiry {
+	return Expiry{at: now.Add(d)}
+}
+
+// ParseExpiry parses an Expiry from s, resolving relative values against now.
+// Numeric strings are parsed as a number of seconds, other strings are
+// parsed with dur.Parse if possible, and with Parse otherwise. The returned
+// Expiry marshals in the form s was given in.
+func ParseExpiry(s string, now time.Time) (Expiry, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Expiry{}, fmt.Errorf("%w: %q", ErrInvalidExpiry, s)
+	}
+
+	if secs, err := strconv.ParseFloat(s, 64); err == nil {
+		return expiryIn(now, secs)
+	}
+
+	if d, err := dur.Parse(s); err == nil {
+		e := ExpiresIn(now, time.Duration(d))
+		e.form = ExpiryFormDuration
+
+		return e, nil
+	}
+
+	t, err := Parse(s)
+	if err != nil {
+		return Expiry{}, fmt.Errorf("%w: %q", ErrInvalidExpiry, s)
+	}
+
+	return ExpiresAt(time.Time(t)), nil
+}
+
+// WithClock returns a copy of e which uses c as the reference time when
+// unmarshaling relative values, and when marshaling in a relative form.
+func (e Expiry) WithClock(c Clock) Expiry {
+	e.clock = c
+
+	return e
+}
+
+// WithForm returns a copy of e which marshals in the form f.
+func (e Expiry) WithForm(f ExpiryForm) Expiry {
+	e.form = f
+
+	return e
+}
+
+// Form returns the form the Expiry marshals in.
+func (e Expiry) Form() ExpiryForm {
+	return e.form
+}
+
+// At returns the time the Expiry expires at.
+func (e Expiry) At() time.Time {
+	return e.at
+}
+
+// Remaining returns the time remaining from now until the Expiry expires, or
+// zero if it has expired or is the zero Expiry.
+func (e Expiry) Remaining(now time.Time) dur.Duration {
+	if e.IsZero() || !now.Before(e.at) {
+		return 0
+	}
+
+	return dur.Duration(e.at.Sub(now))
+}
+
+// Expired reports whether the Expiry has expired at now. The zero Expiry
+// never expires.
+func (e Expiry) Expired(now time.Time) bool {
+	return !e.IsZero() && !now.Before(e.at)
+}
+
+// IsZero reports whether e is the zero Expiry.
+func (e Expiry) IsZero() bool {
+	return e.at.IsZero()
+}
+
+// MarshalJSON implements the json.Marshaler interface, formatting the Expiry
+// according to its form.
+func (e Expiry) MarshalJSON() ([]byte, error) {
+	v, err := e.marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	if t, ok := v.(time.Time); ok {
+		return Time(t).MarshalJSON()
+	}
+
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. Numbers are parsed
+// as a number of seconds, and strings are parsed with ParseExpiry, resolving
+// relative values against the Expiry's Clock.
+func (e *Expiry) UnmarshalJSON(b []byte) error {
+	var x interface{}
+	if err := json.Unmarshal(b, &x); err != nil {
+		return err
+	}
+
+	ne := Expiry{form: e.form}
+	var err error
+
+	switch v := x.(type) {
+	case nil:
+	case float64:
+		ne, err = expiryIn(e.now(), v)
+	case string:
+		ne, err = ParseExpiry(v, e.now())
+	default:
+		err = fmt.Errorf("%w: %s", ErrInvalidExpiry, b)
+	}
+	if err != nil {
+		return err
+	}
+
+	ne.clock = e.clock
+	*e = ne
+
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface, formatting the Expiry
+// according to its form.
+func (e Expiry) MarshalYAML() (interface{}, error) {
+	return e.marshal()
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface. Integers and
+// floats are parsed as a number of seconds, and strings are parsed with
+// ParseExpiry, resolving relative values against the Expiry's Clock.
+func (e *Expiry) UnmarshalYAML(node *yaml.Node) error {
+	ne := Expiry{form: e.form}
+	var err error
+
+	switch node.Tag {
+	case "!!null":
+	case "!!timestamp":
+		var t time.Time
+		if err = node.Decode(&t); err == nil {
+			ne = ExpiresAt(t)
+		}
+	case "!!int", "!!float":
+		var secs float64
+		if err = node.Decode(&secs); err == nil {
+			ne, err = expiryIn(e.now(), secs)
+		}
+	case "!!str":
+		ne, err = ParseExpiry(node.Value, e.now())
+	default:
+		err = ErrInvalidExpiry
+	}
+	if err != nil {
+		return &yaml.TypeError{Errors: []string{err.Error()}}
+	}
+
+	ne.clock = e.clock
+	*e = ne
+
+	return nil
+}
+
+func (e Expiry) marshal() (interface{}, error) {
+	if e.IsZero() {
+		return nil, nil
+	}
+
+	remaining := time.Duration(e.Remaining(e.now())).Truncate(time.Second)
+
+	switch e.form {
+	case ExpiryFormTime:
+		return e.at, nil
+	case ExpiryFormDuration:
+		return remaining.String(), nil
+	case ExpiryFormSeconds:
+		return int64(remaining / time.Second), nil
+	default:
+		return nil, fmt.Errorf(
+			"invalid expiry marshal form %d", e.form,
+		)
+	}
+}
+
+func (e Expiry) now() time.Time {
+	if e.clock == nil {
+		return time.Now()
+	}
+
+	return e.clock.Now()
+}
+
+// expiryIn returns an Expiry secs seconds after now, in ExpiryFormSeconds. It
+// returns an error if secs is not finite, or overflows a time.Duration.
+func expiryIn(now time.Time, secs float64) (Expiry, error) {
+	ns := secs * float64(time.Second)
+	if math.IsNaN(ns) || ns >= math.MaxInt64 || ns < math.MinInt64 {
+		return Expiry{}, fmt.Errorf(
+			"%w: %s", ErrInvalidExpiry,
+			strconv.FormatFloat(secs, 'g', -1, 64),
+		)
+	}
+
+	e := ExpiresIn(now, time.Duration(ns))
+	e.form = ExpiryFormSeconds
+
+	return e, nil
+}
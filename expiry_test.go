@@ -0,0 +1,396 @@
+package tyme
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/jimeh/go-tyme/dur"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestParseExpiry(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		want     time.Time
+		wantForm ExpiryForm
+		wantErr  string
+	}{
+		{
+			name:     "seconds",
+			s:        "3600",
+			want:     utc.Add(time.Hour),
+			wantForm: ExpiryFormSeconds,
+		},
+		{
+			name:     "fractional seconds",
+			s:        "1.5",
+			want:     utc.Add(1500 * time.Millisecond),
+			wantForm: ExpiryFormSeconds,
+		},
+		{
+			name:     "negative seconds",
+			s:        "-60",
+			want:     utc.Add(-time.Minute),
+			wantForm: ExpiryFormSeconds,
+		},
+		{
+			name:     "duration",
+			s:        "1h30m",
+			want:     utc.Add(90 * time.Minute),
+			wantForm: ExpiryFormDuration,
+		},
+		{
+			name:     "padded duration",
+			s:        " 10s ",
+			want:     utc.Add(10 * time.Second),
+			wantForm: ExpiryFormDuration,
+		},
+		{
+			name: "RFC 3339 time",
+			s:    "2026-10-18T10:00:00Z",
+			want: time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "RFC 3339 time with offset",
+			s:    "2026-10-18T18:00:00+08:00",
+			want: time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC),
+		},
+		{name: "empty", s: "", wantErr: `invalid expiry: ""`},
+		{name: "invalid", s: "soon", wantErr: `invalid expiry: "soon"`},
+		{name: "NaN", s: "NaN", wantErr: "invalid expiry: NaN"},
+		{name: "infinity", s: "-Inf", wantErr: "invalid expiry: -Inf"},
+		{name: "overflow", s: "1e10", wantErr: "invalid expiry: 1e+10"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseExpiry(tt.s, utc)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.ErrorIs(t, err, ErrInvalidExpiry)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(got.At()),
+				"expected %s, got %s", tt.want, got.At())
+			assert.Equal(t, tt.wantForm, got.Form())
+		})
+	}
+}
+
+func TestExpiry(t *testing.T) {
+	e := ExpiresIn(utc, time.Hour)
+
+	assert.Equal(t, utc.Add(time.Hour), e.At())
+	assert.Equal(t, ExpiresAt(utc.Add(time.Hour)), e)
+	assert.False(t, e.IsZero())
+
+	assert.Equal(t, dur.Duration(time.Hour), e.Remaining(utc))
+	assert.Equal(t,
+		dur.Duration(time.Second), e.Remaining(e.At().Add(-time.Second)),
+	)
+	assert.Zero(t, e.Remaining(e.At()))
+	assert.Zero(t, e.Remaining(e.At().Add(time.Hour)))
+
+	assert.False(t, e.Expired(utc))
+	assert.False(t, e.Expired(e.At().Add(-time.Nanosecond)))
+	assert.True(t, e.Expired(e.At()))
+	assert.True(t, e.Expired(e.At().Add(time.Hour)))
+}
+
+func TestExpiry_Zero(t *testing.T) {
+	var e Expiry
+
+	assert.True(t, e.IsZero())
+	assert.True(t, e.At().IsZero())
+	assert.False(t, e.Expired(utc))
+	assert.Zero(t, e.Remaining(utc))
+
+	for _, f := range []ExpiryForm{
+		ExpiryFormTime, ExpiryFormDuration, ExpiryFormSeconds,
+	} {
+		b, err := json.Marshal(e.WithForm(f))
+		require.NoError(t, err)
+		assert.Equal(t, "null", string(b))
+
+		b, err = yaml.Marshal(e.WithForm(f))
+		require.NoError(t, err)
+		assert.Equal(t, "null\n", string(b))
+	}
+}
+
+func TestExpiry_UnmarshalJSON(t *testing.T) {
+	clk := NewFakeClock(utc)
+
+	tests := []struct {
+		name     string
+		json     string
+		want     time.Time
+		wantForm ExpiryForm
+		wantErr  string
+	}{
+		{
+			name:     "integer",
+			json:     `3600`,
+			want:     utc.Add(time.Hour),
+			wantForm: ExpiryFormSeconds,
+		},
+		{
+			name:     "float",
+			json:     `0.25`,
+			want:     utc.Add(250 * time.Millisecond),
+			wantForm: ExpiryFormSeconds,
+		},
+		{
+			name:     "numeric string",
+			json:     `"3600"`,
+			want:     utc.Add(time.Hour),
+			wantForm: ExpiryFormSeconds,
+		},
+		{
+			name:     "duration",
+			json:     `"1h"`,
+			want:     utc.Add(time.Hour),
+			wantForm: ExpiryFormDuration,
+		},
+		{
+			name: "time",
+			json: `"2026-10-18T10:00:00Z"`,
+			want: time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC),
+		},
+		{name: "null", json: `null`},
+		{
+			name:    "invalid string",
+			json:    `"soon"`,
+			wantErr: `invalid expiry: "soon"`,
+		},
+		{name: "invalid type", json: `true`, wantErr: "invalid expiry: true"},
+		{name: "NaN string", json: `"NaN"`, wantErr: "invalid expiry: NaN"},
+		{
+			name:    "infinite string",
+			json:    `"+Inf"`,
+			wantErr: "invalid expiry: +Inf",
+		},
+		{name: "overflow", json: `1e300`, wantErr: "invalid expiry: 1e+300"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Expiry{}.WithClock(clk)
+			err := json.Unmarshal([]byte(tt.json), &got)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(got.At()),
+				"expected %s, got %s", tt.want, got.At())
+			assert.Equal(t, tt.wantForm, got.Form())
+		})
+	}
+}
+
+func TestExpiry_UnmarshalYAML(t *testing.T) {
+	clk := NewFakeClock(utc)
+
+	tests := []struct {
+		name     string
+		yaml     string
+		want     time.Time
+		wantForm ExpiryForm
+		wantErr  string
+	}{
+		{
+			name:     "integer",
+			yaml:     `3600`,
+			want:     utc.Add(time.Hour),
+			wantForm: ExpiryFormSeconds,
+		},
+		{
+			name:     "float",
+			yaml:     `0.25`,
+			want:     utc.Add(250 * time.Millisecond),
+			wantForm: ExpiryFormSeconds,
+		},
+		{
+			name:     "numeric string",
+			yaml:     `"3600"`,
+			want:     utc.Add(time.Hour),
+			wantForm: ExpiryFormSeconds,
+		},
+		{
+			name:     "duration",
+			yaml:     `1h`,
+			want:     utc.Add(time.Hour),
+			wantForm: ExpiryFormDuration,
+		},
+		{
+			name: "timestamp",
+			yaml: `2026-10-18T10:00:00Z`,
+			want: time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "time string",
+			yaml: `"2026-10-18 10:00:00"`,
+			want: time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC),
+		},
+		{name: "null", yaml: `null`},
+		{
+			name:    "invalid string",
+			yaml:    `soon`,
+			wantErr: "yaml: unmarshal errors:\n  invalid expiry: \"soon\"",
+		},
+		{
+			name:    "invalid type",
+			yaml:    `[1]`,
+			wantErr: "yaml: unmarshal errors:\n  invalid expiry",
+		},
+		{
+			name:    "NaN",
+			yaml:    `.nan`,
+			wantErr: "yaml: unmarshal errors:\n  invalid expiry: NaN",
+		},
+		{
+			name:    "infinity",
+			yaml:    `-.inf`,
+			wantErr: "yaml: unmarshal errors:\n  invalid expiry: -Inf",
+		},
+		{
+			name:    "overflow",
+			yaml:    `1e300`,
+			wantErr: "yaml: unmarshal errors:\n  invalid expiry: 1e+300",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Expiry{}.WithClock(clk)
+			err := yaml.Unmarshal([]byte(tt.yaml), &got)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(got.At()),
+				"expected %s, got %s", tt.want, got.At())
+			assert.Equal(t, tt.wantForm, got.Form())
+		})
+	}
+}
+
+func TestExpiry_Marshal(t *testing.T) {
+	tests := []struct {
+		name     string
+		form     ExpiryForm
+		expiry   Expiry
+		want     string
+		wantYAML string
+		wantErr  string
+	}{
+		{
+			name: "time",
+			form: ExpiryFormTime,
+			expiry: ExpiresAt(
+				time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC),
+			),
+			want:     `"2026-10-18T10:00:00Z"`,
+			wantYAML: "2026-10-18T10:00:00Z\n",
+		},
+		{
+			name:     "time with sub-second precision",
+			form:     ExpiryFormTime,
+			expiry:   ExpiresAt(utc8),
+			want:     `"2022-10-29T22:40:34.934349003+08:00"`,
+			wantYAML: "2022-10-29T22:40:34.934349003+08:00\n",
+		},
+		{
+			name:     "duration",
+			form:     ExpiryFormDuration,
+			expiry:   ExpiresIn(utc, 90*time.Minute+500*time.Millisecond),
+			want:     `"1h30m0s"`,
+			wantYAML: "1h30m0s\n",
+		},
+		{
+			name:     "seconds",
+			form:     ExpiryFormSeconds,
+			expiry:   ExpiresIn(utc, time.Hour+500*time.Millisecond),
+			want:     `3600`,
+			wantYAML: "3600\n",
+		},
+		{
+			name:     "expired duration",
+			form:     ExpiryFormDuration,
+			expiry:   ExpiresIn(utc, -time.Hour),
+			want:     `"0s"`,
+			wantYAML: "0s\n",
+		},
+		{
+			name:     "expired seconds",
+			form:     ExpiryFormSeconds,
+			expiry:   ExpiresIn(utc, -time.Hour),
+			want:     `0`,
+			wantYAML: "0\n",
+		},
+		{
+			name:    "invalid form",
+			form:    ExpiryForm(42),
+			expiry:  ExpiresIn(utc, time.Hour),
+			wantErr: "invalid expiry marshal form 42",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := tt.expiry.WithClock(NewFakeClock(utc)).WithForm(tt.form)
+
+			b, err := json.Marshal(e)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+
+				_, err = yaml.Marshal(e)
+				assert.ErrorContains(t, err, tt.wantErr)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(b))
+
+			b, err = yaml.Marshal(e)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantYAML, string(b))
+		})
+	}
+}
+
+func TestExpiry_RoundTrip(t *testing.T) {
+	clk := NewFakeClock(utc)
+
+	type token struct {
+		ExpiresIn Expiry `json:"expires_in" yaml:"expires_in"`
+	}
+
+	tok := token{ExpiresIn: Expiry{}.WithClock(clk)}
+	err := json.Unmarshal([]byte(`{"expires_in":3600}`), &tok)
+	require.NoError(t, err)
+	assert.Equal(t, utc.Add(time.Hour), tok.ExpiresIn.At())
+
+	clk.Advance(15 * time.Minute)
+
+	b, err := json.Marshal(tok)
+	require.NoError(t, err)
+	assert.Equal(t, `{"expires_in":2700}`, string(b))
+
+	b, err = yaml.Marshal(tok)
+	require.NoError(t, err)
+	assert.Equal(t, "expires_in: 2700\n", string(b))
+
+	assert.False(t, tok.ExpiresIn.Expired(clk.Now()))
+	clk.Advance(45 * time.Minute)
+	assert.True(t, tok.ExpiresIn.Expired(clk.Now()))
+}
@@ -0,0 +1,298 @@
+package tyme
+
+import (
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	rfc3339Second = "2006-01-02T15:04:05Z07:00"
+	rfc3339Milli  = "2006-01-02T15:04:05.000Z07:00"
+	rfc3339Micro  = "2006-01-02T15:04:05.000000Z07:00"
+	rfc3339Nano   = "2006-01-02T15:04:05.000000000Z07:00"
+)
+
+// TimeSecond is a wrapper around time.Time that implements JSON/YAML
+// marshaling/unmarshaling interfaces, using RFC 3339 format with second
+// precision.
+//
+// It marshals to a string in RFC 3339 format without any fractional seconds.
+//
+// It unmarshals from a string in RFC 3339 format with any number of fractional
+// second digits, rounding the result to the nearest second.
+//
+// Values are marshaled in their own location. Use TimeSecondUTC to always
+// marshal in UTC, with a "Z" suffix.
+type TimeSecond time.Time
+
+// TimeMilli is a wrapper around time.Time that implements JSON/YAML
+// marshaling/unmarshaling interfaces, using RFC 3339 format with millisecond
+// precision.
+//
+// It marshals to a string in RFC 3339 format with exactly three fractional
+// second digits.
+//
+// It unmarshals from a string in RFC 3339 format with any number of fractional
+// second digits, rounding the result to the nearest millisecond.
+//
+// Values are marshaled in their own location. Use TimeMilliUTC to always
+// marshal in UTC, with a "Z" suffix.
+type TimeMilli time.Time
+
+// TimeMicro is a wrapper around time.Time that implements JSON/YAML
+// marshaling/unmarshaling interfaces, using RFC 3339 format with microsecond
+// precision.
+//
+// It marshals to a string in RFC 3339 format with exactly six fractional
+// second digits.
+//
+// It unmarshals from a string in RFC 3339 format with any number of fractional
+// second digits, rounding the result to the nearest microsecond.
+//
+// Values are marshaled in their own location. Use TimeMicroUTC to always
+// marshal in UTC, with a "Z" suffix.
+type TimeMicro time.Time
+
+// TimeNano is a wrapper around time.Time that implements JSON/YAML
+// marshaling/unmarshaling interfaces, using RFC 3339 format with nanosecond
+// precision.
+//
+// It marshals to a string in RFC 3339 format with exactly nine fractional
+// second digits.
+//
+// It unmarshals from a string in RFC 3339 format with any number of fractional
+// second digits.
+//
+// Values are marshaled in their own location. Use TimeNanoUTC to always
+// marshal in UTC, with a "Z" suffix.
+type TimeNano time.Time
+
+// UTC is a Normalizer which converts times to UTC. It can be used with
+// Normalized to make any type in this package, or the ts package, always
+// marshal in UTC:
+//
+//	type Event struct {
+//		At tyme.Normalized[tyme.TimeMilli, tyme.UTC] `json:"at"`
+//	}
+type UTC struct{}
+
+// Normalization implements the Normalizer interface.
+func (UTC) Normalization() Normalization {
+	return Normalization{Location: time.UTC}
+}
+
+// TimeSecondUTC is a TimeSecond which is always marshaled in UTC, with a "Z"
+// suffix, and is converted to UTC when unmarshaled.
+type TimeSecondUTC = Normalized[TimeSecond, UTC]
+
+// TimeMilliUTC is a TimeMilli which is always marshaled in UTC, with a "Z"
+// suffix, and is converted to UTC when unmarshaled.
+type TimeMilliUTC = Normalized[TimeMilli, UTC]
+
+// TimeMicroUTC is a TimeMicro which is always marshaled in UTC, with a "Z"
+// suffix, and is converted to UTC when unmarshaled.
+type TimeMicroUTC = Normalized[TimeMicro, UTC]
+
+// TimeNanoUTC is a TimeNano which is always marshaled in UTC, with a "Z"
+// suffix, and is converted to UTC when unmarshaled.
+type TimeNanoUTC = Normalized[TimeNano, UTC]
+
+// MarshalJSON implements the json.Marshaler interface.
+func (t TimeSecond) MarshalJSON() ([]byte, error) {
+	return marshalFixedJSON(time.Time(t), time.Second, rfc3339Second)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (t *TimeSecond) UnmarshalJSON(b []byte) error {
+	nt, err := unmarshalFixedJSON(b, time.Second)
+	if err != nil {
+		return err
+	}
+
+	*t = TimeSecond(nt)
+
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (t TimeSecond) MarshalYAML() (interface{}, error) {
+	return marshalFixedYAML(time.Time(t), time.Second, rfc3339Second), nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (t *TimeSecond) UnmarshalYAML(node *yaml.Node) error {
+	nt, err := unmarshalFixedYAML(node, time.Second)
+	if err != nil {
+		return err
+	}
+
+	*t = TimeSecond(nt)
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (t TimeMilli) MarshalJSON() ([]byte, error) {
+	return marshalFixedJSON(time.Time(t), time.Millisecond, rfc3339Milli)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (t *TimeMilli) UnmarshalJSON(b []byte) error {
+	nt, err := unmarshalFixedJSON(b, time.Millisecond)
+	if err != nil {
+		return err
+	}
+
+	*t = TimeMilli(nt)
+
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (t TimeMilli) MarshalYAML() (interface{}, error) {
+	return marshalFixedYAML(time.Time(t), time.Millisecond, rfc3339Milli), nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (t *TimeMilli) UnmarshalYAML(node *yaml.Node) error {
+	nt, err := unmarshalFixedYAML(node, time.Millisecond)
+	if err != nil {
+		return err
+	}
+
+	*t = TimeMilli(nt)
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (t TimeMicro) MarshalJSON() ([]byte, error) {
+	return marshalFixedJSON(time.Time(t), time.Microsecond, rfc3339Micro)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (t *TimeMicro) UnmarshalJSON(b []byte) error {
+	nt, err := unmarshalFixedJSON(b, time.Microsecond)
+	if err != nil {
+		return err
+	}
+
+	*t = TimeMicro(nt)
+
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (t TimeMicro) MarshalYAML() (interface{}, error) {
+	return marshalFixedYAML(time.Time(t), time.Microsecond, rfc3339Micro), nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (t *TimeMicro) UnmarshalYAML(node *yaml.Node) error {
+	nt, err := unmarshalFixedYAML(node, time.Microsecond)
+	if err != nil {
+		return err
+	}
+
+	*t = TimeMicro(nt)
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (t TimeNano) MarshalJSON() ([]byte, error) {
+	return marshalFixedJSON(time.Time(t), time.Nanosecond, rfc3339Nano)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (t *TimeNano) UnmarshalJSON(b []byte) error {
+	nt, err := unmarshalFixedJSON(b, time.Nanosecond)
+	if err != nil {
+		return err
+	}
+
+	*t = TimeNano(nt)
+
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (t TimeNano) MarshalYAML() (interface{}, error) {
+	return marshalFixedYAML(time.Time(t), time.Nanosecond, rfc3339Nano), nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (t *TimeNano) UnmarshalYAML(node *yaml.Node) error {
+	nt, err := unmarshalFixedYAML(node, time.Nanosecond)
+	if err != nil {
+		return err
+	}
+
+	*t = TimeNano(nt)
+
+	return nil
+}
+
+func formatFixed(t time.Time, precision time.Duration, layout string) string {
+	return t.Round(precision).Format(layout)
+}
+
+func marshalFixedJSON(
+	t time.Time,
+	precision time.Duration,
+	layout string,
+) ([]byte, error) {
+	return []byte(strconv.Quote(formatFixed(t, precision, layout))), nil
+}
+
+func marshalFixedYAML(
+	t time.Time,
+	precision time.Duration,
+	layout string,
+) *yaml.Node {
+	return &yaml.Node{
+		Kind:  yaml.ScalarNode,
+		Tag:   "!!timestamp",
+		Value: formatFixed(t, precision, layout),
+	}
+}
+
+func unmarshalFixedJSON(b []byte, precision time.Duration) (time.Time, error) {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return t.Round(precision), nil
+}
+
+func unmarshalFixedYAML(
+	node *yaml.Node,
+	precision time.Duration,
+) (time.Time, error) {
+	var t time.Time
+	var err error
+
+	switch node.Tag {
+	case "!!timestamp":
+		err = node.Decode(&t)
+	case "!!str":
+		t, err = time.Parse(time.RFC3339Nano, node.Value)
+	default:
+		return time.Time{}, &yaml.TypeError{
+			Errors: []string{"invalid time format"},
+		}
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return t.Round(precision), nil
+}
@@ -0,0 +1,244 @@
+package tyme
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+var fixedMarshalTestCases = []struct {
+	name   string
+	t      time.Time
+	second string
+	milli  string
+	micro  string
+	nano   string
+}{
+	{
+		name:   "UTC",
+		t:      utc,
+		second: `2022-10-29T14:40:35Z`,
+		milli:  `2022-10-29T14:40:34.934Z`,
+		micro:  `2022-10-29T14:40:34.934349Z`,
+		nano:   `2022-10-29T14:40:34.934349003Z`,
+	},
+	{
+		name:   "UTC+8",
+		t:      utc8,
+		second: `2022-10-29T22:40:35+08:00`,
+		milli:  `2022-10-29T22:40:34.934+08:00`,
+		micro:  `2022-10-29T22:40:34.934349+08:00`,
+		nano:   `2022-10-29T22:40:34.934349003+08:00`,
+	},
+	{
+		name:   "UTC whole second",
+		t:      time.Date(2022, 10, 29, 14, 40, 5, 0, time.UTC),
+		second: `2022-10-29T14:40:05Z`,
+		milli:  `2022-10-29T14:40:05.000Z`,
+		micro:  `2022-10-29T14:40:05.000000Z`,
+		nano:   `2022-10-29T14:40:05.000000000Z`,
+	},
+	{
+		name:   "UTC+8 tenth of a second",
+		t:      time.Date(2022, 10, 29, 22, 40, 5, 1e8, loc),
+		second: `2022-10-29T22:40:05+08:00`,
+		milli:  `2022-10-29T22:40:05.100+08:00`,
+		micro:  `2022-10-29T22:40:05.100000+08:00`,
+		nano:   `2022-10-29T22:40:05.100000000+08:00`,
+	},
+}
+
+func TestTimeFixed_MarshalJSON(t *testing.T) {
+	for _, tt := range fixedMarshalTestCases {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := json.Marshal([]interface{}{
+				TimeSecond(tt.t),
+				TimeMilli(tt.t),
+				TimeMicro(tt.t),
+				TimeNano(tt.t),
+			})
+			require.NoError(t, err)
+
+			want := `["` + tt.second + `","` + tt.milli + `","` +
+				tt.micro + `","` + tt.nano + `"]`
+			assert.Equal(t, want, string(b))
+		})
+	}
+}
+
+func TestTimeFixed_MarshalYAML(t *testing.T) {
+	for _, tt := range fixedMarshalTestCases {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := yaml.Marshal([]interface{}{
+				TimeSecond(tt.t),
+				TimeMilli(tt.t),
+				TimeMicro(tt.t),
+				TimeNano(tt.t),
+			})
+			require.NoError(t, err)
+
+			want := "- " + tt.second + "\n- " + tt.milli + "\n- " +
+				tt.micro + "\n- " + tt.nano + "\n"
+			assert.Equal(t, want, string(b))
+		})
+	}
+}
+
+type fixedUTC struct{}
+
+func (fixedUTC) Normalization() Normalization {
+	return Normalization{
+		Location: time.UTC,
+		Min:      time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestTimeFixed_Normalized(t *testing.T) {
+	b, err := json.Marshal(Normalized[TimeMilli, fixedUTC](utc8))
+	require.NoError(t, err)
+	assert.Equal(t, `"2022-10-29T14:40:34.934Z"`, string(b))
+
+	b, err = yaml.Marshal(Normalized[TimeMicro, fixedUTC](utc8))
+	require.NoError(t, err)
+	assert.Equal(t, "2022-10-29T14:40:34.934349Z\n", string(b))
+
+	var got Normalized[TimeSecond, fixedUTC]
+	err = json.Unmarshal([]byte(`"2022-10-29T22:40:34.6+08:00"`), &got)
+	require.NoError(t, err)
+	assert.Equal(t, utc.Round(time.Second), got.Time())
+	assert.Equal(t, time.UTC, got.Time().Location())
+
+	var nano Normalized[TimeNano, fixedUTC]
+	err = yaml.Unmarshal([]byte("1999-12-31T23:59:59Z"), &nano)
+	assert.ErrorContains(t, err, "is before 2000-01-01T00:00:00Z")
+}
+
+func TestTimeFixed_Unmarshal(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		second  time.Time
+		milli   time.Time
+		micro   time.Time
+		nano    time.Time
+		wantErr string
+	}{
+		{
+			name:   "nanosecond input",
+			s:      `2022-10-29T14:40:34.934349003Z`,
+			second: utc.Round(time.Second),
+			milli:  utc.Round(time.Millisecond),
+			micro:  utc.Round(time.Microsecond),
+			nano:   utc,
+		},
+		{
+			name:   "millisecond input rounds up",
+			s:      `2022-10-29T22:40:34.9995+08:00`,
+			second: time.Date(2022, 10, 29, 22, 40, 35, 0, loc),
+			milli:  time.Date(2022, 10, 29, 22, 40, 35, 0, loc),
+			micro:  time.Date(2022, 10, 29, 22, 40, 34, 999500000, loc),
+			nano:   time.Date(2022, 10, 29, 22, 40, 34, 999500000, loc),
+		},
+		{
+			name:   "second input",
+			s:      `2022-10-29T14:40:35Z`,
+			second: utc.Round(time.Second),
+			milli:  utc.Round(time.Second),
+			micro:  utc.Round(time.Second),
+			nano:   utc.Round(time.Second),
+		},
+		{
+			name:    "non-RFC 3339 input",
+			s:       `October 29th, 2022, 14:40:35`,
+			wantErr: "cannot parse",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+" JSON", func(t *testing.T) {
+			b := []byte(`"` + tt.s + `"`)
+
+			var s TimeSecond
+			var ms TimeMilli
+			var us TimeMicro
+			var ns TimeNano
+			errs := []error{
+				json.Unmarshal(b, &s),
+				json.Unmarshal(b, &ms),
+				json.Unmarshal(b, &us),
+				json.Unmarshal(b, &ns),
+			}
+
+			if tt.wantErr != "" {
+				for _, err := range errs {
+					assert.ErrorContains(t, err, tt.wantErr)
+				}
+
+				return
+			}
+			for _, err := range errs {
+				require.NoError(t, err)
+			}
+			assert.True(t, tt.second.Equal(time.Time(s)))
+			assert.True(t, tt.milli.Equal(time.Time(ms)))
+			assert.True(t, tt.micro.Equal(time.Time(us)))
+			assert.True(t, tt.nano.Equal(time.Time(ns)))
+		})
+		t.Run(tt.name+" YAML", func(t *testing.T) {
+			b := []byte(tt.s)
+
+			var s TimeSecond
+			var ms TimeMilli
+			var us TimeMicro
+			var ns TimeNano
+			errs := []error{
+				yaml.Unmarshal(b, &s),
+				yaml.Unmarshal(b, &ms),
+				yaml.Unmarshal(b, &us),
+				yaml.Unmarshal(b, &ns),
+			}
+
+			if tt.wantErr != "" {
+				for _, err := range errs {
+					assert.ErrorContains(t, err, tt.wantErr)
+				}
+
+				return
+			}
+			for _, err := range errs {
+				require.NoError(t, err)
+			}
+			assert.True(t, tt.second.Equal(time.Time(s)))
+			assert.True(t, tt.milli.Equal(time.Time(ms)))
+			assert.True(t, tt.micro.Equal(time.Time(us)))
+			assert.True(t, tt.nano.Equal(time.Time(ns)))
+		})
+	}
+}
+
+func TestTimeFixed_UTC(t *testing.T) {
+	b, err := json.Marshal([]interface{}{
+		TimeSecondUTC(utc8),
+		TimeMilliUTC(utc8),
+		TimeMicroUTC(utc8),
+		TimeNanoUTC(utc8),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `["2022-10-29T14:40:35Z","2022-10-29T14:40:34.934Z",`+
+		`"2022-10-29T14:40:34.934349Z","2022-10-29T14:40:34.934349003Z"]`,
+		string(b),
+	)
+
+	b, err = yaml.Marshal(TimeMilliUTC(utc8))
+	require.NoError(t, err)
+	assert.Equal(t, "2022-10-29T14:40:34.934Z\n", string(b))
+
+	var got TimeMicroUTC
+	err = json.Unmarshal([]byte(`"2022-10-29T22:40:34.9343494+08:00"`), &got)
+	require.NoError(t, err)
+	assert.Equal(t, utc.Round(time.Microsecond), got.Time())
+	assert.Equal(t, time.UTC, got.Time().Location())
+}
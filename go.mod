@@ -1,9 +1,11 @@
 module github.com/jimeh/go-tyme
 
-go 1.18
+go 1.23
 
 require (
 	github.com/araddon/dateparse v0.0.0-20210429162001-6b43995a97de
+	github.com/jimeh/go-tyme/dur v0.0.0-20221030033507-5d31aa674303
+	github.com/jimeh/go-tyme/ts v0.1.0
 	github.com/stretchr/testify v1.8.1
 	gopkg.in/yaml.v3 v3.0.1
 )
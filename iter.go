@@ -0,0 +1,72 @@
+package tyme
+
+import (
+	"iter"
+	"time"
+
+	"github.com/jimeh/go-tyme/ts"
+)
+
+// Every returns an iterator over the Timestamps from start up to, but
+// excluding, end, in steps of the absolute duration step. A negative step
+// iterates backwards from start down to, but excluding, end. A zero step
+// yields nothing.
+//
+// Steps are added to the previous value as a time.Time, rather than as T, so
+// any rounding or location conversion in T does not accumulate.
+func Every[T Timestamp, D ts.Duration](start, end T, step D) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		t, e, d := time.Time(start), time.Time(end), time.Duration(step)
+		if d == 0 {
+			return
+		}
+
+		for ; (d > 0 && t.Before(e)) || (d < 0 && t.After(e)); t = t.Add(d) {
+			if !yield(T(t)) {
+				return
+			}
+		}
+	}
+}
+
+// Days returns an iterator over the Timestamps from start up to, but
+// excluding, end, in steps of one calendar day in loc. Each yielded value has
+// the same wall clock time as start in loc, regardless of daylight saving time
+// transitions, which makes some days 23 or 25 hours long. When loc is nil,
+// start's location is used.
+func Days[T Timestamp](start, end T, loc *time.Location) iter.Seq[T] {
+	return calendarSeq(start, end, loc, 0, 1)
+}
+
+// Months returns an iterator over the Timestamps from start up to, but
+// excluding, end, in steps of one calendar month in loc. Each yielded value
+// has the same wall clock time and day of month as start in loc, clamped to
+// the last day of shorter months, so iterating from January 31 yields
+// February 28 followed by March 31. When loc is nil, start's location is used.
+func Months[T Timestamp](start, end T, loc *time.Location) iter.Seq[T] {
+	return calendarSeq(start, end, loc, 1, 0)
+}
+
+func calendarSeq[T Timestamp](
+	start, end T,
+	loc *time.Location,
+	months, days int,
+) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		s, e := time.Time(start), time.Time(end)
+		if loc != nil {
+			s = s.In(loc)
+		}
+
+		for i := 0; ; i++ {
+			t := AddDate(s, 0, i*months, i*days, OverflowClamp)
+			if !t.Before(e) {
+				return
+			}
+
+			if !yield(T(t)) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package tyme
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/jimeh/go-tyme/dur"
+	"github.com/jimeh/go-tyme/ts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvery(t *testing.T) {
+	at := func(h, m int) Time {
+		return Time(time.Date(2023, 10, 11, h, m, 0, 0, time.UTC))
+	}
+
+	tests := []struct {
+		name  string
+		start Time
+		end   Time
+		step  dur.Duration
+		want  []Time
+	}{
+		{
+			name:  "forwards excluding end",
+			start: at(10, 0),
+			end:   at(11, 0),
+			step:  dur.Duration(20 * time.Minute),
+			want:  []Time{at(10, 0), at(10, 20), at(10, 40)},
+		},
+		{
+			name:  "forwards uneven",
+			start: at(10, 0),
+			end:   at(10, 50),
+			step:  dur.Duration(20 * time.Minute),
+			want:  []Time{at(10, 0), at(10, 20), at(10, 40)},
+		},
+		{
+			name:  "backwards",
+			start: at(11, 0),
+			end:   at(10, 0),
+			step:  dur.Duration(-30 * time.Minute),
+			want:  []Time{at(11, 0), at(10, 30)},
+		},
+		{
+			name:  "end before start",
+			start: at(11, 0),
+			end:   at(10, 0),
+			step:  dur.Duration(time.Minute),
+			want:  nil,
+		},
+		{
+			name:  "zero step",
+			start: at(10, 0),
+			end:   at(11, 0),
+			step:  0,
+			want:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := slices.Collect(Every(tt.start, tt.end, tt.step))
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEvery_LongRange(t *testing.T) {
+	// Five steps of 200 years exceed the range of time.Duration.
+	step := 200 * 365 * 24 * time.Hour
+	start := time.Date(1700, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2500, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	got := slices.Collect(Every(Time(start), Time(end), dur.Duration(step)))
+
+	require.Len(t, got, 5)
+	assert.Equal(t, Time(start), got[0])
+	for i := 1; i < len(got); i++ {
+		assert.Equal(t, step, time.Time(got[i]).Sub(time.Time(got[i-1])))
+	}
+}
+
+func TestEvery_Break(t *testing.T) {
+	start := ts.Second(utc)
+
+	var got []ts.Second
+	for v := range Every(start, ts.Second(utc.Add(time.Hour)), time.Second) {
+		got = append(got, v)
+		if len(got) == 3 {
+			break
+		}
+	}
+
+	assert.Equal(t, []ts.Second{
+		start,
+		ts.Second(utc.Add(time.Second)),
+		ts.Second(utc.Add(2 * time.Second)),
+	}, got)
+}
+
+func TestDays(t *testing.T) {
+	nyc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	start := ts.Millisecond(time.Date(2023, 3, 11, 14, 30, 0, 0, time.UTC))
+	end := ts.Millisecond(time.Date(2023, 3, 14, 0, 0, 0, 0, time.UTC))
+
+	got := slices.Collect(Days(start, end, nyc))
+
+	assert.Equal(t, []ts.Millisecond{
+		ts.Millisecond(time.Date(2023, 3, 11, 9, 30, 0, 0, nyc)),
+		ts.Millisecond(time.Date(2023, 3, 12, 9, 30, 0, 0, nyc)),
+		ts.Millisecond(time.Date(2023, 3, 13, 9, 30, 0, 0, nyc)),
+	}, got)
+	assert.Equal(t,
+		23*time.Hour, time.Time(got[1]).Sub(time.Time(got[0])),
+	)
+
+	got = slices.Collect(Days(start, end, nil))
+
+	assert.Len(t, got, 3)
+	assert.Equal(t, time.UTC, got[0].Time().Location())
+}
+
+func TestMonths(t *testing.T) {
+	start := Time(time.Date(2024, 1, 31, 12, 0, 0, 0, loc))
+	end := Time(time.Date(2024, 6, 1, 0, 0, 0, 0, loc))
+
+	got := slices.Collect(Months(start, end, nil))
+
+	assert.Equal(t, []Time{
+		Time(time.Date(2024, 1, 31, 12, 0, 0, 0, loc)),
+		Time(time.Date(2024, 2, 29, 12, 0, 0, 0, loc)),
+		Time(time.Date(2024, 3, 31, 12, 0, 0, 0, loc)),
+		Time(time.Date(2024, 4, 30, 12, 0, 0, 0, loc)),
+		Time(time.Date(2024, 5, 31, 12, 0, 0, 0, loc)),
+	}, got)
+}
@@ -0,0 +1,138 @@
+package tyme
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/jimeh/go-tyme/ts"
+	"gopkg.in/yaml.v3"
+)
+
+// Normalization describes how time values are normalized after being
+// unmarshaled. It is an alias for ts.Normalization, allowing the same rules to
+// be shared between the tyme and ts packages.
+type Normalization = ts.Normalization
+
+// Normalizer is implemented by types which declare the Normalization applied
+// by a Normalized. It is an alias for ts.Normalizer.
+type Normalizer = ts.Normalizer
+
+// Normalized is a thin wrapper around ts.Normalized, which also accepts the
+// types in this package as T:
+//
+//	type postgres struct{}
+//
+//	func (postgres) Normalization() tyme.Normalization {
+//		return tyme.Normalization{
+//			Location:  time.UTC,
+//			Precision: time.Microsecond,
+//		}
+//	}
+//
+//	type Event struct {
+//		At tyme.Normalized[tyme.Time, postgres] `json:"at"`
+//	}
+//
+// It marshals and unmarshals JSON, YAML and text in the same way as T, and
+// otherwise behaves exactly like ts.Normalized.
+type Normalized[T Timestamp, N Normalizer] time.Time
+
+// Time returns the time.Time corresponding to the instant t.
+func (t Normalized[T, N]) Time() time.Time {
+	return time.Time(t)
+}
+
+// Set sets t to v, normalized with the Normalization declared by N.
+func (t *Normalized[T, N]) Set(v time.Time) error {
+	return t.base().Set(v)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (t Normalized[T, N]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.value())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (t *Normalized[T, N]) UnmarshalJSON(b []byte) error {
+	var v T
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	return t.Set(time.Time(v))
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (t Normalized[T, N]) MarshalYAML() (interface{}, error) {
+	return t.value(), nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (t *Normalized[T, N]) UnmarshalYAML(node *yaml.Node) error {
+	var v T
+	if err := node.Decode(&v); err != nil {
+		return err
+	}
+
+	return t.Set(time.Time(v))
+}
+
+// MarshalText implements the encoding.TextMarshaler interface, producing the
+// same value as MarshalJSON, without any JSON string quotes.
+func (t Normalized[T, N]) MarshalText() ([]byte, error) {
+	b, err := t.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	if s, err := strconv.Unquote(string(b)); err == nil {
+		return []byte(s), nil
+	}
+
+	return b, nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface, accepting
+// the same values as UnmarshalJSON does for JSON strings.
+func (t *Normalized[T, N]) UnmarshalText(text []byte) error {
+	return t.UnmarshalJSON([]byte(strconv.Quote(string(text))))
+}
+
+// Scan implements the sql.Scanner interface. String, []byte, int64 and
+// float64 values are unmarshaled with UnmarshalText, all other values are
+// handled as by ts.Normalized.
+func (t *Normalized[T, N]) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case string:
+		return t.UnmarshalText([]byte(v))
+	case []byte:
+		return t.UnmarshalText(v)
+	case int64:
+		return t.UnmarshalText(strconv.AppendInt(nil, v, 10))
+	case float64:
+		return t.UnmarshalText(strconv.AppendFloat(nil, v, 'f', -1, 64))
+	}
+
+	return t.base().Scan(src)
+}
+
+// Value implements the driver.Valuer interface, returning t as a time.Time in
+// the location of N's Normalization, if any.
+func (t Normalized[T, N]) Value() (driver.Value, error) {
+	return t.base().Value()
+}
+
+// base returns t as a ts.Normalized, which implements normalization for all
+// values of T.
+func (t *Normalized[T, N]) base() *ts.Normalized[time.Time, N] {
+	return (*ts.Normalized[time.Time, N])(t)
+}
+
+// value returns t as T, in the location of N's Normalization, if any.
+func (t Normalized[T, N]) value() T {
+	var n N
+
+	return T(n.Normalization().In(time.Time(t)))
+}
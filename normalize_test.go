@@ -0,0 +1,125 @@
+package tyme
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/jimeh/go-tyme/ts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+type microsecondUTC struct{}
+
+func (microsecondUTC) Normalization() Normalization {
+	return Normalization{Location: time.UTC, Precision: time.Microsecond}
+}
+
+type strictMilliUTC struct{}
+
+func (strictMilliUTC) Normalization() Normalization {
+	return Normalization{
+		Location:  time.UTC,
+		Precision: time.Millisecond,
+		Round:     true,
+		Strict:    true,
+	}
+}
+
+func TestNormalized_Time(t *testing.T) {
+	s := `2022-10-29T22:40:34.934349003+08:00`
+	want := utc.Truncate(time.Microsecond)
+
+	var got Normalized[Time, microsecondUTC]
+	err := json.Unmarshal([]byte(`"`+s+`"`), &got)
+	require.NoError(t, err)
+	assert.Equal(t, want, got.Time())
+
+	b, err := json.Marshal(got)
+	require.NoError(t, err)
+	assert.Equal(t, `"2022-10-29T14:40:34.934349Z"`, string(b))
+
+	got = Normalized[Time, microsecondUTC]{}
+	err = yaml.Unmarshal([]byte(s), &got)
+	require.NoError(t, err)
+	assert.Equal(t, want, got.Time())
+
+	b, err = yaml.Marshal(got)
+	require.NoError(t, err)
+	assert.Equal(t, "2022-10-29T14:40:34.934349Z\n", string(b))
+}
+
+func TestNormalized_TimeRFC3339(t *testing.T) {
+	s := `2022-10-29T22:40:34.934000000+08:00`
+	want := time.Date(2022, 10, 29, 14, 40, 34, 934000000, time.UTC)
+
+	var got Normalized[TimeRFC3339, strictMilliUTC]
+	err := json.Unmarshal([]byte(`"`+s+`"`), &got)
+	require.NoError(t, err)
+	assert.Equal(t, want, got.Time())
+
+	got = Normalized[TimeRFC3339, strictMilliUTC]{}
+	err = yaml.Unmarshal([]byte(`"`+s+`"`), &got)
+	require.NoError(t, err)
+	assert.Equal(t, want, got.Time())
+
+	s = `2022-10-29T22:40:34.934549003+08:00`
+	err = json.Unmarshal([]byte(`"`+s+`"`), &got)
+	assert.ErrorIs(t, err, ts.ErrExcessPrecision)
+
+	err = yaml.Unmarshal([]byte(`"`+s+`"`), &got)
+	assert.ErrorIs(t, err, ts.ErrExcessPrecision)
+
+	err = yaml.Unmarshal([]byte(`[1]`), &got)
+	assert.Error(t, err)
+}
+
+func TestNormalized_ts(t *testing.T) {
+	var got Normalized[ts.Millisecond, microsecondUTC]
+	err := json.Unmarshal([]byte("1667054434934"), &got)
+	require.NoError(t, err)
+	assert.Equal(t, utc.Truncate(time.Millisecond), got.Time())
+	assert.Equal(t, time.UTC, got.Time().Location())
+}
+
+func TestNormalized_TextAndSQL(t *testing.T) {
+	s := `2022-10-29T22:40:34.934349003+08:00`
+	want := utc.Truncate(time.Microsecond)
+
+	var got Normalized[Time, microsecondUTC]
+	err := got.UnmarshalText([]byte(s))
+	require.NoError(t, err)
+	assert.Equal(t, want, got.Time())
+
+	b, err := got.MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, "2022-10-29T14:40:34.934349Z", string(b))
+
+	got = Normalized[Time, microsecondUTC]{}
+	err = got.Scan([]byte(s))
+	require.NoError(t, err)
+	assert.Equal(t, want, got.Time())
+
+	err = got.Scan(utc)
+	require.NoError(t, err)
+	assert.Equal(t, want, got.Time())
+
+	v, err := got.Value()
+	require.NoError(t, err)
+	assert.Equal(t, want, v)
+
+	err = got.Scan(nil)
+	require.NoError(t, err)
+	assert.True(t, got.Time().IsZero())
+
+	var ms Normalized[ts.Millisecond, microsecondUTC]
+	err = ms.Scan(int64(1667054434934))
+	require.NoError(t, err)
+	assert.Equal(t, utc.Truncate(time.Millisecond), ms.Time())
+
+	var strict Normalized[TimeRFC3339, strictMilliUTC]
+	err = strict.Scan(s)
+	assert.ErrorIs(t, err, ts.ErrExcessPrecision)
+}
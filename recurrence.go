@@ -0,0 +1,1136 @@
+package tyme
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrInvalidRecurrence is returned when parsing an invalid recurrence.
+var ErrInvalidRecurrence = errors.New("invalid recurrence")
+
+// recurSearchYears is the number of years a recurrence rule is expanded
+// without finding an occurrence, before it is considered to have no further
+// occurrences.
+const recurSearchYears = 10
+
+type recurFreq int
+
+const (
+	recurSecondly recurFreq = iota
+	recurMinutely
+	recurHourly
+	recurDaily
+	recurWeekly
+	recurMonthly
+	recurYearly
+)
+
+var recurFreqs = map[string]recurFreq{
+	"SECONDLY": recurSecondly,
+	"MINUTELY": recurMinutely,
+	"HOURLY":   recurHourly,
+	"DAILY":    recurDaily,
+	"WEEKLY":   recurWeekly,
+	"MONTHLY":  recurMonthly,
+	"YEARLY":   recurYearly,
+}
+
+var recurWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// Recurrence is a set of recurring times described by the RFC 5545 (iCalendar)
+// text form of a DTSTART property, an optional RRULE, and any number of RDATE
+// and EXDATE properties, one per line:
+//
+//	DTSTART;TZID=America/New_York:20230110T090000
+//	RRULE:FREQ=MONTHLY;BYDAY=2TU;COUNT=10
+//	EXDATE;TZID=America/New_York:20230411T090000
+//
+// All rule parts of RFC 5545 are supported, including BYDAY ordinals like
+// "-1FR" and BYSETPOS. As required by RFC 5545, DTSTART is always the first
+// occurrence, and counts towards COUNT. EXDATE values are excluded after COUNT
+// has been applied.
+//
+// Occurrences are expanded on the wall clock of DTSTART's location, given by
+// its TZID parameter, UTC when it has a "Z" suffix, or the location given to
+// ParseRecurrenceInLocation otherwise. Wall clock times which are skipped by a
+// daylight saving time transition occur at the same time in the UTC offset
+// before the transition, and wall clock times which are repeated only occur
+// at their first instance.
+type Recurrence struct {
+	text    string
+	start   time.Time
+	rule    *recurRule
+	rdates  []time.Time
+	exdates []time.Time
+	exdays  []time.Time
+}
+
+type recurRule struct {
+	freq       recurFreq
+	interval   int
+	count      int
+	until      time.Time
+	wkst       time.Weekday
+	byMonth    []int
+	byWeekNo   []int
+	byYearDay  []int
+	byMonthDay []int
+	byDay      []recurDay
+	byHour     []int
+	byMinute   []int
+	bySecond   []int
+	bySetPos   []int
+}
+
+type recurDay struct {
+	n       int
+	weekday time.Weekday
+}
+
+// ParseRecurrence parses the RFC 5545 text form of a recurrence, as described
+// on Recurrence. Lines may be separated by "\n" or "\r\n", and may be folded
+// by starting continuation lines with a single space or tab. A DTSTART line
+// is required. Floating values without a TZID parameter or "Z" suffix are
+// interpreted in UTC. Rules whose BYMONTH and BYMONTHDAY, or BYHOUR, BYMINUTE
+// and BYSECOND rule parts can never match are rejected.
+func ParseRecurrence(s string) (Recurrence, error) {
+	return ParseRecurrenceInLocation(s, time.UTC)
+}
+
+// ParseRecurrenceInLocation is like ParseRecurrence but interprets floating
+// DTSTART, RDATE and EXDATE values in loc.
+func ParseRecurrenceInLocation(
+	s string,
+	loc *time.Location,
+) (Recurrence, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	var lines []string
+	for _, line := range unfoldRecurLines(s) {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	r := Recurrence{text: strings.Join(lines, "\n")}
+
+	var ruleValue string
+	var rdates, exdates []string
+	for _, line := range lines {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return Recurrence{}, recurrenceError("invalid line %q", line)
+		}
+
+		prop, _, _ := strings.Cut(name, ";")
+		switch strings.ToUpper(prop) {
+		case "DTSTART":
+			if !r.start.IsZero() {
+				return Recurrence{}, recurrenceError("multiple DTSTART lines")
+			}
+
+			t, _, err := parseRecurTime(name, value, loc)
+			if err != nil {
+				return Recurrence{}, err
+			}
+			r.start = t
+		case "RRULE":
+			if ruleValue != "" {
+				return Recurrence{}, recurrenceError("multiple RRULE lines")
+			}
+			ruleValue = value
+		case "RDATE":
+			rdates = append(rdates, line)
+		case "EXDATE":
+			exdates = append(exdates, line)
+		default:
+			return Recurrence{}, recurrenceError("unknown property %q", prop)
+		}
+	}
+
+	if r.start.IsZero() {
+		return Recurrence{}, recurrenceError("missing DTSTART")
+	}
+
+	if ruleValue != "" {
+		rule, err := parseRecurRule(ruleValue, r.start)
+		if err != nil {
+			return Recurrence{}, err
+		}
+		r.rule = rule
+	}
+
+	loc = r.start.Location()
+	for _, line := range rdates {
+		name, value, _ := strings.Cut(line, ":")
+		if strings.Contains(strings.ToUpper(name), "VALUE=PERIOD") {
+			return Recurrence{}, recurrenceError("unsupported RDATE period")
+		}
+
+		for _, v := range strings.Split(value, ",") {
+			t, _, err := parseRecurTime(name, v, loc)
+			if err != nil {
+				return Recurrence{}, err
+			}
+			r.rdates = append(r.rdates, t.In(loc))
+		}
+	}
+	sort.Slice(r.rdates, func(i, j int) bool {
+		return r.rdates[i].Before(r.rdates[j])
+	})
+
+	for _, line := range exdates {
+		name, value, _ := strings.Cut(line, ":")
+		for _, v := range strings.Split(value, ",") {
+			t, date, err := parseRecurTime(name, v, loc)
+			if err != nil {
+				return Recurrence{}, err
+			}
+
+			if date {
+				r.exdays = append(r.exdays, cronWall(t))
+			} else {
+				r.exdates = append(r.exdates, t)
+			}
+		}
+	}
+
+	return r, nil
+}
+
+// MustParseRecurrence is like ParseRecurrence but panics if s cannot be
+// parsed.
+func MustParseRecurrence(s string) Recurrence {
+	r, err := ParseRecurrence(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return r
+}
+
+// String returns the text form of the recurrence, with lines separated by
+// "\n".
+func (r Recurrence) String() string {
+	return r.text
+}
+
+// Start returns the DTSTART time of the recurrence, which is its first
+// occurrence.
+func (r Recurrence) Start() time.Time {
+	return r.start
+}
+
+// Location returns the location of DTSTART, which occurrences are expanded
+// in.
+func (r Recurrence) Location() *time.Location {
+	return r.start.Location()
+}
+
+// IsZero reports whether r is the zero Recurrence, which has no occurrences.
+func (r Recurrence) IsZero() bool {
+	return r.start.IsZero()
+}
+
+// All returns an iterator over all occurrences of the recurrence in ascending
+// order, in the location of DTSTART. Unless limited by COUNT or UNTIL, the
+// iterator is infinite.
+func (r Recurrence) All() iter.Seq[time.Time] {
+	return r.from(time.Time{})
+}
+
+// from is like All, but skips ahead to the rule period containing t where the
+// rule has no COUNT, so the iterator may omit occurrences before t.
+func (r Recurrence) from(t time.Time) iter.Seq[time.Time] {
+	return func(yield func(time.Time) bool) {
+		if r.IsZero() {
+			return
+		}
+
+		var last time.Time
+		var started bool
+		emit := func(t time.Time) bool {
+			if (started && !t.After(last)) || r.excluded(t) {
+				return true
+			}
+			last, started = t, true
+
+			return yield(t)
+		}
+
+		i := 0
+		ok := r.expand(t, func(t time.Time) bool {
+			for ; i < len(r.rdates) && !r.rdates[i].After(t); i++ {
+				if !emit(r.rdates[i]) {
+					return false
+				}
+			}
+
+			return emit(t)
+		})
+		if !ok {
+			return
+		}
+
+		for ; i < len(r.rdates); i++ {
+			if !emit(r.rdates[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Between returns all occurrences of the recurrence which are at or after
+// start, and before end, in ascending order.
+func (r Recurrence) Between(start, end time.Time) []time.Time {
+	var times []time.Time
+	for t := range r.from(start) {
+		if !t.Before(end) {
+			break
+		}
+
+		if !t.Before(start) {
+			times = append(times, t)
+		}
+	}
+
+	return times
+}
+
+// Next returns the first occurrence of the recurrence after t, or the zero
+// time if there is none.
+func (r Recurrence) Next(t time.Time) time.Time {
+	for next := range r.from(t) {
+		if next.After(t) {
+			return next
+		}
+	}
+
+	return time.Time{}
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (r Recurrence) MarshalText() ([]byte, error) {
+	return []byte(r.text), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (r *Recurrence) UnmarshalText(text []byte) error {
+	nr, err := ParseRecurrence(string(text))
+	if err != nil {
+		return err
+	}
+	*r = nr
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (r Recurrence) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.text)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (r *Recurrence) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	return r.UnmarshalText([]byte(s))
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (r Recurrence) MarshalYAML() (interface{}, error) {
+	return r.text, nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (r *Recurrence) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.ScalarNode || node.Tag != "!!str" {
+		return &yaml.TypeError{
+			Errors: []string{ErrInvalidRecurrence.Error()},
+		}
+	}
+
+	if err := r.UnmarshalText([]byte(node.Value)); err != nil {
+		return &yaml.TypeError{Errors: []string{err.Error()}}
+	}
+
+	return nil
+}
+
+func (r Recurrence) excluded(t time.Time) bool {
+	for _, ex := range r.exdates {
+		if ex.Equal(t) {
+			return true
+		}
+	}
+
+	if len(r.exdays) > 0 {
+		y, m, d := t.In(r.Location()).Date()
+		day := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+		for _, ex := range r.exdays {
+			if ex.Equal(day) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// expand calls yield with DTSTART followed by each time generated by the
+// rule, in ascending order, until yield returns false. It returns false if
+// yield did. Unless the rule has a COUNT, periods which end before from are
+// skipped.
+func (r Recurrence) expand(from time.Time, yield func(time.Time) bool) bool {
+	rule := r.rule
+	if rule == nil {
+		return yield(r.start)
+	}
+
+	if !rule.until.IsZero() && r.start.After(rule.until) {
+		return true
+	}
+
+	if !yield(r.start) {
+		return false
+	}
+
+	n := 1
+	if rule.count == 1 {
+		return true
+	}
+
+	loc := r.Location()
+	start := cronWall(r.start)
+	last := r.start
+	lastWall := start
+
+	// Wall clock times skipped by a daylight saving time transition resolve
+	// to later instants, so periods are skipped up to cronShift before from.
+	k0 := 0
+	if rule.count == 0 && from.After(r.start) {
+		k0 = rule.periodIndex(start, cronWall(from.In(loc)).Add(-cronShift))
+		lastWall = rule.period(start, k0)
+	}
+
+	for k := k0; ; {
+		p := rule.period(start, k)
+		if p.After(lastWall.AddDate(recurSearchYears, 0, 0)) ||
+			p.Year() > 9999 {
+			return true
+		}
+
+		// Periods before the next wall clock time which can match have no
+		// candidates, so are skipped rather than expanded one at a time.
+		if w := rule.nextWall(p); w.After(p) {
+			if k = rule.periodIndex(start, w); rule.period(start, k).Before(w) {
+				k++
+			}
+
+			continue
+		}
+		k++
+
+		for _, w := range rule.candidates(p, start) {
+			if w.Before(start) {
+				continue
+			}
+			lastWall = w
+
+			t := recurResolve(w, loc)
+			if !t.After(last) {
+				continue
+			}
+
+			if !rule.until.IsZero() && t.After(rule.until) {
+				return true
+			}
+
+			if !yield(t) {
+				return false
+			}
+			last = t
+
+			if n++; rule.count > 0 && n >= rule.count {
+				return true
+			}
+		}
+	}
+}
+
+// nextWall returns the earliest wall clock time at or after the start of the
+// period p which may match the rule. Like Cron's nextWall, it skips whole
+// months, days, hours and minutes which do not match, for rules with periods
+// no longer than a day.
+func (rule *recurRule) nextWall(p time.Time) time.Time {
+	if rule.freq > recurDaily {
+		return p
+	}
+
+	y, m, d := p.Date()
+	switch {
+	case len(rule.byMonth) > 0 && !containsInt(rule.byMonth, int(m)):
+		return time.Date(y, m+1, 1, 0, 0, 0, 0, time.UTC)
+	case !rule.dayMatches(p):
+		return time.Date(y, m, d+1, 0, 0, 0, 0, time.UTC)
+	case rule.freq < recurDaily && len(rule.byHour) > 0 &&
+		!containsInt(rule.byHour, p.Hour()):
+		h := nextInt(rule.byHour, p.Hour(), 24)
+		return time.Date(y, m, d, h, 0, 0, 0, time.UTC)
+	case rule.freq < recurHourly && len(rule.byMinute) > 0 &&
+		!containsInt(rule.byMinute, p.Minute()):
+		min := nextInt(rule.byMinute, p.Minute(), 60)
+		return p.Truncate(time.Hour).Add(time.Duration(min) * time.Minute)
+	case rule.freq < recurMinutely && len(rule.bySecond) > 0 &&
+		!containsInt(rule.bySecond, p.Second()):
+		sec := nextInt(rule.bySecond, p.Second(), 60)
+		return p.Truncate(time.Minute).Add(time.Duration(sec) * time.Second)
+	}
+
+	return p
+}
+
+// period returns the wall clock start of the k-th period of the rule after
+// the one containing start.
+func (rule *recurRule) period(start time.Time, k int) time.Time {
+	y, m, d := start.Date()
+	n := k * rule.interval
+
+	switch rule.freq {
+	case recurYearly:
+		return time.Date(y+n, 1, 1, 0, 0, 0, 0, time.UTC)
+	case recurMonthly:
+		return time.Date(y, m+time.Month(n), 1, 0, 0, 0, 0, time.UTC)
+	case recurWeekly:
+		d -= int(start.Weekday()-rule.wkst+7) % 7
+		return time.Date(y, m, d+7*n, 0, 0, 0, 0, time.UTC)
+	case recurDaily:
+		return time.Date(y, m, d+n, 0, 0, 0, 0, time.UTC)
+	case recurHourly:
+		return start.Truncate(time.Hour).Add(time.Duration(n) * time.Hour)
+	case recurMinutely:
+		return start.Truncate(time.Minute).
+			Add(time.Duration(n) * time.Minute)
+	default:
+		return start.Add(time.Duration(n) * time.Second)
+	}
+}
+
+// periodIndex returns the index k of the last period of the rule starting
+// at or before the wall clock time w, as passed to period.
+func (rule *recurRule) periodIndex(start, w time.Time) int {
+	if !w.After(start) {
+		return 0
+	}
+
+	var n int
+	switch rule.freq {
+	case recurYearly:
+		n = w.Year() - start.Year()
+	case recurMonthly:
+		n = (w.Year()-start.Year())*12 + int(w.Month()-start.Month())
+	case recurWeekly:
+		n = int(w.Sub(rule.period(start, 0)) / (7 * 24 * time.Hour))
+	case recurDaily:
+		n = int(w.Sub(rule.period(start, 0)) / (24 * time.Hour))
+	case recurHourly:
+		n = int(w.Sub(start.Truncate(time.Hour)) / time.Hour)
+	case recurMinutely:
+		n = int(w.Sub(start.Truncate(time.Minute)) / time.Minute)
+	default:
+		n = int(w.Sub(start) / time.Second)
+	}
+
+	k := n / rule.interval
+	for k > 0 && rule.period(start, k).After(w) {
+		k--
+	}
+
+	return k
+}
+
+// candidates returns the wall clock times within the period starting at p
+// which match the rule, in ascending order.
+func (rule *recurRule) candidates(p, start time.Time) []time.Time {
+	y, m, d := p.Date()
+
+	var first, end time.Time
+	switch rule.freq {
+	case recurYearly:
+		first, end = p, time.Date(y+1, 1, 1, 0, 0, 0, 0, time.UTC)
+	case recurMonthly:
+		first, end = p, time.Date(y, m+1, 1, 0, 0, 0, 0, time.UTC)
+	case recurWeekly:
+		first, end = p, p.AddDate(0, 0, 7)
+	default:
+		first = time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+		end = first.AddDate(0, 0, 1)
+	}
+
+	hours := rule.timeSet(rule.byHour, p.Hour(), start.Hour(), recurHourly)
+	minutes := rule.timeSet(
+		rule.byMinute, p.Minute(), start.Minute(), recurMinutely,
+	)
+	seconds := rule.timeSet(
+		rule.bySecond, p.Second(), start.Second(), recurSecondly,
+	)
+
+	var times []time.Time
+	for day := first; day.Before(end); day = day.AddDate(0, 0, 1) {
+		if !rule.dayMatches(day) {
+			continue
+		}
+
+		for _, h := range hours {
+			for _, min := range minutes {
+				for _, sec := range seconds {
+					times = append(times, day.Add(
+						time.Duration(h)*time.Hour+
+							time.Duration(min)*time.Minute+
+							time.Duration(sec)*time.Second,
+					))
+				}
+			}
+		}
+	}
+
+	if len(rule.bySetPos) == 0 {
+		return times
+	}
+
+	var selected []time.Time
+	for i, t := range times {
+		if matchesPos(rule.bySetPos, i+1, len(times)) {
+			selected = append(selected, t)
+		}
+	}
+
+	return selected
+}
+
+// timeSet returns the values of a time of day unit which match the rule.
+// Units smaller than the frequency are expanded from by, defaulting to the
+// value of DTSTART, while other units are fixed by the period, and limited by
+// by.
+func (rule *recurRule) timeSet(
+	by []int,
+	periodValue, startValue int,
+	unit recurFreq,
+) []int {
+	switch {
+	case rule.freq > unit && len(by) > 0:
+		return by
+	case rule.freq > unit:
+		return []int{startValue}
+	case len(by) == 0 || containsInt(by, periodValue):
+		return []int{periodValue}
+	default:
+		return nil
+	}
+}
+
+func (rule *recurRule) dayMatches(day time.Time) bool {
+	y, m, d := day.Date()
+
+	if len(rule.byMonth) > 0 && !containsInt(rule.byMonth, int(m)) {
+		return false
+	}
+
+	if len(rule.byWeekNo) > 0 {
+		week, weeks := weekNumber(day, rule.wkst)
+		if !matchesPos(rule.byWeekNo, week, weeks) {
+			return false
+		}
+	}
+
+	if len(rule.byYearDay) > 0 &&
+		!matchesPos(rule.byYearDay, day.YearDay(), daysInYear(y)) {
+		return false
+	}
+
+	if len(rule.byMonthDay) > 0 &&
+		!matchesPos(rule.byMonthDay, d, daysIn(y, m)) {
+		return false
+	}
+
+	if len(rule.byDay) == 0 {
+		return true
+	}
+
+	// Ordinals count weekdays within the month for monthly rules, and yearly
+	// rules restricted to months, and within the year otherwise.
+	pos, total := day.YearDay(), daysInYear(y)
+	if rule.freq == recurMonthly ||
+		(rule.freq == recurYearly && len(rule.byMonth) > 0) {
+		pos, total = d, daysIn(y, m)
+	}
+
+	for _, bd := range rule.byDay {
+		switch {
+		case bd.weekday != day.Weekday():
+			continue
+		case bd.n > 0 && (pos-1)/7+1 == bd.n,
+			bd.n < 0 && (total-pos)/7+1 == -bd.n,
+			bd.n == 0:
+			return true
+		}
+	}
+
+	return false
+}
+
+func parseRecurRule(value string, start time.Time) (*recurRule, error) {
+	rule := &recurRule{freq: -1, interval: 1, wkst: time.Monday}
+
+	var untilValue string
+	for _, part := range strings.Split(value, ";") {
+		key, v, ok := strings.Cut(part, "=")
+		if !ok || v == "" {
+			return nil, recurrenceError("invalid RRULE part %q", part)
+		}
+
+		var err error
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			freq, ok := recurFreqs[strings.ToUpper(v)]
+			if !ok {
+				return nil, recurrenceError("invalid FREQ %q", v)
+			}
+			rule.freq = freq
+		case "INTERVAL":
+			rule.interval, err = strconv.Atoi(v)
+			if err != nil || rule.interval < 1 {
+				return nil, recurrenceError("invalid INTERVAL %q", v)
+			}
+		case "COUNT":
+			rule.count, err = strconv.Atoi(v)
+			if err != nil || rule.count < 1 {
+				return nil, recurrenceError("invalid COUNT %q", v)
+			}
+		case "UNTIL":
+			untilValue = v
+		case "WKST":
+			wd, ok := recurWeekdays[strings.ToUpper(v)]
+			if !ok {
+				return nil, recurrenceError("invalid WKST %q", v)
+			}
+			rule.wkst = wd
+		case "BYMONTH":
+			rule.byMonth, err = parseRecurInts(key, v, 1, 12, false)
+		case "BYWEEKNO":
+			rule.byWeekNo, err = parseRecurInts(key, v, 1, 53, true)
+		case "BYYEARDAY":
+			rule.byYearDay, err = parseRecurInts(key, v, 1, 366, true)
+		case "BYMONTHDAY":
+			rule.byMonthDay, err = parseRecurInts(key, v, 1, 31, true)
+		case "BYDAY":
+			rule.byDay, err = parseRecurDays(v)
+		case "BYHOUR":
+			rule.byHour, err = parseRecurInts(key, v, 0, 23, false)
+		case "BYMINUTE":
+			rule.byMinute, err = parseRecurInts(key, v, 0, 59, false)
+		case "BYSECOND":
+			rule.bySecond, err = parseRecurInts(key, v, 0, 59, false)
+		case "BYSETPOS":
+			rule.bySetPos, err = parseRecurInts(key, v, 1, 366, true)
+		default:
+			return nil, recurrenceError("invalid RRULE part %q", part)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := rule.validate(untilValue); err != nil {
+		return nil, err
+	}
+
+	if !rule.anyTimeOfDay(start) {
+		return nil, recurrenceError(
+			"BYHOUR, BYMINUTE and BYSECOND never match INTERVAL",
+		)
+	}
+
+	if untilValue != "" {
+		until, date, err := parseRecurTime(
+			"UNTIL", untilValue, start.Location(),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if date {
+			// A date includes the whole day.
+			until = recurResolve(
+				cronWall(until).AddDate(0, 0, 1), start.Location(),
+			).Add(-time.Nanosecond)
+		}
+		rule.until = until
+	}
+
+	rule.setDefaults(start)
+
+	return rule, nil
+}
+
+func (rule *recurRule) validate(until string) error {
+	switch {
+	case rule.freq < 0:
+		return recurrenceError("missing FREQ")
+	case rule.count > 0 && until != "":
+		return recurrenceError("COUNT and UNTIL are mutually exclusive")
+	case len(rule.byWeekNo) > 0 && rule.freq != recurYearly:
+		return recurrenceError("BYWEEKNO requires FREQ=YEARLY")
+	case len(rule.byYearDay) > 0 &&
+		rule.freq >= recurDaily && rule.freq <= recurMonthly:
+		return recurrenceError("BYYEARDAY is not valid with this FREQ")
+	case len(rule.byMonthDay) > 0 && rule.freq == recurWeekly:
+		return recurrenceError("BYMONTHDAY is not valid with FREQ=WEEKLY")
+	case len(rule.bySetPos) > 0 && len(rule.byMonth)+len(rule.byWeekNo)+
+		len(rule.byYearDay)+len(rule.byMonthDay)+len(rule.byDay)+
+		len(rule.byHour)+len(rule.byMinute)+len(rule.bySecond) == 0:
+		return recurrenceError("BYSETPOS requires another BYxxx rule part")
+	case !rule.anyMonthDay():
+		return recurrenceError("BYMONTH and BYMONTHDAY never match any date")
+	}
+
+	for _, bd := range rule.byDay {
+		if bd.n != 0 && rule.freq != recurMonthly &&
+			(rule.freq != recurYearly || len(rule.byWeekNo) > 0) {
+			return recurrenceError(
+				"BYDAY ordinals require FREQ=MONTHLY or FREQ=YEARLY",
+			)
+		}
+	}
+
+	return nil
+}
+
+// anyMonthDay reports whether the BYMONTH and BYMONTHDAY rule parts match at
+// least one date.
+func (rule *recurRule) anyMonthDay() bool {
+	for m := 1; m <= 12; m++ {
+		if len(rule.byMonth) > 0 && !containsInt(rule.byMonth, m) {
+			continue
+		}
+
+		// February has 29 days in leap years.
+		days := daysIn(2000, time.Month(m))
+		for d := 1; d <= days; d++ {
+			if len(rule.byMonthDay) == 0 ||
+				matchesPos(rule.byMonthDay, d, days) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// anyTimeOfDay reports whether the periods of a rule with a frequency of
+// less than a day, which start every INTERVAL hours, minutes or seconds from
+// start, include a time of day matching the BYHOUR, BYMINUTE and BYSECOND
+// rule parts.
+func (rule *recurRule) anyTimeOfDay(start time.Time) bool {
+	var unit int
+	switch rule.freq {
+	case recurHourly:
+		unit = 3600
+	case recurMinutely:
+		unit = 60
+	case recurSecondly:
+		unit = 1
+	default:
+		return true
+	}
+
+	// Periods start at seconds of the day which are congruent to the first
+	// one, modulo the greatest common divisor of the period and a day.
+	const day = 24 * 3600
+	a, b := rule.interval%(day/unit), day/unit
+	for a != 0 {
+		a, b = b%a, a
+	}
+	mod := unit * b
+	first := start.Hour()*3600 + start.Minute()*60 + start.Second()
+	first -= first % unit
+
+	for sec := 0; sec < day; sec += unit {
+		h, m, s := sec/3600, sec/60%60, sec%60
+		if (sec-first)%mod == 0 &&
+			(len(rule.byHour) == 0 || containsInt(rule.byHour, h)) &&
+			(unit > 60 || len(rule.byMinute) == 0 ||
+				containsInt(rule.byMinute, m)) &&
+			(unit > 1 || len(rule.bySecond) == 0 ||
+				containsInt(rule.bySecond, s)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// setDefaults derives the days of yearly, monthly and weekly rules without
+// any day rule parts from start, as described by RFC 5545.
+func (rule *recurRule) setDefaults(start time.Time) {
+	if len(rule.byWeekNo)+len(rule.byYearDay)+len(rule.byMonthDay)+
+		len(rule.byDay) > 0 {
+		return
+	}
+
+	switch rule.freq {
+	case recurYearly:
+		if len(rule.byMonth) == 0 {
+			rule.byMonth = []int{int(start.Month())}
+		}
+		rule.byMonthDay = []int{start.Day()}
+	case recurMonthly:
+		rule.byMonthDay = []int{start.Day()}
+	case recurWeekly:
+		rule.byDay = []recurDay{{weekday: start.Weekday()}}
+	}
+}
+
+func parseRecurInts(
+	key, value string,
+	min, max int,
+	negative bool,
+) ([]int, error) {
+	var ints []int
+	for _, s := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(s)
+		abs := n
+		if negative && n < 0 {
+			abs = -n
+		}
+
+		if err != nil || abs < min || abs > max {
+			return nil, recurrenceError(
+				"invalid %s value %q", strings.ToUpper(key), s,
+			)
+		}
+		ints = append(ints, n)
+	}
+	sort.Ints(ints)
+
+	return ints, nil
+}
+
+func parseRecurDays(value string) ([]recurDay, error) {
+	var days []recurDay
+	for _, s := range strings.Split(value, ",") {
+		if len(s) < 2 {
+			return nil, recurrenceError("invalid BYDAY value %q", s)
+		}
+
+		wd, ok := recurWeekdays[strings.ToUpper(s[len(s)-2:])]
+		if !ok {
+			return nil, recurrenceError("invalid BYDAY value %q", s)
+		}
+
+		var n int
+		if ord := s[:len(s)-2]; ord != "" {
+			var err error
+			n, err = strconv.Atoi(ord)
+			if err != nil || n == 0 || n < -53 || n > 53 {
+				return nil, recurrenceError("invalid BYDAY value %q", s)
+			}
+		}
+		days = append(days, recurDay{n: n, weekday: wd})
+	}
+
+	return days, nil
+}
+
+// parseRecurTime parses a DATE or DATE-TIME value of the property name, which
+// may include parameters. Floating values are interpreted in loc, unless name
+// has a TZID parameter. It reports whether the value is a DATE.
+func parseRecurTime(
+	name, value string,
+	loc *time.Location,
+) (time.Time, bool, error) {
+	params := strings.Split(name, ";")
+	prop := strings.ToUpper(params[0])
+
+	date := len(value) == len("20060102")
+	tzid := false
+	for _, param := range params[1:] {
+		k, v, _ := strings.Cut(param, "=")
+		switch strings.ToUpper(k) {
+		case "TZID":
+			l, err := time.LoadLocation(strings.Trim(v, `"`))
+			if err != nil {
+				return time.Time{}, false, recurrenceError(
+					"unknown %s time zone %q", prop, v,
+				)
+			}
+			loc, tzid = l, true
+		case "VALUE":
+			date = strings.EqualFold(v, "DATE")
+		}
+	}
+
+	layout := "20060102T150405"
+	if date {
+		layout = "20060102"
+	}
+
+	utc := !date && strings.HasSuffix(value, "Z")
+	w, err := time.Parse(layout, strings.TrimSuffix(value, "Z"))
+	if err != nil || (utc && tzid) {
+		return time.Time{}, false, recurrenceError(
+			"invalid %s value %q", prop, value,
+		)
+	}
+
+	if utc {
+		return w, false, nil
+	}
+
+	return recurResolve(w, loc), date, nil
+}
+
+// recurResolve returns the instant at which the wall clock time w occurs in
+// loc, as described on Recurrence.
+func recurResolve(w time.Time, loc *time.Location) time.Time {
+	y, m, d := w.Date()
+	h, min, sec := w.Clock()
+	t := time.Date(y, m, d, h, min, sec, 0, loc)
+
+	before := w.Add(-time.Duration(cronOffset(t.Add(-cronShift))) *
+		time.Second).In(loc)
+	if cronWall(before).Equal(w) {
+		return before
+	}
+
+	after := w.Add(-time.Duration(cronOffset(t.Add(cronShift))) *
+		time.Second).In(loc)
+	if cronWall(after).Equal(w) {
+		return after
+	}
+
+	return before
+}
+
+// weekNumber returns the week number of day within its week numbering year,
+// where weeks start on wkst and the first week has at least four days, and
+// the number of weeks in that year.
+func weekNumber(day time.Time, wkst time.Weekday) (int, int) {
+	firstWeek := func(y int) time.Time {
+		jan1 := time.Date(y, 1, 1, 0, 0, 0, 0, time.UTC)
+		offset := int(jan1.Weekday()-wkst+7) % 7
+		if offset >= 4 {
+			offset -= 7
+		}
+
+		return jan1.AddDate(0, 0, -offset)
+	}
+
+	y := day.Year()
+	start, next := firstWeek(y), firstWeek(y+1)
+	switch {
+	case day.Before(start):
+		start, next = firstWeek(y-1), start
+	case !day.Before(next):
+		start, next = next, firstWeek(y+2)
+	}
+
+	week := int(day.Sub(start)/(24*time.Hour))/7 + 1
+	weeks := int(next.Sub(start)/(24*time.Hour)) / 7
+
+	return week, weeks
+}
+
+// matchesPos reports whether the 1-based position pos out of total matches
+// any of the positions in list, where negative positions count from the end.
+func matchesPos(list []int, pos, total int) bool {
+	for _, n := range list {
+		if n == pos || (n < 0 && total+1+n == pos) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// nextInt returns the smallest value in list which is greater than n, or the
+// smallest value plus size if there is none, rolling over into the next day,
+// hour or minute when size is the number of hours, minutes or seconds in it.
+func nextInt(list []int, n, size int) int {
+	next, first := -1, list[0]
+	for _, v := range list {
+		if v > n && (next < 0 || v < next) {
+			next = v
+		}
+		if v < first {
+			first = v
+		}
+	}
+
+	if next < 0 {
+		return first + size
+	}
+
+	return next
+}
+
+func containsInt(list []int, n int) bool {
+	for _, v := range list {
+		if v == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+func daysInYear(y int) int {
+	return time.Date(y, 12, 31, 0, 0, 0, 0, time.UTC).YearDay()
+}
+
+// unfoldRecurLines splits s into content lines, joining lines folded by
+// starting continuation lines with a single space or tab, as described by
+// RFC 5545.
+func unfoldRecurLines(s string) []string {
+	var lines []string
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		folded := strings.HasPrefix(line, " ") ||
+			strings.HasPrefix(line, "\t")
+		if folded && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+func recurrenceError(format string, args ...interface{}) error {
+	return fmt.Errorf(
+		"%w: %s", ErrInvalidRecurrence, fmt.Sprintf(format, args...),
+	)
+}
@@ -0,0 +1,620 @@
+package tyme
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestParseRecurrence_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		wantErr string
+	}{
+		{
+			name:    "empty",
+			s:       "",
+			wantErr: "invalid recurrence: missing DTSTART",
+		},
+		{
+			name:    "missing DTSTART",
+			s:       "RRULE:FREQ=DAILY",
+			wantErr: "invalid recurrence: missing DTSTART",
+		},
+		{
+			name:    "invalid line",
+			s:       "DTSTART:20230101T000000Z\nnope",
+			wantErr: `invalid recurrence: invalid line "nope"`,
+		},
+		{
+			name:    "unknown property",
+			s:       "DTSTART:20230101T000000Z\nFOO:bar",
+			wantErr: `invalid recurrence: unknown property "FOO"`,
+		},
+		{
+			name:    "invalid DTSTART",
+			s:       "DTSTART:2023-01-01",
+			wantErr: `invalid recurrence: invalid DTSTART value "2023-01-01"`,
+		},
+		{
+			name: "unknown time zone",
+			s:    "DTSTART;TZID=Nowhere/Town:20230101T000000",
+			wantErr: "invalid recurrence: " +
+				`unknown DTSTART time zone "Nowhere/Town"`,
+		},
+		{
+			name: "UTC time with time zone",
+			s:    "DTSTART;TZID=Europe/Paris:20230101T000000Z",
+			wantErr: "invalid recurrence: " +
+				`invalid DTSTART value "20230101T000000Z"`,
+		},
+		{
+			name:    "multiple DTSTART",
+			s:       "DTSTART:20230101T000000Z\nDTSTART:20230102T000000Z",
+			wantErr: "invalid recurrence: multiple DTSTART lines",
+		},
+		{
+			name: "multiple RRULE",
+			s: "DTSTART:20230101T000000Z\nRRULE:FREQ=DAILY\n" +
+				"RRULE:FREQ=WEEKLY",
+			wantErr: "invalid recurrence: multiple RRULE lines",
+		},
+		{
+			name:    "missing FREQ",
+			s:       "DTSTART:20230101T000000Z\nRRULE:COUNT=1",
+			wantErr: "invalid recurrence: missing FREQ",
+		},
+		{
+			name:    "invalid FREQ",
+			s:       "DTSTART:20230101T000000Z\nRRULE:FREQ=SOMETIMES",
+			wantErr: `invalid recurrence: invalid FREQ "SOMETIMES"`,
+		},
+		{
+			name:    "invalid part",
+			s:       "DTSTART:20230101T000000Z\nRRULE:FREQ=DAILY;FOO=1",
+			wantErr: `invalid recurrence: invalid RRULE part "FOO=1"`,
+		},
+		{
+			name: "COUNT and UNTIL",
+			s: "DTSTART:20230101T000000Z\n" +
+				"RRULE:FREQ=DAILY;COUNT=2;UNTIL=20230201T000000Z",
+			wantErr: "invalid recurrence: " +
+				"COUNT and UNTIL are mutually exclusive",
+		},
+		{
+			name:    "invalid BYMONTH",
+			s:       "DTSTART:20230101T000000Z\nRRULE:FREQ=YEARLY;BYMONTH=13",
+			wantErr: `invalid recurrence: invalid BYMONTH value "13"`,
+		},
+		{
+			name: "invalid BYDAY",
+			s:    "DTSTART:20230101T000000Z\nRRULE:FREQ=MONTHLY;BYDAY=0MO",
+			wantErr: "invalid recurrence: " +
+				`invalid BYDAY value "0MO"`,
+		},
+		{
+			name: "BYDAY ordinal with weekly",
+			s:    "DTSTART:20230101T000000Z\nRRULE:FREQ=WEEKLY;BYDAY=1MO",
+			wantErr: "invalid recurrence: " +
+				"BYDAY ordinals require FREQ=MONTHLY or FREQ=YEARLY",
+		},
+		{
+			name: "BYWEEKNO with monthly",
+			s: "DTSTART:20230101T000000Z\n" +
+				"RRULE:FREQ=MONTHLY;BYWEEKNO=1",
+			wantErr: "invalid recurrence: BYWEEKNO requires FREQ=YEARLY",
+		},
+		{
+			name: "BYSETPOS alone",
+			s:    "DTSTART:20230101T000000Z\nRRULE:FREQ=MONTHLY;BYSETPOS=1",
+			wantErr: "invalid recurrence: " +
+				"BYSETPOS requires another BYxxx rule part",
+		},
+		{
+			name: "BYMONTHDAY not in BYMONTH",
+			s: "DTSTART:20230101T000000Z\n" +
+				"RRULE:FREQ=MINUTELY;BYMONTH=2;BYMONTHDAY=30",
+			wantErr: "invalid recurrence: " +
+				"BYMONTH and BYMONTHDAY never match any date",
+		},
+		{
+			name: "negative BYMONTHDAY not in BYMONTH",
+			s: "DTSTART:20230101T000000Z\n" +
+				"RRULE:FREQ=SECONDLY;BYMONTH=4,6;BYMONTHDAY=-31",
+			wantErr: "invalid recurrence: " +
+				"BYMONTH and BYMONTHDAY never match any date",
+		},
+		{
+			name: "BYSECOND not in INTERVAL",
+			s: "DTSTART:20230101T000000Z\n" +
+				"RRULE:FREQ=SECONDLY;INTERVAL=2;BYSECOND=59",
+			wantErr: "invalid recurrence: " +
+				"BYHOUR, BYMINUTE and BYSECOND never match INTERVAL",
+		},
+		{
+			name: "BYHOUR not in INTERVAL",
+			s: "DTSTART:20230101T010000Z\n" +
+				"RRULE:FREQ=MINUTELY;INTERVAL=120;BYHOUR=2,4",
+			wantErr: "invalid recurrence: " +
+				"BYHOUR, BYMINUTE and BYSECOND never match INTERVAL",
+		},
+		{
+			name: "RDATE period",
+			s: "DTSTART:20230101T000000Z\n" +
+				"RDATE;VALUE=PERIOD:20230102T000000Z/PT1H",
+			wantErr: "invalid recurrence: unsupported RDATE period",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseRecurrence(tt.s)
+
+			assert.EqualError(t, err, tt.wantErr)
+			assert.ErrorIs(t, err, ErrInvalidRecurrence)
+		})
+	}
+}
+
+func TestRecurrence_All(t *testing.T) {
+	nyc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	utcDate := func(y int, m time.Month, d, h, min int) time.Time {
+		return time.Date(y, m, d, h, min, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name  string
+		s     string
+		limit int
+		want  []time.Time
+	}{
+		{
+			name: "second Tuesday with time zone",
+			s: "DTSTART;TZID=America/New_York:20230110T090000\n" +
+				"RRULE:FREQ=MONTHLY;BYDAY=2TU;COUNT=4",
+			want: []time.Time{
+				time.Date(2023, 1, 10, 9, 0, 0, 0, nyc),
+				time.Date(2023, 2, 14, 9, 0, 0, 0, nyc),
+				time.Date(2023, 3, 14, 9, 0, 0, 0, nyc),
+				time.Date(2023, 4, 11, 9, 0, 0, 0, nyc),
+			},
+		},
+		{
+			name: "EXDATE after COUNT",
+			s: "DTSTART;TZID=America/New_York:20230110T090000\n" +
+				"RRULE:FREQ=MONTHLY;BYDAY=2TU;COUNT=4\n" +
+				"EXDATE;TZID=America/New_York:20230214T090000",
+			want: []time.Time{
+				time.Date(2023, 1, 10, 9, 0, 0, 0, nyc),
+				time.Date(2023, 3, 14, 9, 0, 0, 0, nyc),
+				time.Date(2023, 4, 11, 9, 0, 0, 0, nyc),
+			},
+		},
+		{
+			name: "last weekday of month",
+			s: "DTSTART:20230131T170000Z\n" +
+				"RRULE:FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1;COUNT=3",
+			want: []time.Time{
+				utcDate(2023, 1, 31, 17, 0),
+				utcDate(2023, 2, 28, 17, 0),
+				utcDate(2023, 3, 31, 17, 0),
+			},
+		},
+		{
+			name: "last Friday of month",
+			s: "DTSTART:20231027T120000Z\n" +
+				"RRULE:FREQ=MONTHLY;BYDAY=-1FR;COUNT=3",
+			want: []time.Time{
+				utcDate(2023, 10, 27, 12, 0),
+				utcDate(2023, 11, 24, 12, 0),
+				utcDate(2023, 12, 29, 12, 0),
+			},
+		},
+		{
+			name: "yearly on leap day",
+			s:    "DTSTART:20200229T000000Z\nRRULE:FREQ=YEARLY;COUNT=3",
+			want: []time.Time{
+				utcDate(2020, 2, 29, 0, 0),
+				utcDate(2024, 2, 29, 0, 0),
+				utcDate(2028, 2, 29, 0, 0),
+			},
+		},
+		{
+			name: "biweekly with UNTIL",
+			s: "DTSTART:20231002T080000Z\n" +
+				"RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE;" +
+				"UNTIL=20231020T000000Z",
+			want: []time.Time{
+				utcDate(2023, 10, 2, 8, 0),
+				utcDate(2023, 10, 4, 8, 0),
+				utcDate(2023, 10, 16, 8, 0),
+				utcDate(2023, 10, 18, 8, 0),
+			},
+		},
+		{
+			name: "daily dates with UNTIL date",
+			s: "DTSTART;VALUE=DATE:20231030\n" +
+				"RRULE:FREQ=DAILY;UNTIL=20231102",
+			want: []time.Time{
+				utcDate(2023, 10, 30, 0, 0),
+				utcDate(2023, 10, 31, 0, 0),
+				utcDate(2023, 11, 1, 0, 0),
+				utcDate(2023, 11, 2, 0, 0),
+			},
+		},
+		{
+			name: "week number",
+			s: "DTSTART:19970512T090000Z\n" +
+				"RRULE:FREQ=YEARLY;BYWEEKNO=20;BYDAY=MO;COUNT=3",
+			want: []time.Time{
+				utcDate(1997, 5, 12, 9, 0),
+				utcDate(1998, 5, 11, 9, 0),
+				utcDate(1999, 5, 17, 9, 0),
+			},
+		},
+		{
+			name: "year days",
+			s: "DTSTART:19970101T090000Z\n" +
+				"RRULE:FREQ=YEARLY;INTERVAL=3;COUNT=4;BYYEARDAY=1,100,200",
+			want: []time.Time{
+				utcDate(1997, 1, 1, 9, 0),
+				utcDate(1997, 4, 10, 9, 0),
+				utcDate(1997, 7, 19, 9, 0),
+				utcDate(2000, 1, 1, 9, 0),
+			},
+		},
+		{
+			name: "first and last day of month",
+			s: "DTSTART:19970930T090000Z\n" +
+				"RRULE:FREQ=MONTHLY;BYMONTHDAY=1,-1;COUNT=4",
+			want: []time.Time{
+				utcDate(1997, 9, 30, 9, 0),
+				utcDate(1997, 10, 1, 9, 0),
+				utcDate(1997, 10, 31, 9, 0),
+				utcDate(1997, 11, 1, 9, 0),
+			},
+		},
+		{
+			name: "Friday the 13th",
+			s: "DTSTART:19970902T090000Z\n" +
+				"RRULE:FREQ=MONTHLY;BYDAY=FR;BYMONTHDAY=13\n" +
+				"EXDATE:19970902T090000Z",
+			limit: 3,
+			want: []time.Time{
+				utcDate(1998, 2, 13, 9, 0),
+				utcDate(1998, 3, 13, 9, 0),
+				utcDate(1998, 11, 13, 9, 0),
+			},
+		},
+		{
+			name: "every third hour",
+			s: "DTSTART:19970902T090000Z\n" +
+				"RRULE:FREQ=HOURLY;INTERVAL=3;UNTIL=19970902T170000Z",
+			want: []time.Time{
+				utcDate(1997, 9, 2, 9, 0),
+				utcDate(1997, 9, 2, 12, 0),
+				utcDate(1997, 9, 2, 15, 0),
+			},
+		},
+		{
+			name: "every 20 minutes within hours",
+			s: "DTSTART:19970902T090000Z\n" +
+				"RRULE:FREQ=MINUTELY;INTERVAL=20;BYHOUR=9,10;COUNT=7",
+			want: []time.Time{
+				utcDate(1997, 9, 2, 9, 0),
+				utcDate(1997, 9, 2, 9, 20),
+				utcDate(1997, 9, 2, 9, 40),
+				utcDate(1997, 9, 2, 10, 0),
+				utcDate(1997, 9, 2, 10, 20),
+				utcDate(1997, 9, 2, 10, 40),
+				utcDate(1997, 9, 3, 9, 0),
+			},
+		},
+		{
+			name: "DTSTART not matching rule",
+			s: "DTSTART:20231003T090000Z\n" +
+				"RRULE:FREQ=WEEKLY;BYDAY=MO;COUNT=2",
+			want: []time.Time{
+				utcDate(2023, 10, 3, 9, 0),
+				utcDate(2023, 10, 9, 9, 0),
+			},
+		},
+		{
+			name: "RDATE",
+			s: "DTSTART:20231001T100000Z\n" +
+				"RRULE:FREQ=WEEKLY;COUNT=2\n" +
+				"RDATE:20231003T100000Z,20231001T100000Z",
+			want: []time.Time{
+				utcDate(2023, 10, 1, 10, 0),
+				utcDate(2023, 10, 3, 10, 0),
+				utcDate(2023, 10, 8, 10, 0),
+			},
+		},
+		{
+			name: "without RRULE",
+			s:    "DTSTART:20231001T100000Z\r\nRDATE:20230901T100000Z\r\n",
+			want: []time.Time{
+				utcDate(2023, 9, 1, 10, 0),
+				utcDate(2023, 10, 1, 10, 0),
+			},
+		},
+		{
+			name: "EXDATE date",
+			s: "DTSTART:20231001T100000Z\n" +
+				"RRULE:FREQ=DAILY;COUNT=3\n" +
+				"EXDATE;VALUE=DATE:20231002",
+			want: []time.Time{
+				utcDate(2023, 10, 1, 10, 0),
+				utcDate(2023, 10, 3, 10, 0),
+			},
+		},
+		{
+			name: "skipped wall clock time",
+			s: "DTSTART;TZID=America/New_York:20230311T023000\n" +
+				"RRULE:FREQ=DAILY;COUNT=3",
+			want: []time.Time{
+				utcDate(2023, 3, 11, 7, 30),
+				utcDate(2023, 3, 12, 7, 30),
+				utcDate(2023, 3, 13, 6, 30),
+			},
+		},
+		{
+			name: "repeated wall clock time",
+			s: "DTSTART;TZID=America/New_York:20231104T013000\n" +
+				"RRULE:FREQ=DAILY;COUNT=3",
+			want: []time.Time{
+				utcDate(2023, 11, 4, 5, 30),
+				utcDate(2023, 11, 5, 5, 30),
+				utcDate(2023, 11, 6, 6, 30),
+			},
+		},
+		{
+			name: "never matches again",
+			s: "DTSTART:20230101T000000Z\n" +
+				"RRULE:FREQ=YEARLY;BYMONTH=1;BYYEARDAY=366",
+			want: []time.Time{utcDate(2023, 1, 1, 0, 0)},
+		},
+		{
+			name: "secondly on leap days",
+			s: "DTSTART:20230101T000000Z\n" +
+				"RRULE:FREQ=SECONDLY;BYMONTH=2;BYMONTHDAY=29",
+			limit: 3,
+			want: []time.Time{
+				utcDate(2023, 1, 1, 0, 0),
+				utcDate(2024, 2, 29, 0, 0),
+				utcDate(2024, 2, 29, 0, 0).Add(time.Second),
+			},
+		},
+		{
+			name: "minutely on one hour and minute of Mondays",
+			s: "DTSTART:20230101T000000Z\n" +
+				"RRULE:FREQ=MINUTELY;INTERVAL=7;BYDAY=MO;BYHOUR=23;BYMINUTE=1",
+			limit: 3,
+			want: []time.Time{
+				utcDate(2023, 1, 1, 0, 0),
+				utcDate(2023, 1, 2, 23, 1),
+				utcDate(2023, 1, 9, 23, 1),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := ParseRecurrence(tt.s)
+			require.NoError(t, err)
+
+			var got []time.Time
+			for occ := range r.All() {
+				assert.Equal(t, r.Location(), occ.Location())
+				got = append(got, occ)
+				if len(got) == tt.limit {
+					break
+				}
+			}
+
+			require.Len(t, got, len(tt.want), "got: %v", got)
+			for i, want := range tt.want {
+				assert.True(t, want.Equal(got[i]), "got: %s", got[i])
+			}
+		})
+	}
+}
+
+func TestRecurrence_BetweenNext(t *testing.T) {
+	r := MustParseRecurrence(
+		"DTSTART;TZID=Europe/Paris:20231001T090000\n" +
+			"RRULE:FREQ=WEEKLY;BYDAY=MO,FR",
+	)
+	paris := r.Location()
+
+	assert.Equal(t, "Europe/Paris", paris.String())
+	assert.Equal(t, time.Date(2023, 10, 1, 9, 0, 0, 0, paris), r.Start())
+
+	got := r.Between(
+		time.Date(2023, 10, 27, 9, 0, 0, 0, paris),
+		time.Date(2023, 11, 3, 9, 0, 0, 0, paris),
+	)
+	assert.Equal(t, []time.Time{
+		time.Date(2023, 10, 27, 9, 0, 0, 0, paris),
+		time.Date(2023, 10, 30, 9, 0, 0, 0, paris),
+	}, got)
+
+	assert.Equal(t,
+		time.Date(2023, 10, 6, 9, 0, 0, 0, paris),
+		r.Next(time.Date(2023, 10, 2, 9, 0, 0, 0, paris)),
+	)
+	assert.True(t, MustParseRecurrence("DTSTART:20231001T090000Z").
+		Next(utc.AddDate(1, 0, 0)).IsZero())
+
+	assert.True(t, Recurrence{}.IsZero())
+	assert.Empty(t, Recurrence{}.Between(utc, utc.AddDate(1, 0, 0)))
+}
+
+func TestRecurrence_BetweenNext_SkipsAhead(t *testing.T) {
+	tests := []struct {
+		name  string
+		s     string
+		start time.Time
+		span  time.Duration
+	}{
+		{
+			name:  "secondly",
+			s:     "DTSTART:20300601T000000Z\nRRULE:FREQ=SECONDLY;INTERVAL=7",
+			start: time.Date(2030, 6, 15, 12, 0, 3, 0, time.UTC),
+		},
+		{
+			name: "minutely",
+			s: "DTSTART;TZID=America/New_York:20200101T000000\n" +
+				"RRULE:FREQ=MINUTELY;INTERVAL=13",
+			start: time.Date(2023, 3, 12, 6, 50, 0, 0, time.UTC),
+		},
+		{
+			name: "hourly across DST",
+			s: "DTSTART;TZID=America/New_York:20000101T023000\n" +
+				"RRULE:FREQ=HOURLY;INTERVAL=24",
+			start: time.Date(2023, 3, 12, 7, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "daily skipped wall clock time",
+			s: "DTSTART;TZID=America/New_York:20000101T023000\n" +
+				"RRULE:FREQ=DAILY",
+			start: time.Date(2023, 3, 12, 7, 15, 0, 0, time.UTC),
+		},
+		{
+			name: "weekly",
+			s: "DTSTART;TZID=Europe/Paris:20000103T090000\n" +
+				"RRULE:FREQ=WEEKLY;INTERVAL=3;BYDAY=MO,FR;WKST=SU",
+			start: time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "monthly",
+			s: "DTSTART:19990131T090000Z\n" +
+				"RRULE:FREQ=MONTHLY;INTERVAL=5;BYMONTHDAY=-1",
+			start: time.Date(2024, 2, 29, 9, 0, 0, 0, time.UTC),
+			span:  5 * 365 * 24 * time.Hour,
+		},
+		{
+			name: "yearly",
+			s: "DTSTART:19040229T000000Z\n" +
+				"RRULE:FREQ=YEARLY;INTERVAL=2",
+			start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+			span:  12 * 365 * 24 * time.Hour,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := MustParseRecurrence(tt.s)
+			span := tt.span
+			if span == 0 {
+				span = 3 * 24 * time.Hour
+			}
+			end := tt.start.Add(span)
+
+			// Expanding from DTSTART with a COUNT is never skipped ahead.
+			counted := MustParseRecurrence(tt.s + ";COUNT=100000000")
+
+			var want []time.Time
+			for occ := range counted.All() {
+				if !occ.Before(end) {
+					break
+				}
+				if !occ.Before(tt.start) {
+					want = append(want, occ)
+				}
+			}
+			require.NotEmpty(t, want)
+
+			assert.Equal(t, want, r.Between(tt.start, end))
+			assert.Equal(t, want[0], r.Next(tt.start.Add(-time.Nanosecond)))
+		})
+	}
+}
+
+func TestParseRecurrenceInLocation(t *testing.T) {
+	nyc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	r, err := ParseRecurrenceInLocation(
+		"DTSTART:20230110T090000\nRRULE:FREQ=DAILY;COUNT=2", nyc,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2023, 1, 10, 9, 0, 0, 0, nyc), r.Start())
+
+	r, err = ParseRecurrenceInLocation("DTSTART:20230110T090000Z", nyc)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2023, 1, 10, 9, 0, 0, 0, time.UTC), r.Start())
+
+	r, err = ParseRecurrenceInLocation("DTSTART:20230110T090000", nil)
+	require.NoError(t, err)
+	assert.Equal(t, time.UTC, r.Location())
+}
+
+func TestParseRecurrence_Folded(t *testing.T) {
+	r, err := ParseRecurrence(
+		"DTSTART;TZID=America/\r\n New_York:20230110T090000\r\n" +
+			"RRULE:FREQ=MONTHLY;\r\n\tBYDAY=2TU;COUNT=2\r\n",
+	)
+	require.NoError(t, err)
+	assert.Equal(t,
+		"DTSTART;TZID=America/New_York:20230110T090000\n"+
+			"RRULE:FREQ=MONTHLY;BYDAY=2TU;COUNT=2",
+		r.String(),
+	)
+	assert.Equal(t, "America/New_York", r.Location().String())
+	assert.Len(t, r.Between(r.Start(), r.Start().AddDate(1, 0, 0)), 2)
+}
+
+func TestMustParseRecurrence(t *testing.T) {
+	assert.PanicsWithError(t,
+		"invalid recurrence: missing DTSTART",
+		func() { MustParseRecurrence("RRULE:FREQ=DAILY") },
+	)
+}
+
+func TestRecurrence_MarshalUnmarshal(t *testing.T) {
+	type event struct {
+		Repeat Recurrence `json:"repeat" yaml:"repeat"`
+	}
+
+	text := "DTSTART;TZID=America/New_York:20230110T090000\n" +
+		"RRULE:FREQ=MONTHLY;BYDAY=2TU"
+
+	var e event
+	b, err := json.Marshal(map[string]string{"repeat": text})
+	require.NoError(t, err)
+
+	err = json.Unmarshal(b, &e)
+	require.NoError(t, err)
+	assert.Equal(t, text, e.Repeat.String())
+
+	got, err := json.Marshal(e)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(b), string(got))
+
+	y := "repeat: |-\n" +
+		"    DTSTART;TZID=America/New_York:20230110T090000\n" +
+		"    RRULE:FREQ=MONTHLY;BYDAY=2TU\n"
+
+	e = event{}
+	err = yaml.Unmarshal([]byte(y), &e)
+	require.NoError(t, err)
+	assert.Equal(t, text, e.Repeat.String())
+
+	got, err = yaml.Marshal(e)
+	require.NoError(t, err)
+	assert.Equal(t, y, string(got))
+
+	err = json.Unmarshal([]byte(`{"repeat":"RRULE:FREQ=DAILY"}`), &e)
+	assert.ErrorIs(t, err, ErrInvalidRecurrence)
+
+	err = yaml.Unmarshal([]byte("repeat: RRULE:FREQ=DAILY\n"), &e)
+	assert.ErrorContains(t, err, "missing DTSTART")
+
+	err = yaml.Unmarshal([]byte("repeat: [1, 2]\n"), &e)
+	assert.ErrorContains(t, err, "invalid recurrence")
+}
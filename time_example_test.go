@@ -34,3 +34,20 @@ func ExampleTime_MarshalYAML() {
 	// Output:
 	// date: 2006-01-02T15:04:05.999Z
 }
+
+func ExampleTimeMilli_MarshalJSON() {
+	type Event struct {
+		Start tyme.TimeMilli `json:"start"`
+		End   tyme.TimeMilli `json:"end"`
+	}
+	start := time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)
+	event := Event{
+		Start: tyme.TimeMilli(start),
+		End:   tyme.TimeMilli(start.Add(1500 * time.Millisecond)),
+	}
+	b, _ := json.Marshal(event)
+
+	fmt.Println(string(b))
+	// Output:
+	// {"start":"2006-01-02T15:04:05.000Z","end":"2006-01-02T15:04:06.500Z"}
+}
@@ -0,0 +1,201 @@
+package ts
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// The range of years which Auto considers plausible when inferring the unit
+// of a numeric timestamp.
+const (
+	autoMinYear = 1971
+	autoMaxYear = 2200
+)
+
+// autoUnits lists the units Auto attempts to infer, in order of preference.
+var autoUnits = []time.Duration{
+	time.Second,
+	time.Millisecond,
+	time.Microsecond,
+	time.Nanosecond,
+}
+
+// Auto is a wrapper around time.Time for unmarshaling Unix timestamps of
+// unknown precision from JSON/YAML.
+//
+// It unmarshals from a JSON/YAML number, or numeric string, representing the
+// number of seconds, milliseconds, microseconds or nanoseconds since the Unix
+// time epoch. The unit is inferred by picking the first of these which results
+// in a year between 1971 and 2200. Use AutoWithin for a different range of
+// years. It also unmarshals from a string in RFC 3339 format.
+//
+// It marshals to a JSON/YAML number in the unit given by Unit, which is the
+// unit it was unmarshaled from, allowing values to round-trip. Set Unit to
+// marshal in a different unit. When Unit is zero, it marshals to a string in
+// RFC 3339 format.
+type Auto struct {
+	// Time is the time instant.
+	Time time.Time
+
+	// Unit is the unit of the timestamp, one of time.Second,
+	// time.Millisecond, time.Microsecond, or time.Nanosecond. It is zero when
+	// unmarshaled from a RFC 3339 string.
+	Unit time.Duration
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (a Auto) MarshalJSON() ([]byte, error) {
+	if a.Unit == 0 {
+		return []byte(a.Time.Format(`"` + time.RFC3339Nano + `"`)), nil
+	}
+
+	return []byte(floorRat(timeToRat(a.Time, a.Unit)).String()), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (a *Auto) UnmarshalJSON(data []byte) error {
+	return a.unmarshalJSON(data, autoMinYear, autoMaxYear)
+}
+
+func (a *Auto) unmarshalJSON(data []byte, minYear, maxYear int) error {
+	s, err := strconv.Unquote(string(data))
+	if err == nil {
+		if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+			*a = Auto{Time: t}
+
+			return nil
+		}
+	}
+
+	r, err := unmarshalDecimalBytes(data)
+	if err != nil {
+		return err
+	}
+
+	na, err := autoFromRat(r, minYear, maxYear)
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, string(data))
+	}
+
+	*a = na
+
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (a Auto) MarshalYAML() (interface{}, error) {
+	if a.Unit == 0 {
+		return a.Time, nil
+	}
+
+	return &yaml.Node{
+		Kind:  yaml.ScalarNode,
+		Tag:   "!!int",
+		Value: floorRat(timeToRat(a.Time, a.Unit)).String(),
+	}, nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (a *Auto) UnmarshalYAML(node *yaml.Node) error {
+	return a.unmarshalYAML(node, autoMinYear, autoMaxYear)
+}
+
+func (a *Auto) unmarshalYAML(node *yaml.Node, minYear, maxYear int) error {
+	switch node.Tag {
+	case "!!timestamp":
+		var t time.Time
+		if err := node.Decode(&t); err != nil {
+			return err
+		}
+		*a = Auto{Time: t}
+
+		return nil
+	case "!!str":
+		if t, err := time.Parse(time.RFC3339Nano, node.Value); err == nil {
+			*a = Auto{Time: t}
+
+			return nil
+		}
+	}
+
+	r, err := unmarshalDecimalYAMLNode(node)
+	if err != nil {
+		return err
+	}
+
+	na, err := autoFromRat(r, minYear, maxYear)
+	if err != nil {
+		return &yaml.TypeError{Errors: []string{err.Error()}}
+	}
+
+	*a = na
+
+	return nil
+}
+
+// AutoYears is implemented by types which declare the range of years an
+// AutoWithin considers plausible.
+type AutoYears interface {
+	AutoYears() (minYear, maxYear int)
+}
+
+// AutoWithin is like Auto, but infers the unit of numeric timestamps by
+// picking the first unit which results in a year within the range declared by
+// Y:
+//
+//	type since1950 struct{}
+//
+//	func (since1950) AutoYears() (int, int) { return 1950, 2100 }
+//
+//	type Timestamp = ts.AutoWithin[since1950]
+type AutoWithin[Y AutoYears] Auto
+
+// MarshalJSON implements the json.Marshaler interface.
+func (a AutoWithin[Y]) MarshalJSON() ([]byte, error) {
+	return Auto(a).MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (a *AutoWithin[Y]) UnmarshalJSON(data []byte) error {
+	var y Y
+	minYear, maxYear := y.AutoYears()
+
+	return (*Auto)(a).unmarshalJSON(data, minYear, maxYear)
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (a AutoWithin[Y]) MarshalYAML() (interface{}, error) {
+	return Auto(a).MarshalYAML()
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (a *AutoWithin[Y]) UnmarshalYAML(node *yaml.Node) error {
+	var y Y
+	minYear, maxYear := y.AutoYears()
+
+	return (*Auto)(a).unmarshalYAML(node, minYear, maxYear)
+}
+
+// autoFromRat infers the unit of r by trying each of autoUnits in turn,
+// returning the first which results in a year between minYear and maxYear.
+func autoFromRat(r *big.Rat, minYear, maxYear int) (Auto, error) {
+	for _, unit := range autoUnits {
+		t, err := ratToTime(r, unit)
+		if err != nil {
+			continue
+		}
+
+		if y := t.Year(); y >= minYear && y <= maxYear {
+			return Auto{Time: t, Unit: unit}, nil
+		}
+	}
+
+	return Auto{}, fmt.Errorf(
+		"cannot infer unit of numeric timestamp between years %d and %d",
+		minYear, maxYear,
+	)
+}
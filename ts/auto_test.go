@@ -0,0 +1,189 @@
+package ts
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+var autoTestCases = []struct {
+	name     string
+	s        string
+	want     time.Time
+	wantUnit time.Duration
+	wantErr  string
+}{
+	{
+		name:     "seconds",
+		s:        "1697040000",
+		want:     time.Unix(1697040000, 0),
+		wantUnit: time.Second,
+	},
+	{
+		name:     "fractional seconds",
+		s:        "1697040000.123",
+		want:     time.Unix(1697040000, 123000000),
+		wantUnit: time.Second,
+	},
+	{
+		name:     "milliseconds",
+		s:        "1697040000123",
+		want:     time.Unix(1697040000, 123000000),
+		wantUnit: time.Millisecond,
+	},
+	{
+		name:     "microseconds",
+		s:        "1697040000123456",
+		want:     time.Unix(1697040000, 123456000),
+		wantUnit: time.Microsecond,
+	},
+	{
+		name:     "nanoseconds",
+		s:        "1697040000123456789",
+		want:     time.Unix(1697040000, 123456789),
+		wantUnit: time.Nanosecond,
+	},
+	{
+		name:     "numeric string",
+		s:        `"1697040000123"`,
+		want:     time.Unix(1697040000, 123000000),
+		wantUnit: time.Millisecond,
+	},
+	{
+		name: "RFC 3339 string",
+		s:    `"2023-10-11T16:00:00.123Z"`,
+		want: time.Date(2023, 10, 11, 16, 0, 0, 123000000, time.UTC),
+	},
+	{
+		name:    "zero",
+		s:       "0",
+		wantErr: "cannot infer unit of numeric timestamp",
+	},
+	{
+		name:    "too large",
+		s:       "16970400001234567890123",
+		wantErr: "cannot infer unit of numeric timestamp",
+	},
+	{
+		name:    "invalid string",
+		s:       `"next tuesday"`,
+		wantErr: "invalid numeric timestamp",
+	},
+}
+
+func TestAuto_UnmarshalJSON(t *testing.T) {
+	for _, tt := range autoTestCases {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Auto
+			err := json.Unmarshal([]byte(tt.s), &got)
+
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(got.Time))
+			assert.Equal(t, tt.wantUnit, got.Unit)
+		})
+	}
+}
+
+func TestAuto_UnmarshalYAML(t *testing.T) {
+	for _, tt := range autoTestCases {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Auto
+			err := yaml.Unmarshal([]byte(tt.s), &got)
+
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(got.Time))
+			assert.Equal(t, tt.wantUnit, got.Unit)
+		})
+	}
+}
+
+func TestAuto_RoundTrip(t *testing.T) {
+	tests := []struct {
+		s        string
+		wantYAML string
+	}{
+		{s: "1697040000", wantYAML: "1697040000\n"},
+		{s: "1697040000123", wantYAML: "1697040000123\n"},
+		{s: "1697040000123456", wantYAML: "1697040000123456\n"},
+		{s: "1697040000123456789", wantYAML: "1697040000123456789\n"},
+		{
+			s:        `"2023-10-11T16:00:00.123Z"`,
+			wantYAML: "2023-10-11T16:00:00.123Z\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			var a Auto
+			require.NoError(t, json.Unmarshal([]byte(tt.s), &a))
+
+			b, err := json.Marshal(a)
+			require.NoError(t, err)
+			assert.Equal(t, tt.s, string(b))
+
+			var y Auto
+			require.NoError(t, yaml.Unmarshal([]byte(tt.s), &y))
+
+			b, err = yaml.Marshal(y)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantYAML, string(b))
+		})
+	}
+}
+
+func TestAuto_MarshalUnit(t *testing.T) {
+	a := Auto{Time: time.Unix(1697040000, 123456789), Unit: time.Millisecond}
+
+	b, err := json.Marshal(a)
+	require.NoError(t, err)
+	assert.Equal(t, "1697040000123", string(b))
+
+	b, err = yaml.Marshal(a)
+	require.NoError(t, err)
+	assert.Equal(t, "1697040000123\n", string(b))
+}
+
+type since1950 struct{}
+
+func (since1950) AutoYears() (int, int) { return 1950, 2100 }
+
+func TestAutoWithin(t *testing.T) {
+	var a AutoWithin[since1950]
+	err := json.Unmarshal([]byte("-631152000"), &a)
+	require.NoError(t, err)
+	assert.Equal(t, time.Second, a.Unit)
+	assert.Equal(t, 1950, a.Time.UTC().Year())
+
+	b, err := json.Marshal(a)
+	require.NoError(t, err)
+	assert.Equal(t, "-631152000", string(b))
+
+	err = json.Unmarshal([]byte("99999999999999999999"), &a)
+	assert.ErrorContains(t, err, "between years 1950 and 2100")
+
+	a = AutoWithin[since1950]{}
+	err = yaml.Unmarshal([]byte("-631152000000"), &a)
+	require.NoError(t, err)
+	assert.Equal(t, time.Millisecond, a.Unit)
+
+	b, err = yaml.Marshal(a)
+	require.NoError(t, err)
+	assert.Equal(t, "-631152000000\n", string(b))
+
+	var def Auto
+	err = json.Unmarshal([]byte("-631152000"), &def)
+	assert.ErrorContains(t, err, "between years 1971 and 2200")
+}
@@ -0,0 +1,96 @@
+package ts
+
+import (
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+var cocoaEpoch = Epoch{
+	Origin: time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC),
+	Unit:   time.Second,
+	Digits: 6,
+}
+
+// CocoaSecond is a wrapper around time.Time for marshaling to/from JSON/YAML
+// as Apple Cocoa/Core Foundation absolute time values, as used by NSDate and
+// CFAbsoluteTime.
+//
+// It marshals to a JSON/YAML number representing the number of seconds since
+// January 1, 2001 UTC, with six fractional digits. For a different number of
+// digits, use an EpochTime with an Epoch starting at the same instant.
+//
+// It unmarshals from a JSON/YAML number, or numeric string, representing the
+// number of seconds since January 1, 2001 UTC. Decimal values are parsed
+// exactly, retaining precision down to the nanosecond.
+type CocoaSecond time.Time
+
+// Time returns the time.Time corresponding to the Cocoa instant s.
+func (s CocoaSecond) Time() time.Time {
+	return time.Time(s)
+}
+
+// Local returns the local time corresponding to the Cocoa instant s.
+func (s CocoaSecond) Local() CocoaSecond {
+	return CocoaSecond(time.Time(s).Local())
+}
+
+// GoString implements the fmt.GoStringer interface.
+func (s CocoaSecond) GoString() string {
+	return time.Time(s).GoString()
+}
+
+// IsDST reports whether the Cocoa instant s occurs within Daylight Saving
+// Time.
+func (s CocoaSecond) IsDST() bool {
+	return time.Time(s).IsDST()
+}
+
+// IsZero returns true if the CocoaSecond is the zero value.
+func (s CocoaSecond) IsZero() bool {
+	return time.Time(s).IsZero()
+}
+
+// String calls time.Time.String.
+func (s CocoaSecond) String() string {
+	return time.Time(s).String()
+}
+
+// UTC returns a copy of the CocoaSecond with the location set to UTC.
+func (s CocoaSecond) UTC() CocoaSecond {
+	return CocoaSecond(time.Time(s).UTC())
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (s CocoaSecond) MarshalJSON() ([]byte, error) {
+	return cocoaEpoch.marshalJSON(time.Time(s))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (s *CocoaSecond) UnmarshalJSON(data []byte) error {
+	t, err := cocoaEpoch.unmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+
+	*s = CocoaSecond(t)
+
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (s CocoaSecond) MarshalYAML() (interface{}, error) {
+	return cocoaEpoch.marshalYAML(time.Time(s))
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (s *CocoaSecond) UnmarshalYAML(node *yaml.Node) error {
+	t, err := cocoaEpoch.unmarshalYAML(node)
+	if err != nil {
+		return err
+	}
+
+	*s = CocoaSecond(t)
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package ts
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+var bigNanosPerSecond = big.NewInt(int64(time.Second))
+
+// parseDecimal parses s as a decimal number, returning its exact value without
+// any of the rounding which would occur when using float64.
+func parseDecimal(s string) (*big.Rat, error) {
+	s = strings.TrimSpace(s)
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, err
+	}
+	if math.IsNaN(f) {
+		return nil, strconv.ErrSyntax
+	}
+	if math.IsInf(f, 0) {
+		return nil, strconv.ErrRange
+	}
+
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, strconv.ErrSyntax
+	}
+
+	return r, nil
+}
+
+// parseFloor parses s as a decimal number, returning the largest integer less
+// than or equal to it.
+func parseFloor(s string) (int64, error) {
+	r, err := parseDecimal(s)
+	if err != nil {
+		return 0, err
+	}
+
+	i := floorRat(r)
+	if !i.IsInt64() {
+		return 0, strconv.ErrRange
+	}
+
+	return i.Int64(), nil
+}
+
+// floorRat returns the largest integer less than or equal to r.
+func floorRat(r *big.Rat) *big.Int {
+	// Denominators are always positive, so Euclidean division as performed by
+	// big.Int.Div is equivalent to floored division.
+	return new(big.Int).Div(r.Num(), r.Denom())
+}
+
+// ratToTime converts r, a number of the given unit since the Unix epoch, to
+// a time.Time. Sub-nanosecond values are rounded down.
+func ratToTime(r *big.Rat, unit time.Duration) (time.Time, error) {
+	ns := new(big.Rat).Mul(r, new(big.Rat).SetInt64(int64(unit)))
+
+	sec, nsec := new(big.Int).DivMod(
+		floorRat(ns), bigNanosPerSecond, new(big.Int),
+	)
+	if !sec.IsInt64() {
+		return time.Time{}, fmt.Errorf("%w: %s", ErrOverflow, r.FloatString(9))
+	}
+
+	return time.Unix(sec.Int64(), nsec.Int64()), nil
+}
+
+// timeToRat returns the exact number of the given unit since the Unix epoch
+// of t.
+func timeToRat(t time.Time, unit time.Duration) *big.Rat {
+	ns := new(big.Int).Mul(big.NewInt(t.Unix()), bigNanosPerSecond)
+	ns.Add(ns, big.NewInt(int64(t.Nanosecond())))
+
+	return new(big.Rat).SetFrac(ns, big.NewInt(int64(unit)))
+}
+
+// formatDecimal formats t as a decimal number of the given unit since the Unix
+// epoch, with exactly digits number of fractional digits. The last digit is
+// rounded half away from zero.
+func formatDecimal(t time.Time, unit time.Duration, digits int) string {
+	if digits < 0 {
+		digits = 0
+	}
+
+	return timeToRat(t, unit).FloatString(digits)
+}
+
+// marshalDecimalYAML returns a YAML node for the decimal number s, ensuring it
+// is output with all of its fractional digits intact.
+func marshalDecimalYAML(s string) *yaml.Node {
+	tag := "!!float"
+	if !strings.Contains(s, ".") {
+		tag = "!!int"
+	}
+
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: s}
+}
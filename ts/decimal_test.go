@@ -0,0 +1,157 @@
+package ts
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFloor(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    int64
+		wantErr bool
+	}{
+		{s: "0", want: 0},
+		{s: "1.5", want: 1},
+		{s: "-1.5", want: -2},
+		{s: "-1", want: -1},
+		{s: "-0.000000001", want: -1},
+		{s: "1697040000.123", want: 1697040000},
+		{s: "-1697040000.123", want: -1697040001},
+		{s: "1.697040000123e9", want: 1697040000},
+		{s: "  42.9  ", want: 42},
+		{s: "9223372036854775807.5", want: 9223372036854775807},
+		{s: "9223372036854775808", wantErr: true},
+		{s: "1e300", wantErr: true},
+		{s: "NaN", wantErr: true},
+		{s: "Inf", wantErr: true},
+		{s: "1/2", wantErr: true},
+		{s: "abc", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := parseFloor(tt.s)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRatToTime(t *testing.T) {
+	tests := []struct {
+		s    string
+		unit time.Duration
+		want time.Time
+	}{
+		{
+			s:    "1697040000.123456789",
+			unit: time.Second,
+			want: time.Unix(1697040000, 123456789),
+		},
+		{
+			s:    "1697040000.1234567899",
+			unit: time.Second,
+			want: time.Unix(1697040000, 123456789),
+		},
+		{
+			s:    "-1.25",
+			unit: time.Second,
+			want: time.Unix(-2, 750000000),
+		},
+		{
+			s:    "1697040000123.456789",
+			unit: time.Millisecond,
+			want: time.Unix(1697040000, 123456789),
+		},
+		{
+			s:    "-1500.5",
+			unit: time.Millisecond,
+			want: time.Unix(-2, 499500000),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			r, err := parseDecimal(tt.s)
+			require.NoError(t, err)
+
+			got, err := ratToTime(r, tt.unit)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFormatDecimal(t *testing.T) {
+	tests := []struct {
+		name   string
+		t      time.Time
+		unit   time.Duration
+		digits int
+		want   string
+	}{
+		{
+			name:   "seconds with 6 digits",
+			t:      time.Unix(1697040000, 123456789),
+			unit:   time.Second,
+			digits: 6,
+			want:   "1697040000.123457",
+		},
+		{
+			name:   "seconds with 9 digits",
+			t:      time.Unix(1697040000, 123456789),
+			unit:   time.Second,
+			digits: 9,
+			want:   "1697040000.123456789",
+		},
+		{
+			name:   "seconds with trailing zeros",
+			t:      time.Unix(1697040000, 100000000),
+			unit:   time.Second,
+			digits: 3,
+			want:   "1697040000.100",
+		},
+		{
+			name:   "seconds with no digits",
+			t:      time.Unix(1697040000, 500000000),
+			unit:   time.Second,
+			digits: 0,
+			want:   "1697040001",
+		},
+		{
+			name:   "negative seconds",
+			t:      time.Unix(-2, 750000000),
+			unit:   time.Second,
+			digits: 3,
+			want:   "-1.250",
+		},
+		{
+			name:   "milliseconds",
+			t:      time.Unix(1697040000, 123456789),
+			unit:   time.Millisecond,
+			digits: 3,
+			want:   "1697040000123.457",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := formatDecimal(tt.t, tt.unit, tt.digits)
+
+			assert.Equal(t, tt.want, got)
+
+			r, ok := new(big.Rat).SetString(got)
+			require.True(t, ok)
+			assert.Equal(t, r.FloatString(tt.digits), got)
+		})
+	}
+}
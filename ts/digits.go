@@ -0,0 +1,61 @@
+package ts
+
+// Digits is a type constraint matching the Digits0 to Digits9 types, which
+// declare the number of fractional digits FloatSecondOf and FloatMillisecondOf
+// values are marshaled with.
+type Digits interface {
+	Digits0 | Digits1 | Digits2 | Digits3 | Digits4 |
+		Digits5 | Digits6 | Digits7 | Digits8 | Digits9
+
+	digits() int
+}
+
+// Digits0 declares zero fractional digits.
+type Digits0 struct{}
+
+func (Digits0) digits() int { return 0 }
+
+// Digits1 declares one fractional digit.
+type Digits1 struct{}
+
+func (Digits1) digits() int { return 1 }
+
+// Digits2 declares two fractional digits.
+type Digits2 struct{}
+
+func (Digits2) digits() int { return 2 }
+
+// Digits3 declares three fractional digits.
+type Digits3 struct{}
+
+func (Digits3) digits() int { return 3 }
+
+// Digits4 declares four fractional digits.
+type Digits4 struct{}
+
+func (Digits4) digits() int { return 4 }
+
+// Digits5 declares five fractional digits.
+type Digits5 struct{}
+
+func (Digits5) digits() int { return 5 }
+
+// Digits6 declares six fractional digits.
+type Digits6 struct{}
+
+func (Digits6) digits() int { return 6 }
+
+// Digits7 declares seven fractional digits.
+type Digits7 struct{}
+
+func (Digits7) digits() int { return 7 }
+
+// Digits8 declares eight fractional digits.
+type Digits8 struct{}
+
+func (Digits8) digits() int { return 8 }
+
+// Digits9 declares nine fractional digits.
+type Digits9 struct{}
+
+func (Digits9) digits() int { return 9 }
@@ -0,0 +1,174 @@
+package ts
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Epoch describes a numeric timestamp format as a number of units elapsed since
+// an origin instant.
+type Epoch struct {
+	// Origin is the instant represented by the numeric value zero.
+	Origin time.Time
+
+	// Unit is the duration of a single numeric step, e.g. time.Second or
+	// 100 * time.Nanosecond.
+	Unit time.Duration
+
+	// Digits is the number of fractional digits values are marshaled with.
+	// When zero, values are marshaled as integers, rounded down.
+	Digits int
+}
+
+// EpochSpec is implemented by types which declare the Epoch used by an
+// EpochTime.
+type EpochSpec interface {
+	Epoch() Epoch
+}
+
+// EpochTime is a wrapper around time.Time for marshaling to/from JSON/YAML as
+// numeric timestamps relative to the Epoch declared by S. It allows declaring
+// custom timestamp types without implementing any marshaling logic:
+//
+//	type unixDays struct{}
+//
+//	func (unixDays) Epoch() ts.Epoch {
+//		return ts.Epoch{Origin: time.Unix(0, 0), Unit: 24 * time.Hour}
+//	}
+//
+//	type UnixDay = ts.EpochTime[unixDays]
+//
+// It marshals to a JSON/YAML number representing the number of units since the
+// epoch's origin.
+//
+// It unmarshals from a JSON/YAML number, or numeric string, representing the
+// number of units since the epoch's origin. Decimal values are parsed exactly,
+// retaining precision down to the nanosecond.
+//
+// Go type constraints cannot match types declared outside of this package, so
+// EpochTime values are not matched by the Timestamp constraint. Use the Time
+// method to pass them to functions like Add and Sub.
+type EpochTime[S EpochSpec] time.Time
+
+// Time returns the time.Time corresponding to the instant t.
+func (t EpochTime[S]) Time() time.Time {
+	return time.Time(t)
+}
+
+// UTC returns a copy of t with the location set to UTC.
+func (t EpochTime[S]) UTC() EpochTime[S] {
+	return EpochTime[S](time.Time(t).UTC())
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (t EpochTime[S]) MarshalJSON() ([]byte, error) {
+	var s S
+
+	return s.Epoch().marshalJSON(time.Time(t))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (t *EpochTime[S]) UnmarshalJSON(data []byte) error {
+	var s S
+	nt, err := s.Epoch().unmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+
+	*t = EpochTime[S](nt)
+
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (t EpochTime[S]) MarshalYAML() (interface{}, error) {
+	var s S
+
+	return s.Epoch().marshalYAML(time.Time(t))
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (t *EpochTime[S]) UnmarshalYAML(node *yaml.Node) error {
+	var s S
+	nt, err := s.Epoch().unmarshalYAML(node)
+	if err != nil {
+		return err
+	}
+
+	*t = EpochTime[S](nt)
+
+	return nil
+}
+
+// Time returns the instant represented by v units since the epoch's origin.
+// It returns an error wrapping ErrOverflow if the result cannot be
+// represented.
+func (e Epoch) Time(v int64) (time.Time, error) {
+	return e.timeFromRat(new(big.Rat).SetInt64(v))
+}
+
+// Value returns the number of whole units elapsed between the epoch's origin
+// and t, rounded down. It returns an error wrapping ErrOverflow if the result
+// does not fit in an int64.
+func (e Epoch) Value(t time.Time) (int64, error) {
+	i := floorRat(e.rat(t))
+	if !i.IsInt64() {
+		return 0, fmt.Errorf("%w: %s", ErrOverflow, i)
+	}
+
+	return i.Int64(), nil
+}
+
+// rat returns the exact number of units elapsed between the epoch's origin
+// and t.
+func (e Epoch) rat(t time.Time) *big.Rat {
+	return new(big.Rat).Sub(timeToRat(t, e.Unit), timeToRat(e.Origin, e.Unit))
+}
+
+func (e Epoch) timeFromRat(r *big.Rat) (time.Time, error) {
+	if e.Unit <= 0 {
+		return time.Time{}, fmt.Errorf("invalid epoch unit: %s", e.Unit)
+	}
+
+	return ratToTime(
+		new(big.Rat).Add(r, timeToRat(e.Origin, e.Unit)), e.Unit,
+	)
+}
+
+func (e Epoch) format(t time.Time) string {
+	r := e.rat(t)
+	if e.Digits <= 0 {
+		return floorRat(r).String()
+	}
+
+	return r.FloatString(e.Digits)
+}
+
+func (e Epoch) marshalJSON(t time.Time) ([]byte, error) {
+	return []byte(e.format(t)), nil
+}
+
+func (e Epoch) marshalYAML(t time.Time) (interface{}, error) {
+	return marshalDecimalYAML(e.format(t)), nil
+}
+
+func (e Epoch) unmarshalJSON(data []byte) (time.Time, error) {
+	r, err := unmarshalDecimalBytes(data)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return e.timeFromRat(r)
+}
+
+func (e Epoch) unmarshalYAML(node *yaml.Node) (time.Time, error) {
+	r, err := unmarshalDecimalYAMLNode(node)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return e.timeFromRat(r)
+}
@@ -0,0 +1,236 @@
+package ts
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jimeh/go-tyme/dur"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+var epochTestTime = time.Date(2023, 10, 11, 16, 0, 0, 123456789, time.UTC)
+
+type unixDays struct{}
+
+func (unixDays) Epoch() Epoch {
+	return Epoch{Origin: time.Unix(0, 0), Unit: 24 * time.Hour, Digits: 2}
+}
+
+type unixDay = EpochTime[unixDays]
+
+func TestEpochTypes_Marshal(t *testing.T) {
+	tests := []struct {
+		v      interface{}
+		target func() interface{}
+		want   string
+		wantT  time.Time
+	}{
+		{
+			v:      FileTime(epochTestTime),
+			target: func() interface{} { return new(FileTime) },
+			want:   "133415136001234567",
+			wantT:  epochTestTime.Truncate(100 * time.Nanosecond),
+		},
+		{
+			v:      Ticks(epochTestTime),
+			target: func() interface{} { return new(Ticks) },
+			want:   "638326368001234567",
+			wantT:  epochTestTime.Truncate(100 * time.Nanosecond),
+		},
+		{
+			v:      CocoaSecond(epochTestTime),
+			target: func() interface{} { return new(CocoaSecond) },
+			want:   "718732800.123457",
+			wantT:  epochTestTime.Round(time.Microsecond),
+		},
+		{
+			v:      GPSSecond(epochTestTime),
+			target: func() interface{} { return new(GPSSecond) },
+			want:   "1381075218",
+			wantT:  epochTestTime.Truncate(time.Second),
+		},
+		{
+			v:      unixDay(epochTestTime),
+			target: func() interface{} { return new(unixDay) },
+			want:   "19641.67",
+			wantT:  time.Date(2023, 10, 11, 16, 4, 48, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%T", tt.v), func(t *testing.T) {
+			b, err := json.Marshal(tt.v)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(b))
+
+			v := tt.target()
+			err = json.Unmarshal(b, v)
+			require.NoError(t, err)
+			assert.True(t, tt.wantT.Equal(epochTypeTime(v)))
+
+			b, err = yaml.Marshal(tt.v)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want+"\n", string(b))
+
+			v = tt.target()
+			err = yaml.Unmarshal(b, v)
+			require.NoError(t, err)
+			assert.True(t, tt.wantT.Equal(epochTypeTime(v)))
+		})
+	}
+}
+
+func TestEpochTypes_Unmarshal(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		target  func() interface{}
+		want    time.Time
+		wantErr string
+	}{
+		{
+			name:   "FileTime epoch",
+			s:      "0",
+			target: func() interface{} { return new(FileTime) },
+			want:   time.Date(1601, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "FileTime Unix epoch",
+			s:      `"116444736000000000"`,
+			target: func() interface{} { return new(FileTime) },
+			want:   time.Unix(0, 0),
+		},
+		{
+			name:   "Ticks epoch",
+			s:      "0",
+			target: func() interface{} { return new(Ticks) },
+			want:   time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "Ticks max value",
+			s:      "3155378975999999999",
+			target: func() interface{} { return new(Ticks) },
+			want: time.Date(
+				9999, 12, 31, 23, 59, 59, 999999900, time.UTC,
+			),
+		},
+		{
+			name:   "CocoaSecond negative fraction",
+			s:      "-0.25",
+			target: func() interface{} { return new(CocoaSecond) },
+			want:   time.Date(2000, 12, 31, 23, 59, 59, 750000000, time.UTC),
+		},
+		{
+			name:   "GPSSecond epoch",
+			s:      "0",
+			target: func() interface{} { return new(GPSSecond) },
+			want:   time.Date(1980, 1, 6, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "GPSSecond before 2017 leap second",
+			s:      "946339215",
+			target: func() interface{} { return new(GPSSecond) },
+			want:   time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "GPSSecond fraction",
+			s:      "1381075218.5",
+			target: func() interface{} { return new(GPSSecond) },
+			want:   time.Date(2023, 10, 11, 16, 0, 0, 500000000, time.UTC),
+		},
+		{
+			name:   "EpochTime fraction",
+			s:      "1.5",
+			target: func() interface{} { return new(unixDay) },
+			want:   time.Date(1970, 1, 2, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "invalid",
+			s:       `"yesterday"`,
+			target:  func() interface{} { return new(FileTime) },
+			wantErr: "invalid numeric timestamp",
+		},
+		{
+			name:    "overflow",
+			s:       "1e40",
+			target:  func() interface{} { return new(Ticks) },
+			wantErr: ErrOverflow.Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+" JSON", func(t *testing.T) {
+			v := tt.target()
+			err := json.Unmarshal([]byte(tt.s), v)
+
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(epochTypeTime(v)))
+		})
+		t.Run(tt.name+" YAML", func(t *testing.T) {
+			v := tt.target()
+			err := yaml.Unmarshal([]byte(tt.s), v)
+
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(epochTypeTime(v)))
+		})
+	}
+}
+
+func TestEpoch_TimeValue(t *testing.T) {
+	e := Epoch{
+		Origin: time.Date(1601, 1, 1, 0, 0, 0, 0, time.UTC),
+		Unit:   100 * time.Nanosecond,
+	}
+
+	got, err := e.Time(133415136001234567)
+	require.NoError(t, err)
+	assert.True(t, epochTestTime.Truncate(100).Equal(got))
+
+	v, err := e.Value(epochTestTime)
+	require.NoError(t, err)
+	assert.Equal(t, int64(133415136001234567), v)
+
+	_, err = e.Value(time.Date(300000, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.ErrorIs(t, err, ErrOverflow)
+
+	_, err = Epoch{}.Time(1)
+	assert.ErrorContains(t, err, "invalid epoch unit")
+}
+
+func TestEpochTypes_Add(t *testing.T) {
+	ft := FileTime(epochTestTime)
+
+	got := Add(ft, time.Hour)
+
+	assert.IsType(t, FileTime{}, got)
+	assert.Equal(t, dur.Duration(time.Hour), Sub(got, GPSSecond(epochTestTime)))
+}
+
+func epochTypeTime(v interface{}) time.Time {
+	switch x := v.(type) {
+	case *FileTime:
+		return x.Time()
+	case *Ticks:
+		return x.Time()
+	case *CocoaSecond:
+		return x.Time()
+	case *GPSSecond:
+		return x.Time()
+	case *unixDay:
+		return x.Time()
+	}
+
+	return time.Time{}
+}
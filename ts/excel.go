@@ -0,0 +1,359 @@
+package ts
+
+import (
+	"math/big"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ExcelSystem is a spreadsheet date system, determining which date serial
+// number zero refers to.
+type ExcelSystem int
+
+const (
+	// Excel1900 is the default date system used by Excel, Lotus 1-2-3 and OLE
+	// Automation dates. Serial number 1 is January 1, 1900, and serial number
+	// 60 is the non-existent February 29, 1900, inherited from a Lotus 1-2-3
+	// bug.
+	Excel1900 ExcelSystem = iota
+
+	// Excel1904 is the date system used by older Excel for Mac workbooks.
+	// Serial number 0 is January 1, 1904.
+	Excel1904
+)
+
+// ExcelFormat describes how spreadsheet serial date numbers are marshaled and
+// unmarshaled.
+type ExcelFormat struct {
+	// System is the date system serial numbers are relative to.
+	System ExcelSystem
+
+	// Location is the location serial numbers are interpreted in, as they
+	// describe wall clock time without any time zone information. When nil,
+	// UTC is used.
+	Location *time.Location
+
+	// Precision is the precision values are rounded to when marshaled and
+	// unmarshaled, hiding the inherent imprecision of fractional days. When
+	// zero, values are not rounded.
+	Precision time.Duration
+}
+
+// ExcelSpec is implemented by types which declare the ExcelFormat used by an
+// ExcelSerialOf.
+type ExcelSpec interface {
+	ExcelFormat() ExcelFormat
+}
+
+// excelDefault is the ExcelFormat used by ExcelSerial.
+var excelDefault = ExcelFormat{
+	System:    Excel1900,
+	Location:  time.UTC,
+	Precision: time.Millisecond,
+}
+
+const (
+	secondsPerDay = 24 * 60 * 60
+	nanosPerDay   = secondsPerDay * int64(time.Second)
+)
+
+var (
+	excel1900Base = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+	excel1900Leap = time.Date(1900, 3, 1, 0, 0, 0, 0, time.UTC)
+	excel1904Base = time.Date(1904, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// ExcelSerial is a wrapper around time.Time for marshaling to/from JSON/YAML
+// as spreadsheet serial date numbers, as used by Excel, Lotus 1-2-3 and OLE
+// Automation.
+//
+// It marshals to a JSON/YAML number representing the number of days since the
+// start of the Excel1900 date system, with the time of day as a fraction, in
+// UTC. Values are rounded to the nearest millisecond, hiding the inherent
+// imprecision of fractional days. Use ExcelSerialOf for other date systems,
+// locations and precisions.
+//
+// It unmarshals from a JSON/YAML number, or numeric string, representing a
+// serial date number in the Excel1900 date system. The phantom February 29,
+// 1900 (serial number 60) is treated as March 1, 1900.
+type ExcelSerial time.Time
+
+// Time returns the time.Time corresponding to the serial date instant s.
+func (s ExcelSerial) Time() time.Time {
+	return time.Time(s)
+}
+
+// Local returns the local time corresponding to the serial date instant s.
+func (s ExcelSerial) Local() ExcelSerial {
+	return ExcelSerial(time.Time(s).Local())
+}
+
+// GoString implements the fmt.GoStringer interface.
+func (s ExcelSerial) GoString() string {
+	return time.Time(s).GoString()
+}
+
+// IsDST reports whether the serial date instant s occurs within Daylight
+// Saving Time.
+func (s ExcelSerial) IsDST() bool {
+	return time.Time(s).IsDST()
+}
+
+// IsZero returns true if the ExcelSerial is the zero value.
+func (s ExcelSerial) IsZero() bool {
+	return time.Time(s).IsZero()
+}
+
+// String calls time.Time.String.
+func (s ExcelSerial) String() string {
+	return time.Time(s).String()
+}
+
+// UTC returns a copy of the ExcelSerial with the location set to UTC.
+func (s ExcelSerial) UTC() ExcelSerial {
+	return ExcelSerial(time.Time(s).UTC())
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (s ExcelSerial) MarshalJSON() ([]byte, error) {
+	return excelDefault.marshalJSON(time.Time(s)), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (s *ExcelSerial) UnmarshalJSON(data []byte) error {
+	t, err := excelDefault.unmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+
+	*s = ExcelSerial(t)
+
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (s ExcelSerial) MarshalYAML() (interface{}, error) {
+	return excelDefault.marshalYAML(time.Time(s)), nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (s *ExcelSerial) UnmarshalYAML(node *yaml.Node) error {
+	t, err := excelDefault.unmarshalYAML(node)
+	if err != nil {
+		return err
+	}
+
+	*s = ExcelSerial(t)
+
+	return nil
+}
+
+// ExcelSerialOf is a wrapper around time.Time for marshaling to/from JSON/YAML
+// as spreadsheet serial date numbers in the ExcelFormat declared by S:
+//
+//	type macWorkbook struct{}
+//
+//	func (macWorkbook) ExcelFormat() ts.ExcelFormat {
+//		return ts.ExcelFormat{System: ts.Excel1904, Precision: time.Second}
+//	}
+//
+//	type MacSerial = ts.ExcelSerialOf[macWorkbook]
+//
+// It otherwise behaves like ExcelSerial.
+type ExcelSerialOf[S ExcelSpec] time.Time
+
+// Time returns the time.Time corresponding to the serial date instant s.
+func (s ExcelSerialOf[S]) Time() time.Time {
+	return time.Time(s)
+}
+
+// UTC returns a copy of s with the location set to UTC.
+func (s ExcelSerialOf[S]) UTC() ExcelSerialOf[S] {
+	return ExcelSerialOf[S](time.Time(s).UTC())
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (s ExcelSerialOf[S]) MarshalJSON() ([]byte, error) {
+	var spec S
+
+	return spec.ExcelFormat().marshalJSON(time.Time(s)), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (s *ExcelSerialOf[S]) UnmarshalJSON(data []byte) error {
+	var spec S
+	t, err := spec.ExcelFormat().unmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+
+	*s = ExcelSerialOf[S](t)
+
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (s ExcelSerialOf[S]) MarshalYAML() (interface{}, error) {
+	var spec S
+
+	return spec.ExcelFormat().marshalYAML(time.Time(s)), nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (s *ExcelSerialOf[S]) UnmarshalYAML(node *yaml.Node) error {
+	var spec S
+	t, err := spec.ExcelFormat().unmarshalYAML(node)
+	if err != nil {
+		return err
+	}
+
+	*s = ExcelSerialOf[S](t)
+
+	return nil
+}
+
+func (f ExcelFormat) format(t time.Time) string {
+	t = t.Round(f.Precision)
+	n, _ := excelSerial(t, f.System, f.Location).Float64()
+
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
+
+func (f ExcelFormat) marshalJSON(t time.Time) []byte {
+	return []byte(f.format(t))
+}
+
+func (f ExcelFormat) marshalYAML(t time.Time) interface{} {
+	return marshalDecimalYAML(f.format(t))
+}
+
+func (f ExcelFormat) unmarshalJSON(data []byte) (time.Time, error) {
+	r, err := unmarshalDecimalBytes(data)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return f.timeFromRat(r)
+}
+
+func (f ExcelFormat) unmarshalYAML(node *yaml.Node) (time.Time, error) {
+	r, err := unmarshalDecimalYAMLNode(node)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return f.timeFromRat(r)
+}
+
+func (f ExcelFormat) timeFromRat(r *big.Rat) (time.Time, error) {
+	t, err := excelTime(r, f.System, f.Location)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return t.Round(f.Precision), nil
+}
+
+// ExcelSerialToTime returns the time in loc represented by the spreadsheet
+// serial date number serial in the given date system. When loc is nil, UTC is
+// used.
+func ExcelSerialToTime(
+	serial float64,
+	system ExcelSystem,
+	loc *time.Location,
+) (time.Time, error) {
+	r := new(big.Rat)
+	if r.SetFloat64(serial) == nil {
+		return time.Time{}, strconv.ErrRange
+	}
+
+	return excelTime(r, system, loc)
+}
+
+// TimeToExcelSerial returns the spreadsheet serial date number in the given
+// date system for the wall clock time of t in loc. When loc is nil, UTC is
+// used.
+func TimeToExcelSerial(
+	t time.Time,
+	system ExcelSystem,
+	loc *time.Location,
+) float64 {
+	f, _ := excelSerial(t, system, loc).Float64()
+
+	return f
+}
+
+func excelTime(
+	r *big.Rat,
+	system ExcelSystem,
+	loc *time.Location,
+) (time.Time, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	base := excel1904Base
+	if system == Excel1900 {
+		base = excel1900Base
+
+		// Serials before March 1, 1900 are offset by one day, due to the
+		// phantom February 29, 1900.
+		if r.Cmp(big.NewRat(61, 1)) < 0 {
+			r = new(big.Rat).Add(r, big.NewRat(1, 1))
+		}
+	}
+
+	ns := new(big.Rat).Mul(r, new(big.Rat).SetInt64(nanosPerDay))
+	days, nsec := new(big.Int).DivMod(
+		floorRat(ns), big.NewInt(nanosPerDay), new(big.Int),
+	)
+	sec := new(big.Int).Mul(days, big.NewInt(secondsPerDay))
+	if !sec.Add(sec, big.NewInt(base.Unix())).IsInt64() {
+		return time.Time{}, ErrOverflow
+	}
+
+	// Build the wall clock time in UTC from int64 values, and only then move
+	// it to loc, as int may be too small for days or nanoseconds of the day.
+	u := time.Unix(sec.Int64(), nsec.Int64()).UTC()
+	y, m, d := u.Date()
+	hour, minute, second := u.Clock()
+
+	return time.Date(
+		y, m, d, hour, minute, second, u.Nanosecond(), loc,
+	), nil
+}
+
+func excelSerial(
+	t time.Time,
+	system ExcelSystem,
+	loc *time.Location,
+) *big.Rat {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	t = t.In(loc)
+	y, m, d := t.Date()
+	date := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+
+	var days int64
+	if system == Excel1900 {
+		days = (date.Unix() - excel1900Base.Unix()) / secondsPerDay
+		if date.Before(excel1900Leap) {
+			days--
+		}
+	} else {
+		days = (date.Unix() - excel1904Base.Unix()) / secondsPerDay
+	}
+
+	clock := int64(t.Hour())*int64(time.Hour) +
+		int64(t.Minute())*int64(time.Minute) +
+		int64(t.Second())*int64(time.Second) +
+		int64(t.Nanosecond())
+
+	r := new(big.Rat).SetInt64(days)
+
+	return r.Add(r, big.NewRat(clock, nanosPerDay))
+}
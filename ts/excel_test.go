@@ -0,0 +1,251 @@
+package ts
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+type excelValue interface {
+	Time() time.Time
+}
+
+func excelOf[S ExcelSpec](t time.Time) interface{} {
+	return ExcelSerialOf[S](t)
+}
+
+func excelTarget[S ExcelSpec]() excelValue {
+	return new(ExcelSerialOf[S])
+}
+
+type excel1904 struct{}
+
+func (excel1904) ExcelFormat() ExcelFormat {
+	return ExcelFormat{
+		System:    Excel1904,
+		Location:  time.UTC,
+		Precision: time.Millisecond,
+	}
+}
+
+var utc6 = time.FixedZone("UTC+6", 6*60*60)
+
+type excelUTC6 struct{}
+
+func (excelUTC6) ExcelFormat() ExcelFormat {
+	return ExcelFormat{Location: utc6, Precision: time.Millisecond}
+}
+
+type excelNilLocation struct{}
+
+func (excelNilLocation) ExcelFormat() ExcelFormat {
+	return ExcelFormat{}
+}
+
+func TestExcelSerial_MarshalUnmarshal(t *testing.T) {
+	tests := []struct {
+		name   string
+		t      time.Time
+		value  func(time.Time) interface{}
+		target func() excelValue
+		loc    *time.Location
+		want   string
+		wantT  time.Time
+	}{
+		{
+			name: "date and time",
+			t:    time.Date(2023, 10, 19, 9, 0, 0, 0, time.UTC),
+			want: "45218.375",
+		},
+		{
+			name: "date only",
+			t:    time.Date(2023, 10, 19, 0, 0, 0, 0, time.UTC),
+			want: "45218",
+		},
+		{
+			name: "first day",
+			t:    time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC),
+			want: "1",
+		},
+		{
+			name: "before phantom leap day",
+			t:    time.Date(1900, 2, 28, 12, 0, 0, 0, time.UTC),
+			want: "59.5",
+		},
+		{
+			name: "after phantom leap day",
+			t:    time.Date(1900, 3, 1, 0, 0, 0, 0, time.UTC),
+			want: "61",
+		},
+		{
+			name:  "rounded to precision",
+			t:     time.Date(2023, 10, 19, 9, 0, 0, 999600000, time.UTC),
+			want:  "45218.37501157408",
+			wantT: time.Date(2023, 10, 19, 9, 0, 1, 0, time.UTC),
+		},
+		{
+			name:   "1904 system epoch",
+			t:      time.Date(1904, 1, 1, 0, 0, 0, 0, time.UTC),
+			value:  excelOf[excel1904],
+			target: excelTarget[excel1904],
+			want:   "0",
+		},
+		{
+			name:   "1904 system",
+			t:      time.Date(2023, 10, 19, 18, 0, 0, 0, time.UTC),
+			value:  excelOf[excel1904],
+			target: excelTarget[excel1904],
+			want:   "43756.75",
+		},
+		{
+			name:   "custom location",
+			t:      time.Date(2023, 10, 19, 9, 0, 0, 0, time.UTC),
+			value:  excelOf[excelUTC6],
+			target: excelTarget[excelUTC6],
+			loc:    utc6,
+			want:   "45218.625",
+		},
+		{
+			name: "far future",
+			t:    time.Date(2500, 1, 1, 0, 0, 0, 0, time.UTC),
+			want: "219148",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, target := tt.value, tt.target
+			if value == nil {
+				value = func(t time.Time) interface{} { return ExcelSerial(t) }
+				target = func() excelValue { return new(ExcelSerial) }
+			}
+			loc := tt.loc
+			if loc == nil {
+				loc = time.UTC
+			}
+			wantT := tt.wantT
+			if wantT.IsZero() {
+				wantT = tt.t
+			}
+
+			b, err := json.Marshal(value(tt.t))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(b))
+
+			js := target()
+			err = json.Unmarshal(b, js)
+			require.NoError(t, err)
+			assert.True(t, wantT.Equal(js.Time()), js.Time().String())
+			assert.Equal(t, loc, js.Time().Location())
+
+			b, err = yaml.Marshal(value(tt.t))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want+"\n", string(b))
+
+			ys := target()
+			err = yaml.Unmarshal(b, ys)
+			require.NoError(t, err)
+			assert.True(t, wantT.Equal(ys.Time()), ys.Time().String())
+		})
+	}
+}
+
+func TestExcelSerial_Unmarshal(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    time.Time
+		wantErr string
+	}{
+		{
+			name: "phantom leap day",
+			s:    "60",
+			want: time.Date(1900, 3, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "phantom leap day with time",
+			s:    "60.5",
+			want: time.Date(1900, 3, 1, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "numeric string",
+			s:    `"45218.375"`,
+			want: time.Date(2023, 10, 19, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "imprecise fraction",
+			s:    "45218.3750000001",
+			want: time.Date(2023, 10, 19, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "zero",
+			s:    "0",
+			want: time.Date(1899, 12, 31, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "invalid",
+			s:       `"foo"`,
+			wantErr: "invalid numeric timestamp: foo",
+		},
+		{
+			name:    "overflow",
+			s:       "1e30",
+			wantErr: ErrOverflow.Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var s ExcelSerial
+			err := json.Unmarshal([]byte(tt.s), &s)
+
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(s.Time()), s.Time().String())
+		})
+	}
+}
+
+func TestExcelSerialToTime(t *testing.T) {
+	loc := time.FixedZone("UTC-5", -5*60*60)
+
+	got, err := ExcelSerialToTime(45218.375, Excel1900, loc)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2023, 10, 19, 9, 0, 0, 0, loc), got)
+
+	got, err = ExcelSerialToTime(0, Excel1904, time.UTC)
+	require.NoError(t, err)
+	assert.Equal(t, excel1904Base, got)
+
+	assert.Equal(t, 45218.375, TimeToExcelSerial(
+		time.Date(2023, 10, 19, 14, 0, 0, 0, time.UTC), Excel1900, loc,
+	))
+	assert.Equal(t, 1462.0, TimeToExcelSerial(
+		time.Date(1908, 1, 2, 0, 0, 0, 0, time.UTC), Excel1904, time.UTC,
+	))
+}
+
+func TestExcelSerial_NilLocation(t *testing.T) {
+	got, err := ExcelSerialToTime(45218.375, Excel1900, nil)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2023, 10, 19, 9, 0, 0, 0, time.UTC), got)
+
+	assert.Equal(t, 45218.375, TimeToExcelSerial(
+		time.Date(2023, 10, 19, 9, 0, 0, 0, time.UTC), Excel1900, nil,
+	))
+
+	b, err := json.Marshal(ExcelSerialOf[excelNilLocation](got))
+	require.NoError(t, err)
+	assert.Equal(t, "45218.375", string(b))
+
+	var s ExcelSerialOf[excelNilLocation]
+	err = json.Unmarshal(b, &s)
+	require.NoError(t, err)
+	assert.Equal(t, got, s.Time())
+}
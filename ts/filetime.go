@@ -0,0 +1,92 @@
+package ts
+
+import (
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+var fileTimeEpoch = Epoch{
+	Origin: time.Date(1601, 1, 1, 0, 0, 0, 0, time.UTC),
+	Unit:   100 * time.Nanosecond,
+}
+
+// FileTime is a wrapper around time.Time for marshaling to/from JSON/YAML as
+// Windows FILETIME values.
+//
+// It marshals to a JSON/YAML number representing the number of
+// 100-nanosecond intervals since January 1, 1601 UTC.
+//
+// It unmarshals from a JSON/YAML number, or numeric string, representing the
+// number of 100-nanosecond intervals since January 1, 1601 UTC.
+type FileTime time.Time
+
+// Time returns the time.Time corresponding to the FILETIME instant ft.
+func (ft FileTime) Time() time.Time {
+	return time.Time(ft)
+}
+
+// Local returns the local time corresponding to the FILETIME instant ft.
+func (ft FileTime) Local() FileTime {
+	return FileTime(time.Time(ft).Local())
+}
+
+// GoString implements the fmt.GoStringer interface.
+func (ft FileTime) GoString() string {
+	return time.Time(ft).GoString()
+}
+
+// IsDST reports whether the FILETIME instant ft occurs within Daylight
+// Saving Time.
+func (ft FileTime) IsDST() bool {
+	return time.Time(ft).IsDST()
+}
+
+// IsZero returns true if the FileTime is the zero value.
+func (ft FileTime) IsZero() bool {
+	return time.Time(ft).IsZero()
+}
+
+// String calls time.Time.String.
+func (ft FileTime) String() string {
+	return time.Time(ft).String()
+}
+
+// UTC returns a copy of the FileTime with the location set to UTC.
+func (ft FileTime) UTC() FileTime {
+	return FileTime(time.Time(ft).UTC())
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (ft FileTime) MarshalJSON() ([]byte, error) {
+	return fileTimeEpoch.marshalJSON(time.Time(ft))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (ft *FileTime) UnmarshalJSON(data []byte) error {
+	t, err := fileTimeEpoch.unmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+
+	*ft = FileTime(t)
+
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (ft FileTime) MarshalYAML() (interface{}, error) {
+	return fileTimeEpoch.marshalYAML(time.Time(ft))
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (ft *FileTime) UnmarshalYAML(node *yaml.Node) error {
+	t, err := fileTimeEpoch.unmarshalYAML(node)
+	if err != nil {
+		return err
+	}
+
+	*ft = FileTime(t)
+
+	return nil
+}
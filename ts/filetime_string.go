@@ -0,0 +1,77 @@
+package ts
+
+import (
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileTimeString is a wrapper around time.Time for marshaling to/from
+// JSON/YAML as Windows FILETIME values encoded as strings, which keeps large
+// values intact when decoded by JavaScript and other consumers that parse
+// numbers as float64.
+//
+// It marshals to a JSON/YAML string containing the same integer value as
+// FileTime.
+//
+// It unmarshals from a JSON/YAML string or number representing the number of
+// 100-nanosecond intervals since January 1, 1601 UTC, exactly like FileTime.
+type FileTimeString time.Time
+
+// Time returns the time.Time corresponding to the FILETIME string instant
+// ft.
+func (ft FileTimeString) Time() time.Time {
+	return time.Time(ft)
+}
+
+// Local returns the local time corresponding to the FILETIME string
+// instant ft.
+func (ft FileTimeString) Local() FileTimeString {
+	return FileTimeString(time.Time(ft).Local())
+}
+
+// GoString implements the fmt.GoStringer interface.
+func (ft FileTimeString) GoString() string {
+	return time.Time(ft).GoString()
+}
+
+// IsDST reports whether the FILETIME string instant ft occurs within
+// Daylight Saving Time.
+func (ft FileTimeString) IsDST() bool {
+	return time.Time(ft).IsDST()
+}
+
+// IsZero returns true if the FileTimeString is the zero value.
+func (ft FileTimeString) IsZero() bool {
+	return time.Time(ft).IsZero()
+}
+
+// String calls time.Time.String.
+func (ft FileTimeString) String() string {
+	return time.Time(ft).String()
+}
+
+// UTC returns a copy of the FileTimeString with the location set to UTC.
+func (ft FileTimeString) UTC() FileTimeString {
+	return FileTimeString(time.Time(ft).UTC())
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (ft FileTimeString) MarshalJSON() ([]byte, error) {
+	return marshalStringJSON(FileTime(ft).MarshalJSON())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (ft *FileTimeString) UnmarshalJSON(data []byte) error {
+	return (*FileTime)(ft).UnmarshalJSON(data)
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (ft FileTimeString) MarshalYAML() (interface{}, error) {
+	return marshalStringYAML(FileTime(ft).MarshalJSON())
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (ft *FileTimeString) UnmarshalYAML(node *yaml.Node) error {
+	return (*FileTime)(ft).UnmarshalYAML(node)
+}
@@ -0,0 +1,108 @@
+package ts
+
+import (
+	"math/big"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// floatMillisecondDigits is the number of fractional digits FloatMillisecond
+// values are marshaled with.
+const floatMillisecondDigits = 3
+
+// FloatMillisecond is a wrapper around time.Time for marshaling to/from
+// JSON/YAML as millisecond-based decimal Unix timestamps with fractional
+// milliseconds.
+//
+// It marshals to a JSON/YAML number representing the number of milliseconds
+// since the Unix time epoch, with three fractional digits. For a different
+// number of digits, use FloatMillisecondOf.
+//
+// It unmarshals from a JSON/YAML number representing the number of
+// milliseconds since the Unix time epoch. Decimal values are parsed exactly,
+// retaining precision down to the nanosecond.
+type FloatMillisecond time.Time
+
+// Time returns the time.Time corresponding to the float millisecond instant
+// ms.
+func (ms FloatMillisecond) Time() time.Time {
+	return time.Time(ms)
+}
+
+// Local returns the local time corresponding to the float millisecond instant
+// ms.
+func (ms FloatMillisecond) Local() FloatMillisecond {
+	return FloatMillisecond(time.Time(ms).Local())
+}
+
+// GoString implements the fmt.GoStringer interface.
+func (ms FloatMillisecond) GoString() string {
+	return time.Time(ms).GoString()
+}
+
+// IsDST reports whether the float millisecond instant ms occurs within
+// Daylight Saving Time.
+func (ms FloatMillisecond) IsDST() bool {
+	return time.Time(ms).IsDST()
+}
+
+// IsZero returns true if the FloatMillisecond is the zero value.
+func (ms FloatMillisecond) IsZero() bool {
+	return time.Time(ms).IsZero()
+}
+
+// String calls time.Time.String.
+func (ms FloatMillisecond) String() string {
+	return time.Time(ms).String()
+}
+
+// UTC returns a copy of the FloatMillisecond with the location set to UTC.
+func (ms FloatMillisecond) UTC() FloatMillisecond {
+	return FloatMillisecond(time.Time(ms).UTC())
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (ms FloatMillisecond) MarshalJSON() ([]byte, error) {
+	return []byte(
+		formatDecimal(time.Time(ms), time.Millisecond, floatMillisecondDigits),
+	), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (ms *FloatMillisecond) UnmarshalJSON(data []byte) error {
+	r, err := unmarshalDecimalBytes(data)
+	if err != nil {
+		return err
+	}
+
+	return ms.setRat(r)
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (ms FloatMillisecond) MarshalYAML() (interface{}, error) {
+	return marshalDecimalYAML(
+		formatDecimal(time.Time(ms), time.Millisecond, floatMillisecondDigits),
+	), nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (ms *FloatMillisecond) UnmarshalYAML(node *yaml.Node) error {
+	r, err := unmarshalDecimalYAMLNode(node)
+	if err != nil {
+		return err
+	}
+
+	return ms.setRat(r)
+}
+
+func (ms *FloatMillisecond) setRat(r *big.Rat) error {
+	t, err := ratToTime(r, time.Millisecond)
+	if err != nil {
+		return err
+	}
+
+	*ms = FloatMillisecond(t)
+
+	return nil
+}
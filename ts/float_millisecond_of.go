@@ -0,0 +1,57 @@
+package ts
+
+import (
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FloatMillisecondOf is a wrapper around time.Time for marshaling to/from
+// JSON/YAML as millisecond-based decimal Unix timestamps, with the number of
+// fractional digits declared by D:
+//
+//	type Event struct {
+//		At ts.FloatMillisecondOf[ts.Digits6] `json:"at"`
+//	}
+//
+// It otherwise behaves like FloatMillisecond, which is equivalent to
+// FloatMillisecondOf[Digits3].
+type FloatMillisecondOf[D Digits] time.Time
+
+// Time returns the time.Time corresponding to the instant ms.
+func (ms FloatMillisecondOf[D]) Time() time.Time {
+	return time.Time(ms)
+}
+
+// UTC returns a copy of ms with the location set to UTC.
+func (ms FloatMillisecondOf[D]) UTC() FloatMillisecondOf[D] {
+	return FloatMillisecondOf[D](time.Time(ms).UTC())
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (ms FloatMillisecondOf[D]) MarshalJSON() ([]byte, error) {
+	var d D
+
+	return []byte(
+		formatDecimal(time.Time(ms), time.Millisecond, d.digits()),
+	), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (ms *FloatMillisecondOf[D]) UnmarshalJSON(data []byte) error {
+	return (*FloatMillisecond)(ms).UnmarshalJSON(data)
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (ms FloatMillisecondOf[D]) MarshalYAML() (interface{}, error) {
+	var d D
+
+	return marshalDecimalYAML(
+		formatDecimal(time.Time(ms), time.Millisecond, d.digits()),
+	), nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (ms *FloatMillisecondOf[D]) UnmarshalYAML(node *yaml.Node) error {
+	return (*FloatMillisecond)(ms).UnmarshalYAML(node)
+}
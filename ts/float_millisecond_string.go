@@ -0,0 +1,78 @@
+package ts
+
+import (
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FloatMillisecondString is a wrapper around time.Time for marshaling
+// to/from JSON/YAML as millisecond-based Unix timestamps encoded as strings,
+// which keeps large values intact when decoded by JavaScript and other
+// consumers that parse numbers as float64.
+//
+// It marshals to a JSON/YAML string containing the same decimal value as
+// FloatMillisecond.
+//
+// It unmarshals from a JSON/YAML string or number representing the number of
+// milliseconds since the Unix time epoch, exactly like FloatMillisecond.
+type FloatMillisecondString time.Time
+
+// Time returns the time.Time corresponding to the float millisecond string
+// instant ms.
+func (ms FloatMillisecondString) Time() time.Time {
+	return time.Time(ms)
+}
+
+// Local returns the local time corresponding to the float millisecond string
+// instant ms.
+func (ms FloatMillisecondString) Local() FloatMillisecondString {
+	return FloatMillisecondString(time.Time(ms).Local())
+}
+
+// GoString implements the fmt.GoStringer interface.
+func (ms FloatMillisecondString) GoString() string {
+	return time.Time(ms).GoString()
+}
+
+// IsDST reports whether the float millisecond string instant ms occurs
+// within Daylight Saving Time.
+func (ms FloatMillisecondString) IsDST() bool {
+	return time.Time(ms).IsDST()
+}
+
+// IsZero returns true if the FloatMillisecondString is the zero value.
+func (ms FloatMillisecondString) IsZero() bool {
+	return time.Time(ms).IsZero()
+}
+
+// String calls time.Time.String.
+func (ms FloatMillisecondString) String() string {
+	return time.Time(ms).String()
+}
+
+// UTC returns a copy of the FloatMillisecondString with the location set to
+// UTC.
+func (ms FloatMillisecondString) UTC() FloatMillisecondString {
+	return FloatMillisecondString(time.Time(ms).UTC())
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (ms FloatMillisecondString) MarshalJSON() ([]byte, error) {
+	return marshalStringJSON(FloatMillisecond(ms).MarshalJSON())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (ms *FloatMillisecondString) UnmarshalJSON(data []byte) error {
+	return (*FloatMillisecond)(ms).UnmarshalJSON(data)
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (ms FloatMillisecondString) MarshalYAML() (interface{}, error) {
+	return marshalStringYAML(FloatMillisecond(ms).MarshalJSON())
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (ms *FloatMillisecondString) UnmarshalYAML(node *yaml.Node) error {
+	return (*FloatMillisecond)(ms).UnmarshalYAML(node)
+}
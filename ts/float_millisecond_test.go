@@ -0,0 +1,80 @@
+package ts
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestFloatMillisecond_MarshalUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		t    time.Time
+		want string
+	}{
+		{
+			name: "nanoseconds",
+			t:    time.Date(2023, 10, 11, 16, 0, 0, 123456789, time.UTC),
+			want: "1697040000123.457",
+		},
+		{
+			name: "whole milliseconds",
+			t:    time.Date(2023, 10, 11, 16, 0, 0, 123000000, time.UTC),
+			want: "1697040000123.000",
+		},
+		{
+			name: "before epoch",
+			t:    time.Date(1969, 12, 31, 23, 59, 59, 998500000, time.UTC),
+			want: "-1.500",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := json.Marshal(FloatMillisecond(tt.t))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(b))
+
+			var got FloatMillisecond
+			err = json.Unmarshal(b, &got)
+			require.NoError(t, err)
+
+			want := tt.t.Round(time.Microsecond)
+			assert.Equal(t, want.UTC(), time.Time(got).UTC())
+
+			b, err = yaml.Marshal(FloatMillisecond(tt.t))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want+"\n", string(b))
+
+			got = FloatMillisecond{}
+			err = yaml.Unmarshal(b, &got)
+			require.NoError(t, err)
+			assert.Equal(t, want.UTC(), time.Time(got).UTC())
+		})
+	}
+}
+
+func TestFloatMillisecondOf_MarshalUnmarshal(t *testing.T) {
+	tm := time.Date(2023, 10, 11, 16, 0, 0, 123456789, time.UTC)
+
+	b, err := json.Marshal(FloatMillisecondOf[Digits6](tm))
+	require.NoError(t, err)
+	assert.Equal(t, "1697040000123.456789", string(b))
+
+	b, err = yaml.Marshal(FloatMillisecondOf[Digits0](tm))
+	require.NoError(t, err)
+	assert.Equal(t, "1697040000123\n", string(b))
+
+	var got FloatMillisecondOf[Digits6]
+	err = json.Unmarshal([]byte(`"1697040000123.456789"`), &got)
+	require.NoError(t, err)
+	assert.Equal(t, tm, got.UTC().Time())
+
+	got = FloatMillisecondOf[Digits6]{}
+	err = yaml.Unmarshal([]byte("1697040000123.456789"), &got)
+	require.NoError(t, err)
+	assert.Equal(t, tm, got.UTC().Time())
+}
@@ -0,0 +1,105 @@
+package ts
+
+import (
+	"math/big"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// floatSecondDigits is the number of fractional digits FloatSecond values are
+// marshaled with.
+const floatSecondDigits = 6
+
+// FloatSecond is a wrapper around time.Time for marshaling to/from JSON/YAML as
+// second-based decimal Unix timestamps with fractional seconds.
+//
+// It marshals to a JSON/YAML number representing the number of seconds since
+// the Unix time epoch, with six fractional digits. For a different number of
+// digits, use FloatSecondOf.
+//
+// It unmarshals from a JSON/YAML number representing the number of seconds
+// since the Unix time epoch. Decimal values are parsed exactly, retaining
+// precision down to the nanosecond.
+type FloatSecond time.Time
+
+// Time returns the time.Time corresponding to the float second instant s.
+func (s FloatSecond) Time() time.Time {
+	return time.Time(s)
+}
+
+// Local returns the local time corresponding to the float second instant s.
+func (s FloatSecond) Local() FloatSecond {
+	return FloatSecond(time.Time(s).Local())
+}
+
+// GoString implements the fmt.GoStringer interface.
+func (s FloatSecond) GoString() string {
+	return time.Time(s).GoString()
+}
+
+// IsDST reports whether the float second instant s occurs within Daylight
+// Saving Time.
+func (s FloatSecond) IsDST() bool {
+	return time.Time(s).IsDST()
+}
+
+// IsZero returns true if the FloatSecond is the zero value.
+func (s FloatSecond) IsZero() bool {
+	return time.Time(s).IsZero()
+}
+
+// String calls time.Time.String.
+func (s FloatSecond) String() string {
+	return time.Time(s).String()
+}
+
+// UTC returns a copy of the FloatSecond with the location set to UTC.
+func (s FloatSecond) UTC() FloatSecond {
+	return FloatSecond(time.Time(s).UTC())
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (s FloatSecond) MarshalJSON() ([]byte, error) {
+	return []byte(
+		formatDecimal(time.Time(s), time.Second, floatSecondDigits),
+	), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (s *FloatSecond) UnmarshalJSON(data []byte) error {
+	r, err := unmarshalDecimalBytes(data)
+	if err != nil {
+		return err
+	}
+
+	return s.setRat(r)
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (s FloatSecond) MarshalYAML() (interface{}, error) {
+	return marshalDecimalYAML(
+		formatDecimal(time.Time(s), time.Second, floatSecondDigits),
+	), nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (s *FloatSecond) UnmarshalYAML(node *yaml.Node) error {
+	r, err := unmarshalDecimalYAMLNode(node)
+	if err != nil {
+		return err
+	}
+
+	return s.setRat(r)
+}
+
+func (s *FloatSecond) setRat(r *big.Rat) error {
+	t, err := ratToTime(r, time.Second)
+	if err != nil {
+		return err
+	}
+
+	*s = FloatSecond(t)
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package ts
+
+import (
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FloatSecondOf is a wrapper around time.Time for marshaling to/from
+// JSON/YAML as second-based decimal Unix timestamps, with the number of
+// fractional digits declared by D:
+//
+//	type Event struct {
+//		At ts.FloatSecondOf[ts.Digits3] `json:"at"`
+//	}
+//
+// It otherwise behaves like FloatSecond, which is equivalent to
+// FloatSecondOf[Digits6].
+type FloatSecondOf[D Digits] time.Time
+
+// Time returns the time.Time corresponding to the instant s.
+func (s FloatSecondOf[D]) Time() time.Time {
+	return time.Time(s)
+}
+
+// UTC returns a copy of s with the location set to UTC.
+func (s FloatSecondOf[D]) UTC() FloatSecondOf[D] {
+	return FloatSecondOf[D](time.Time(s).UTC())
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (s FloatSecondOf[D]) MarshalJSON() ([]byte, error) {
+	var d D
+
+	return []byte(
+		formatDecimal(time.Time(s), time.Second, d.digits()),
+	), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (s *FloatSecondOf[D]) UnmarshalJSON(data []byte) error {
+	return (*FloatSecond)(s).UnmarshalJSON(data)
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (s FloatSecondOf[D]) MarshalYAML() (interface{}, error) {
+	var d D
+
+	return marshalDecimalYAML(
+		formatDecimal(time.Time(s), time.Second, d.digits()),
+	), nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (s *FloatSecondOf[D]) UnmarshalYAML(node *yaml.Node) error {
+	return (*FloatSecond)(s).UnmarshalYAML(node)
+}
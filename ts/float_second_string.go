@@ -0,0 +1,77 @@
+package ts
+
+import (
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FloatSecondString is a wrapper around time.Time for marshaling to/from
+// JSON/YAML as second-based Unix timestamps encoded as strings, which keeps
+// large values intact when decoded by JavaScript and other consumers that
+// parse numbers as float64.
+//
+// It marshals to a JSON/YAML string containing the same decimal value as
+// FloatSecond.
+//
+// It unmarshals from a JSON/YAML string or number representing the number of
+// seconds since the Unix time epoch, exactly like FloatSecond.
+type FloatSecondString time.Time
+
+// Time returns the time.Time corresponding to the float second string
+// instant s.
+func (s FloatSecondString) Time() time.Time {
+	return time.Time(s)
+}
+
+// Local returns the local time corresponding to the float second string
+// instant s.
+func (s FloatSecondString) Local() FloatSecondString {
+	return FloatSecondString(time.Time(s).Local())
+}
+
+// GoString implements the fmt.GoStringer interface.
+func (s FloatSecondString) GoString() string {
+	return time.Time(s).GoString()
+}
+
+// IsDST reports whether the float second string instant s occurs within
+// Daylight Saving Time.
+func (s FloatSecondString) IsDST() bool {
+	return time.Time(s).IsDST()
+}
+
+// IsZero returns true if the FloatSecondString is the zero value.
+func (s FloatSecondString) IsZero() bool {
+	return time.Time(s).IsZero()
+}
+
+// String calls time.Time.String.
+func (s FloatSecondString) String() string {
+	return time.Time(s).String()
+}
+
+// UTC returns a copy of the FloatSecondString with the location set to UTC.
+func (s FloatSecondString) UTC() FloatSecondString {
+	return FloatSecondString(time.Time(s).UTC())
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (s FloatSecondString) MarshalJSON() ([]byte, error) {
+	return marshalStringJSON(FloatSecond(s).MarshalJSON())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (s *FloatSecondString) UnmarshalJSON(data []byte) error {
+	return (*FloatSecond)(s).UnmarshalJSON(data)
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (s FloatSecondString) MarshalYAML() (interface{}, error) {
+	return marshalStringYAML(FloatSecond(s).MarshalJSON())
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (s *FloatSecondString) UnmarshalYAML(node *yaml.Node) error {
+	return (*FloatSecond)(s).UnmarshalYAML(node)
+}
@@ -0,0 +1,212 @@
+package ts
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+var floatSecondTestCases = []struct {
+	name string
+	t    time.Time
+	want string
+}{
+	{
+		name: "nanoseconds",
+		t:    time.Date(2023, 10, 11, 16, 0, 0, 123456789, time.UTC),
+		want: "1697040000.123457",
+	},
+	{
+		name: "trailing zeros",
+		t:    time.Date(2023, 10, 11, 16, 0, 0, 100000000, time.UTC),
+		want: "1697040000.100000",
+	},
+	{
+		name: "whole seconds",
+		t:    time.Date(2023, 10, 11, 16, 0, 0, 0, time.UTC),
+		want: "1697040000.000000",
+	},
+	{
+		name: "before epoch",
+		t:    time.Date(1969, 12, 31, 23, 59, 58, 750000000, time.UTC),
+		want: "-1.250000",
+	},
+}
+
+func TestFloatSecond_MarshalUnmarshalJSON(t *testing.T) {
+	for _, tt := range floatSecondTestCases {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := json.Marshal(FloatSecond(tt.t))
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, string(b))
+
+			var got FloatSecond
+			err = json.Unmarshal(b, &got)
+			require.NoError(t, err)
+
+			want := tt.t.Round(time.Microsecond)
+			assert.Equal(t, want.UTC(), time.Time(got).UTC())
+			assert.Equal(t, time.Local, time.Time(got).Location())
+		})
+	}
+}
+
+func TestFloatSecond_MarshalUnmarshalYAML(t *testing.T) {
+	for _, tt := range floatSecondTestCases {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := yaml.Marshal(FloatSecond(tt.t))
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want+"\n", string(b))
+
+			var got FloatSecond
+			err = yaml.Unmarshal(b, &got)
+			require.NoError(t, err)
+
+			want := tt.t.Round(time.Microsecond)
+			assert.Equal(t, want.UTC(), time.Time(got).UTC())
+		})
+	}
+}
+
+type nanoFloatSecond struct{}
+
+func (nanoFloatSecond) Epoch() Epoch {
+	return Epoch{Origin: time.Unix(0, 0), Unit: time.Second, Digits: 9}
+}
+
+func TestFloatSecond_EpochTimeDigits(t *testing.T) {
+	tm := time.Date(2023, 10, 11, 16, 0, 0, 123456789, time.UTC)
+
+	b, err := json.Marshal(EpochTime[nanoFloatSecond](tm))
+	require.NoError(t, err)
+	assert.Equal(t, "1697040000.123456789", string(b))
+
+	var got EpochTime[nanoFloatSecond]
+	err = json.Unmarshal(b, &got)
+	require.NoError(t, err)
+	assert.True(t, tm.Equal(got.Time()), got.Time().String())
+}
+
+func TestFloatSecond_Unmarshal(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    time.Time
+		wantErr string
+	}{
+		{
+			name: "integer",
+			s:    "1697040000",
+			want: time.Unix(1697040000, 0),
+		},
+		{
+			name: "nanosecond fraction",
+			s:    "1697040000.123456789",
+			want: time.Unix(1697040000, 123456789),
+		},
+		{
+			name: "string",
+			s:    `"1697040000.123456789"`,
+			want: time.Unix(1697040000, 123456789),
+		},
+		{
+			name: "exponent",
+			s:    "1.697040000123e9",
+			want: time.Unix(1697040000, 123000000),
+		},
+		{
+			name: "negative fraction floors",
+			s:    "-0.5",
+			want: time.Unix(-1, 500000000),
+		},
+		{
+			name:    "invalid",
+			s:       `"2023-10-11T16:00:00Z"`,
+			wantErr: "invalid numeric timestamp",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+" JSON", func(t *testing.T) {
+			var got FloatSecond
+			err := json.Unmarshal([]byte(tt.s), &got)
+
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, time.Time(got))
+		})
+		t.Run(tt.name+" YAML", func(t *testing.T) {
+			var got FloatSecond
+			err := yaml.Unmarshal([]byte(tt.s), &got)
+
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, time.Time(got))
+		})
+	}
+}
+
+func TestFloatSecondOf_MarshalUnmarshal(t *testing.T) {
+	tm := time.Date(2023, 10, 11, 16, 0, 0, 123456789, time.UTC)
+
+	tests := []struct {
+		name  string
+		value interface{}
+		want  string
+	}{
+		{
+			name:  "zero digits",
+			value: FloatSecondOf[Digits0](tm),
+			want:  "1697040000",
+		},
+		{
+			name:  "three digits",
+			value: FloatSecondOf[Digits3](tm),
+			want:  "1697040000.123",
+		},
+		{
+			name:  "six digits",
+			value: FloatSecondOf[Digits6](tm),
+			want:  "1697040000.123457",
+		},
+		{
+			name:  "nine digits",
+			value: FloatSecondOf[Digits9](tm),
+			want:  "1697040000.123456789",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := json.Marshal(tt.value)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(b))
+
+			b, err = yaml.Marshal(tt.value)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want+"\n", string(b))
+		})
+	}
+
+	var got FloatSecondOf[Digits9]
+	err := json.Unmarshal([]byte("1697040000.123456789"), &got)
+	require.NoError(t, err)
+	assert.Equal(t, tm, got.Time().UTC())
+
+	got = FloatSecondOf[Digits9]{}
+	err = yaml.Unmarshal([]byte(`"-1.25"`), &got)
+	require.NoError(t, err)
+	assert.Equal(t, time.Unix(-2, 750000000).UTC(), got.UTC().Time())
+}
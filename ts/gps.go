@@ -0,0 +1,109 @@
+package ts
+
+import (
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// gpsTAIOffset is the fixed amount GPS time is behind TAI.
+const gpsTAIOffset = 19 * time.Second
+
+var gpsEpoch = Epoch{
+	Origin: time.Date(1980, 1, 6, 0, 0, 0, 0, time.UTC),
+	Unit:   time.Second,
+}
+
+// GPSSecond is a wrapper around time.Time for marshaling to/from JSON/YAML as
+// GPS time, as used by GNSS receivers.
+//
+// It marshals to a JSON/YAML number representing the number of seconds since
+// the GPS epoch of January 6, 1980 UTC, without leap seconds. GPS time is
+// behind TAI by 19 seconds, and is converted from UTC using LeapSeconds.
+//
+// It unmarshals from a JSON/YAML number, or numeric string, representing the
+// number of seconds since the GPS epoch, without leap seconds. Decimal values
+// are parsed exactly, retaining precision down to the nanosecond.
+type GPSSecond time.Time
+
+// Time returns the time.Time corresponding to the GPS instant s.
+func (s GPSSecond) Time() time.Time {
+	return time.Time(s)
+}
+
+// Local returns the local time corresponding to the GPS instant s.
+func (s GPSSecond) Local() GPSSecond {
+	return GPSSecond(time.Time(s).Local())
+}
+
+// GoString implements the fmt.GoStringer interface.
+func (s GPSSecond) GoString() string {
+	return time.Time(s).GoString()
+}
+
+// IsDST reports whether the GPS instant s occurs within Daylight
+// Saving Time.
+func (s GPSSecond) IsDST() bool {
+	return time.Time(s).IsDST()
+}
+
+// IsZero returns true if the GPSSecond is the zero value.
+func (s GPSSecond) IsZero() bool {
+	return time.Time(s).IsZero()
+}
+
+// String calls time.Time.String.
+func (s GPSSecond) String() string {
+	return time.Time(s).String()
+}
+
+// UTC returns a copy of the GPSSecond with the location set to UTC.
+func (s GPSSecond) UTC() GPSSecond {
+	return GPSSecond(time.Time(s).UTC())
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (s GPSSecond) MarshalJSON() ([]byte, error) {
+	return gpsEpoch.marshalJSON(utcToGPS(time.Time(s)))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (s *GPSSecond) UnmarshalJSON(data []byte) error {
+	t, err := gpsEpoch.unmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+
+	*s = GPSSecond(gpsToUTC(t))
+
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (s GPSSecond) MarshalYAML() (interface{}, error) {
+	return gpsEpoch.marshalYAML(utcToGPS(time.Time(s)))
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (s *GPSSecond) UnmarshalYAML(node *yaml.Node) error {
+	t, err := gpsEpoch.unmarshalYAML(node)
+	if err != nil {
+		return err
+	}
+
+	*s = GPSSecond(gpsToUTC(t))
+
+	return nil
+}
+
+// utcToGPS returns the GPS time corresponding to the UTC instant t,
+// expressed as a time whose wall clock reads GPS time.
+func utcToGPS(t time.Time) time.Time {
+	return utcToTAI(t).Add(-gpsTAIOffset)
+}
+
+// gpsToUTC returns the UTC instant corresponding to t, a time whose wall clock
+// reads GPS time.
+func gpsToUTC(t time.Time) time.Time {
+	return taiToUTC(t.Add(gpsTAIOffset))
+}
@@ -0,0 +1,141 @@
+package ts
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed leap-seconds.list
+var leapSecondsList []byte
+
+// LeapSecond describes a change in the offset between TAI and UTC.
+type LeapSecond struct {
+	// Time is the UTC instant at which Offset takes effect.
+	Time time.Time
+
+	// Offset is the number of seconds TAI is ahead of UTC from Time onwards.
+	Offset int
+}
+
+// LeapSeconds is the table of leap seconds used to convert between UTC and
+// TAI, sorted by Time. It defaults to an embedded table which is current as of
+// the leap second inserted at the end of 2016, and can be replaced with a newer
+// table, for example one returned by ParseLeapSeconds. It must not be modified
+// while conversions are in progress.
+var LeapSeconds = mustParseLeapSeconds(leapSecondsList)
+
+// ParseLeapSeconds parses a table of leap seconds in the format of the
+// leap-seconds.list file published by the IERS and NIST. Each line holds a NTP
+// timestamp, followed by the TAI-UTC offset in seconds which takes effect at
+// that time. Anything after a '#' is ignored.
+func ParseLeapSeconds(r io.Reader) ([]LeapSecond, error) {
+	var table []LeapSecond
+
+	s := bufio.NewScanner(r)
+	for n := 1; s.Scan(); n++ {
+		line := s.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("invalid leap second on line %d", n)
+		}
+
+		sec, err := strconv.ParseInt(fields[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid leap second on line %d", n)
+		}
+		offset, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid leap second on line %d", n)
+		}
+
+		table = append(table, LeapSecond{
+			Time:   time.Unix(sec-ntpUnixOffset, 0).UTC(),
+			Offset: offset,
+		})
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(table, func(i, j int) bool {
+		return table[i].Time.Before(table[j].Time)
+	})
+
+	return table, nil
+}
+
+func mustParseLeapSeconds(data []byte) []LeapSecond {
+	table, err := ParseLeapSeconds(bytes.NewReader(data))
+	if err != nil {
+		panic(err)
+	}
+
+	return table
+}
+
+// TAIOffset returns the amount TAI is ahead of UTC at the UTC instant t,
+// according to LeapSeconds. Times before the first leap second use its offset,
+// matching the convention of TAI64 labels.
+func TAIOffset(t time.Time) time.Duration {
+	if len(LeapSeconds) == 0 {
+		return 0
+	}
+
+	i := sort.Search(len(LeapSeconds), func(i int) bool {
+		return LeapSeconds[i].Time.After(t)
+	})
+	if i > 0 {
+		i--
+	}
+
+	return time.Duration(LeapSeconds[i].Offset) * time.Second
+}
+
+// UTCToTAI returns the TAI time corresponding to the UTC instant t, expressed
+// as a time whose wall clock reads TAI. The result is strictly increasing
+// across leap seconds.
+func UTCToTAI[T Timestamp](t T) T {
+	return T(utcToTAI(time.Time(t)))
+}
+
+// TAIToUTC returns the UTC instant corresponding to t, a time whose wall clock
+// reads TAI. As time.Time cannot represent leap seconds, TAI times falling
+// within an inserted leap second return the UTC instant at the end of it.
+func TAIToUTC[T Timestamp](t T) T {
+	return T(taiToUTC(time.Time(t)))
+}
+
+func utcToTAI(t time.Time) time.Time {
+	return t.Add(TAIOffset(t))
+}
+
+func taiToUTC(t time.Time) time.Time {
+	for i := len(LeapSeconds) - 1; i > 0; i-- {
+		ls := LeapSeconds[i]
+		offset := time.Duration(ls.Offset) * time.Second
+		if !t.Before(ls.Time.Add(offset)) {
+			return t.Add(-offset)
+		}
+
+		prev := time.Duration(LeapSeconds[i-1].Offset) * time.Second
+		if !t.Before(ls.Time.Add(prev)) {
+			return ls.Time.In(t.Location())
+		}
+	}
+
+	return t.Add(-TAIOffset(t))
+}
@@ -0,0 +1,178 @@
+package ts
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeapSeconds(t *testing.T) {
+	require.Len(t, LeapSeconds, 28)
+	assert.Equal(t, LeapSecond{
+		Time:   time.Date(1972, 1, 1, 0, 0, 0, 0, time.UTC),
+		Offset: 10,
+	}, LeapSeconds[0])
+	assert.Equal(t, LeapSecond{
+		Time:   time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC),
+		Offset: 37,
+	}, LeapSeconds[27])
+}
+
+func TestParseLeapSeconds(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    []LeapSecond
+		wantErr string
+	}{
+		{
+			name: "entries out of order with comments",
+			s: "# leap seconds\n" +
+				"\n" +
+				"2287785600\t11\t# 1 Jul 1972\n" +
+				"2272060800 10\n" +
+				"#@\t3960057600\n",
+			want: []LeapSecond{
+				{Time: time.Date(1972, 1, 1, 0, 0, 0, 0, time.UTC), Offset: 10},
+				{Time: time.Date(1972, 7, 1, 0, 0, 0, 0, time.UTC), Offset: 11},
+			},
+		},
+		{
+			name:    "missing offset",
+			s:       "# leap seconds\n2272060800\n",
+			wantErr: "invalid leap second on line 2",
+		},
+		{
+			name:    "invalid offset",
+			s:       "2272060800\tten\n",
+			wantErr: "invalid leap second on line 1",
+		},
+		{
+			name:    "invalid time",
+			s:       "1972-01-01\t10\n",
+			wantErr: "invalid leap second on line 1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseLeapSeconds(strings.NewReader(tt.s))
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTAIOffset(t *testing.T) {
+	tests := []struct {
+		name string
+		t    time.Time
+		want time.Duration
+	}{
+		{
+			name: "before first leap second",
+			t:    time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
+			want: 10 * time.Second,
+		},
+		{
+			name: "just before leap second",
+			t:    time.Date(2016, 12, 31, 23, 59, 59, 999999999, time.UTC),
+			want: 36 * time.Second,
+		},
+		{
+			name: "at leap second",
+			t:    time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC),
+			want: 37 * time.Second,
+		},
+		{
+			name: "non-UTC location",
+			t: time.Date(
+				2017, 1, 1, 7, 0, 0, 0, time.FixedZone("UTC+8", 8*60*60),
+			),
+			want: 36 * time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, TAIOffset(tt.t))
+		})
+	}
+}
+
+func TestUTCToTAI(t *testing.T) {
+	got := UTCToTAI(Second(time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)))
+
+	assert.IsType(t, Second{}, got)
+	assert.Equal(t, time.Date(2017, 1, 1, 0, 0, 37, 0, time.UTC), got.Time())
+}
+
+func TestTAIToUTC(t *testing.T) {
+	tests := []struct {
+		name string
+		tai  time.Time
+		want time.Time
+	}{
+		{
+			name: "before leap second",
+			tai:  time.Date(2017, 1, 1, 0, 0, 35, 0, time.UTC),
+			want: time.Date(2016, 12, 31, 23, 59, 59, 0, time.UTC),
+		},
+		{
+			name: "during leap second",
+			tai:  time.Date(2017, 1, 1, 0, 0, 36, 500000000, time.UTC),
+			want: time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "after leap second",
+			tai:  time.Date(2017, 1, 1, 0, 0, 37, 500000000, time.UTC),
+			want: time.Date(2017, 1, 1, 0, 0, 0, 500000000, time.UTC),
+		},
+		{
+			name: "before first leap second",
+			tai:  time.Date(1970, 1, 1, 0, 0, 10, 0, time.UTC),
+			want: time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TAIToUTC(Millisecond(tt.tai))
+
+			assert.True(t, tt.want.Equal(got.Time()), got.Time().String())
+		})
+	}
+}
+
+func TestTAIToUTC_Roundtrip(t *testing.T) {
+	start := time.Date(2016, 12, 31, 23, 59, 58, 0, time.UTC)
+	for i := 0; i < 8; i++ {
+		ut := start.Add(time.Duration(i) * 500 * time.Millisecond)
+
+		assert.True(t, ut.Equal(TAIToUTC(UTCToTAI(ut))), ut.String())
+	}
+}
+
+func TestLeapSeconds_Custom(t *testing.T) {
+	defer func(v []LeapSecond) { LeapSeconds = v }(LeapSeconds)
+	LeapSeconds = append(LeapSeconds[:len(LeapSeconds):len(LeapSeconds)],
+		LeapSecond{
+			Time:   time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+			Offset: 38,
+		},
+	)
+
+	assert.Equal(t, 38*time.Second, TAIOffset(
+		time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC),
+	))
+
+	LeapSeconds = nil
+
+	assert.Equal(t, time.Duration(0), TAIOffset(time.Now()))
+}
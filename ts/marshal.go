@@ -0,0 +1,23 @@
+package ts
+
+import "strconv"
+
+// marshalStringJSON wraps the JSON number b, as returned by a MarshalJSON
+// method, in a JSON string.
+func marshalStringJSON(b []byte, err error) ([]byte, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(strconv.Quote(string(b))), nil
+}
+
+// marshalStringYAML returns the JSON number b, as returned by a MarshalJSON
+// method, as a string for YAML marshaling.
+func marshalStringYAML(b []byte, err error) (interface{}, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	return string(b), nil
+}
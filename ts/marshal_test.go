@@ -1,6 +1,9 @@
 package ts
 
-import "time"
+import (
+	"math"
+	"time"
+)
 
 var (
 	marshalUnmarshalTestCases = []struct {
@@ -77,7 +80,7 @@ var (
 		},
 		{
 			name:        "min second",
-			t:           time.Date(292277026596, 12, 4, 15, 30, 8, 0, time.UTC),
+			t:           time.Unix(math.MinInt64, 0).UTC(),
 			second:      "-9223372036854775808",
 			millisecond: "0",
 			microsecond: "0",
@@ -85,7 +88,7 @@ var (
 		},
 		{
 			name:        "max second",
-			t:           time.Date(292277026596, 12, 4, 15, 30, 7, 0, time.UTC),
+			t:           time.Unix(math.MaxInt64, 0).UTC(),
 			second:      "9223372036854775807",
 			millisecond: "-1000",
 			microsecond: "-1000000",
@@ -1,6 +1,7 @@
 package ts
 
 import (
+	"math"
 	"strconv"
 	"time"
 
@@ -53,9 +54,16 @@ func (ms Microsecond) UTC() Microsecond {
 	return Microsecond(time.Time(ms).UTC())
 }
 
-// MarshalJSON implements the json.Marshaler interface.
+// MarshalJSON implements the json.Marshaler interface. It returns an error
+// wrapping ErrOverflow if the time cannot be represented as an int64 number of
+// microseconds.
 func (ms Microsecond) MarshalJSON() ([]byte, error) {
-	return []byte(strconv.FormatInt(time.Time(ms).UnixMicro(), 10)), nil
+	i, err := marshalUnixMicro(time.Time(ms))
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(strconv.FormatInt(i, 10)), nil
 }
 
 // UnmarshalJSON implements the json.Unmarshaler interface.
@@ -65,14 +73,21 @@ func (ms *Microsecond) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
-	*ms = UnixMicro(i)
+	t, err := unixTime(i, time.Microsecond)
+	if err != nil {
+		return err
+	}
+
+	*ms = Microsecond(t)
 
 	return nil
 }
 
-// MarshalJSON implements the yaml.Marshaler interface.
+// MarshalYAML implements the yaml.Marshaler interface. It returns an error
+// wrapping ErrOverflow if the time cannot be represented as an int64 number of
+// microseconds.
 func (ms Microsecond) MarshalYAML() (interface{}, error) {
-	return time.Time(ms).UnixMicro(), nil
+	return marshalUnixMicro(time.Time(ms))
 }
 
 // UnmarshalYAML implements the yaml.Unmarshaler interface.
@@ -82,7 +97,28 @@ func (ms *Microsecond) UnmarshalYAML(node *yaml.Node) error {
 		return err
 	}
 
-	*ms = UnixMicro(i)
+	t, err := unixTime(i, time.Microsecond)
+	if err != nil {
+		return err
+	}
+
+	*ms = Microsecond(t)
 
 	return nil
 }
+
+var (
+	minUnixMicro = time.UnixMicro(math.MinInt64)
+	maxUnixMicro = time.UnixMicro(math.MaxInt64)
+)
+
+// marshalUnixMicro returns t as a number of microseconds since the Unix epoch,
+// rather than the wrapped around value time.Time.UnixMicro returns for times
+// outside of the range of an int64.
+func marshalUnixMicro(t time.Time) (int64, error) {
+	if err := checkUnixRange(t, minUnixMicro, maxUnixMicro); err != nil {
+		return 0, err
+	}
+
+	return t.UnixMicro(), nil
+}
@@ -0,0 +1,77 @@
+package ts
+
+import (
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MicrosecondString is a wrapper around time.Time for marshaling to/from
+// JSON/YAML as microsecond-based Unix timestamps encoded as strings, which
+// keeps large values intact when decoded by JavaScript and other consumers
+// that parse numbers as float64.
+//
+// It marshals to a JSON/YAML string containing the same integer value as
+// Microsecond.
+//
+// It unmarshals from a JSON/YAML string or number representing the number of
+// microseconds since the Unix time epoch, exactly like Microsecond.
+type MicrosecondString time.Time
+
+// Time returns the time.Time corresponding to the microsecond string instant
+// us.
+func (us MicrosecondString) Time() time.Time {
+	return time.Time(us)
+}
+
+// Local returns the local time corresponding to the microsecond string
+// instant us.
+func (us MicrosecondString) Local() MicrosecondString {
+	return MicrosecondString(time.Time(us).Local())
+}
+
+// GoString implements the fmt.GoStringer interface.
+func (us MicrosecondString) GoString() string {
+	return time.Time(us).GoString()
+}
+
+// IsDST reports whether the microsecond string instant us occurs within
+// Daylight Saving Time.
+func (us MicrosecondString) IsDST() bool {
+	return time.Time(us).IsDST()
+}
+
+// IsZero returns true if the MicrosecondString is the zero value.
+func (us MicrosecondString) IsZero() bool {
+	return time.Time(us).IsZero()
+}
+
+// String calls time.Time.String.
+func (us MicrosecondString) String() string {
+	return time.Time(us).String()
+}
+
+// UTC returns a copy of the MicrosecondString with the location set to UTC.
+func (us MicrosecondString) UTC() MicrosecondString {
+	return MicrosecondString(time.Time(us).UTC())
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (us MicrosecondString) MarshalJSON() ([]byte, error) {
+	return marshalStringJSON(Microsecond(us).MarshalJSON())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (us *MicrosecondString) UnmarshalJSON(data []byte) error {
+	return (*Microsecond)(us).UnmarshalJSON(data)
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (us MicrosecondString) MarshalYAML() (interface{}, error) {
+	return marshalStringYAML(Microsecond(us).MarshalJSON())
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (us *MicrosecondString) UnmarshalYAML(node *yaml.Node) error {
+	return (*Microsecond)(us).UnmarshalYAML(node)
+}
@@ -1,6 +1,7 @@
 package ts
 
 import (
+	"math"
 	"strconv"
 	"time"
 
@@ -53,9 +54,16 @@ func (ms Millisecond) UTC() Millisecond {
 	return Millisecond(time.Time(ms).UTC())
 }
 
-// MarshalJSON implements the json.Marshaler interface.
+// MarshalJSON implements the json.Marshaler interface. It returns an error
+// wrapping ErrOverflow if the time cannot be represented as an int64 number of
+// milliseconds.
 func (ms Millisecond) MarshalJSON() ([]byte, error) {
-	return []byte(strconv.FormatInt(time.Time(ms).UnixMilli(), 10)), nil
+	i, err := marshalUnixMilli(time.Time(ms))
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(strconv.FormatInt(i, 10)), nil
 }
 
 // UnmarshalJSON implements the json.Unmarshaler interface.
@@ -65,14 +73,21 @@ func (ms *Millisecond) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
-	*ms = UnixMilli(i)
+	t, err := unixTime(i, time.Millisecond)
+	if err != nil {
+		return err
+	}
+
+	*ms = Millisecond(t)
 
 	return nil
 }
 
-// MarshalJSON implements the yaml.Marshaler interface.
+// MarshalYAML implements the yaml.Marshaler interface. It returns an error
+// wrapping ErrOverflow if the time cannot be represented as an int64 number of
+// milliseconds.
 func (ms Millisecond) MarshalYAML() (interface{}, error) {
-	return time.Time(ms).UnixMilli(), nil
+	return marshalUnixMilli(time.Time(ms))
 }
 
 // UnmarshalYAML implements the yaml.Unmarshaler interface.
@@ -82,7 +97,28 @@ func (ms *Millisecond) UnmarshalYAML(node *yaml.Node) error {
 		return err
 	}
 
-	*ms = UnixMilli(i)
+	t, err := unixTime(i, time.Millisecond)
+	if err != nil {
+		return err
+	}
+
+	*ms = Millisecond(t)
 
 	return nil
 }
+
+var (
+	minUnixMilli = time.UnixMilli(math.MinInt64)
+	maxUnixMilli = time.UnixMilli(math.MaxInt64)
+)
+
+// marshalUnixMilli returns t as a number of milliseconds since the Unix epoch,
+// rather than the wrapped around value time.Time.UnixMilli returns for times
+// outside of the range of an int64.
+func marshalUnixMilli(t time.Time) (int64, error) {
+	if err := checkUnixRange(t, minUnixMilli, maxUnixMilli); err != nil {
+		return 0, err
+	}
+
+	return t.UnixMilli(), nil
+}
@@ -0,0 +1,77 @@
+package ts
+
+import (
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MillisecondString is a wrapper around time.Time for marshaling to/from
+// JSON/YAML as millisecond-based Unix timestamps encoded as strings, which
+// keeps large values intact when decoded by JavaScript and other consumers
+// that parse numbers as float64.
+//
+// It marshals to a JSON/YAML string containing the same integer value as
+// Millisecond.
+//
+// It unmarshals from a JSON/YAML string or number representing the number of
+// milliseconds since the Unix time epoch, exactly like Millisecond.
+type MillisecondString time.Time
+
+// Time returns the time.Time corresponding to the millisecond string instant
+// ms.
+func (ms MillisecondString) Time() time.Time {
+	return time.Time(ms)
+}
+
+// Local returns the local time corresponding to the millisecond string
+// instant ms.
+func (ms MillisecondString) Local() MillisecondString {
+	return MillisecondString(time.Time(ms).Local())
+}
+
+// GoString implements the fmt.GoStringer interface.
+func (ms MillisecondString) GoString() string {
+	return time.Time(ms).GoString()
+}
+
+// IsDST reports whether the millisecond string instant ms occurs within
+// Daylight Saving Time.
+func (ms MillisecondString) IsDST() bool {
+	return time.Time(ms).IsDST()
+}
+
+// IsZero returns true if the MillisecondString is the zero value.
+func (ms MillisecondString) IsZero() bool {
+	return time.Time(ms).IsZero()
+}
+
+// String calls time.Time.String.
+func (ms MillisecondString) String() string {
+	return time.Time(ms).String()
+}
+
+// UTC returns a copy of the MillisecondString with the location set to UTC.
+func (ms MillisecondString) UTC() MillisecondString {
+	return MillisecondString(time.Time(ms).UTC())
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (ms MillisecondString) MarshalJSON() ([]byte, error) {
+	return marshalStringJSON(Millisecond(ms).MarshalJSON())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (ms *MillisecondString) UnmarshalJSON(data []byte) error {
+	return (*Millisecond)(ms).UnmarshalJSON(data)
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (ms MillisecondString) MarshalYAML() (interface{}, error) {
+	return marshalStringYAML(Millisecond(ms).MarshalJSON())
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (ms *MillisecondString) UnmarshalYAML(node *yaml.Node) error {
+	return (*Millisecond)(ms).UnmarshalYAML(node)
+}
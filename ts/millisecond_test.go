@@ -27,13 +27,14 @@ func TestMillisecond_MarshalUnmarshalJSON(t *testing.T) {
 			v := Millisecond(tt.t)
 
 			b, err := json.Marshal(v)
-			require.NoError(t, err)
-
-			assert.Equal(t, tt.millisecond, string(b))
-
 			if millisecondSkipTestCase(t, tt.t) {
+				assert.ErrorIs(t, err, ErrOverflow)
+
 				return
 			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.millisecond, string(b))
 
 			var got Millisecond
 			err = json.Unmarshal(b, &got)
@@ -53,13 +54,14 @@ func TestMillisecond_MarshalUnmarshalYAML(t *testing.T) {
 			v := Millisecond(tt.t)
 
 			b, err := yaml.Marshal(v)
-			require.NoError(t, err)
-
-			assert.Equal(t, tt.millisecond+"\n", string(b))
-
 			if millisecondSkipTestCase(t, tt.t) {
+				assert.ErrorIs(t, err, ErrOverflow)
+
 				return
 			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.millisecond+"\n", string(b))
 
 			var got Millisecond
 			err = yaml.Unmarshal(b, &got)
@@ -79,6 +81,11 @@ func TestMillisecond_MarshalJSON(t *testing.T) {
 			ts := Millisecond(tt.t)
 
 			b, err := json.Marshal(ts)
+			if millisecondSkipTestCase(t, tt.t) {
+				assert.ErrorIs(t, err, ErrOverflow)
+
+				return
+			}
 			require.NoError(t, err)
 
 			assert.Equal(t, tt.millisecond, string(b))
@@ -92,6 +99,11 @@ func TestMillisecond_MarshalYAML(t *testing.T) {
 			ts := Millisecond(tt.t)
 
 			b, err := yaml.Marshal(ts)
+			if millisecondSkipTestCase(t, tt.t) {
+				assert.ErrorIs(t, err, ErrOverflow)
+
+				return
+			}
 			require.NoError(t, err)
 
 			assert.Equal(t, tt.millisecond+"\n", string(b))
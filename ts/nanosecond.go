@@ -1,6 +1,7 @@
 package ts
 
 import (
+	"math"
 	"strconv"
 	"time"
 
@@ -53,9 +54,16 @@ func (ns Nanosecond) UTC() Nanosecond {
 	return Nanosecond(time.Time(ns).UTC())
 }
 
-// MarshalJSON implements the json.Marshaler interface.
+// MarshalJSON implements the json.Marshaler interface. It returns an error
+// wrapping ErrOverflow if the time is outside of the years 1677 to 2262,
+// which cannot be represented as an int64 number of nanoseconds.
 func (ns Nanosecond) MarshalJSON() ([]byte, error) {
-	return []byte(strconv.FormatInt(time.Time(ns).UnixNano(), 10)), nil
+	i, err := marshalUnixNano(time.Time(ns))
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(strconv.FormatInt(i, 10)), nil
 }
 
 // UnmarshalJSON implements the json.Unmarshaler interface.
@@ -65,14 +73,21 @@ func (ns *Nanosecond) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
-	*ns = UnixNano(i)
+	t, err := unixTime(i, time.Nanosecond)
+	if err != nil {
+		return err
+	}
+
+	*ns = Nanosecond(t)
 
 	return nil
 }
 
-// MarshalJSON implements the yaml.Marshaler interface.
+// MarshalJSON implements the yaml.Marshaler interface. It returns an error
+// wrapping ErrOverflow if the time is outside of the years 1677 to 2262,
+// which cannot be represented as an int64 number of nanoseconds.
 func (ns Nanosecond) MarshalYAML() (interface{}, error) {
-	return time.Time(ns).UnixNano(), nil
+	return marshalUnixNano(time.Time(ns))
 }
 
 // UnmarshalYAML implements the yaml.Unmarshaler interface.
@@ -82,7 +97,12 @@ func (ns *Nanosecond) UnmarshalYAML(node *yaml.Node) error {
 		return err
 	}
 
-	*ns = UnixNano(i)
+	t, err := unixTime(i, time.Nanosecond)
+	if err != nil {
+		return err
+	}
+
+	*ns = Nanosecond(t)
 
 	return nil
 }
@@ -90,3 +110,19 @@ func (ns *Nanosecond) UnmarshalYAML(node *yaml.Node) error {
 func unixNano(ts int64) time.Time {
 	return time.Unix(ts/1e9, ts%1e9)
 }
+
+var (
+	minUnixNano = unixNano(math.MinInt64)
+	maxUnixNano = unixNano(math.MaxInt64)
+)
+
+// marshalUnixNano returns t as a number of nanoseconds since the Unix epoch,
+// rather than the wrapped around value time.Time.UnixNano returns for times
+// outside of the range of an int64.
+func marshalUnixNano(t time.Time) (int64, error) {
+	if err := checkUnixRange(t, minUnixNano, maxUnixNano); err != nil {
+		return 0, err
+	}
+
+	return t.UnixNano(), nil
+}
@@ -0,0 +1,77 @@
+package ts
+
+import (
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// NanosecondString is a wrapper around time.Time for marshaling to/from
+// JSON/YAML as nanosecond-based Unix timestamps encoded as strings, which
+// keeps large values intact when decoded by JavaScript and other consumers
+// that parse numbers as float64.
+//
+// It marshals to a JSON/YAML string containing the same integer value as
+// Nanosecond.
+//
+// It unmarshals from a JSON/YAML string or number representing the number of
+// nanoseconds since the Unix time epoch, exactly like Nanosecond.
+type NanosecondString time.Time
+
+// Time returns the time.Time corresponding to the nanosecond string instant
+// ns.
+func (ns NanosecondString) Time() time.Time {
+	return time.Time(ns)
+}
+
+// Local returns the local time corresponding to the nanosecond string
+// instant ns.
+func (ns NanosecondString) Local() NanosecondString {
+	return NanosecondString(time.Time(ns).Local())
+}
+
+// GoString implements the fmt.GoStringer interface.
+func (ns NanosecondString) GoString() string {
+	return time.Time(ns).GoString()
+}
+
+// IsDST reports whether the nanosecond string instant ns occurs within
+// Daylight Saving Time.
+func (ns NanosecondString) IsDST() bool {
+	return time.Time(ns).IsDST()
+}
+
+// IsZero returns true if the NanosecondString is the zero value.
+func (ns NanosecondString) IsZero() bool {
+	return time.Time(ns).IsZero()
+}
+
+// String calls time.Time.String.
+func (ns NanosecondString) String() string {
+	return time.Time(ns).String()
+}
+
+// UTC returns a copy of the NanosecondString with the location set to UTC.
+func (ns NanosecondString) UTC() NanosecondString {
+	return NanosecondString(time.Time(ns).UTC())
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (ns NanosecondString) MarshalJSON() ([]byte, error) {
+	return marshalStringJSON(Nanosecond(ns).MarshalJSON())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (ns *NanosecondString) UnmarshalJSON(data []byte) error {
+	return (*Nanosecond)(ns).UnmarshalJSON(data)
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (ns NanosecondString) MarshalYAML() (interface{}, error) {
+	return marshalStringYAML(Nanosecond(ns).MarshalJSON())
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (ns *NanosecondString) UnmarshalYAML(node *yaml.Node) error {
+	return (*Nanosecond)(ns).UnmarshalYAML(node)
+}
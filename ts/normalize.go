@@ -0,0 +1,249 @@
+package ts
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrExcessPrecision is returned by Normalization.Normalize when Strict is
+// enabled, and the given time has more precision than allowed.
+var ErrExcessPrecision = errors.New("excess time precision")
+
+// Normalization describes how time values are normalized and validated after
+// being unmarshaled, typically by a Normalized value. The zero value leaves
+// times unchanged, apart from stripping any monotonic clock reading.
+type Normalization struct {
+	// Location converts times to the given location when not nil. Use
+	// time.UTC to force all times to UTC.
+	Location *time.Location
+
+	// Precision truncates times to a multiple of the given duration when
+	// greater than zero.
+	Precision time.Duration
+
+	// Round rounds times to the nearest multiple of Precision, instead of
+	// truncating them.
+	Round bool
+
+	// Strict causes times with more precision than Precision to be rejected
+	// with an ErrExcessPrecision error, instead of truncating or rounding
+	// them.
+	Strict bool
+
+	// Min rejects times before it with a *RangeError when not zero.
+	Min time.Time
+
+	// Max rejects times after it with a *RangeError when not zero.
+	Max time.Time
+}
+
+// Normalize returns t normalized according to the rules in n.
+func (n Normalization) Normalize(t time.Time) (time.Time, error) {
+	t = t.Round(0)
+
+	if n.Precision > 0 {
+		var nt time.Time
+		if n.Round {
+			nt = t.Round(n.Precision)
+		} else {
+			nt = t.Truncate(n.Precision)
+		}
+
+		if n.Strict && !nt.Equal(t) {
+			return time.Time{}, fmt.Errorf(
+				"%w: %s is not a multiple of %s",
+				ErrExcessPrecision, t.Format(time.RFC3339Nano), n.Precision,
+			)
+		}
+
+		t = nt
+	}
+
+	if (!n.Min.IsZero() && t.Before(n.Min)) ||
+		(!n.Max.IsZero() && t.After(n.Max)) {
+		return time.Time{}, &RangeError{Time: t, Min: n.Min, Max: n.Max}
+	}
+
+	if n.Location != nil {
+		t = t.In(n.Location)
+	}
+
+	return t, nil
+}
+
+// In returns t converted to n's Location, or t unchanged when Location is nil.
+func (n Normalization) In(t time.Time) time.Time {
+	if n.Location != nil {
+		return t.In(n.Location)
+	}
+
+	return t
+}
+
+// Normalizer is implemented by types which declare the Normalization applied
+// by a Normalized.
+type Normalizer interface {
+	Normalization() Normalization
+}
+
+// Normalized is a wrapper around time.Time for marshaling to/from JSON/YAML
+// and text in the same way as the Timestamp type T, and for use with
+// database/sql, normalizing values with the Normalization declared by N when
+// unmarshaled or scanned. It allows normalization rules
+// to be configured per field:
+//
+//	type postgres struct{}
+//
+//	func (postgres) Normalization() ts.Normalization {
+//		return ts.Normalization{
+//			Location:  time.UTC,
+//			Precision: time.Microsecond,
+//		}
+//	}
+//
+//	type Event struct {
+//		At ts.Normalized[ts.Nanosecond, postgres] `json:"at"`
+//	}
+//
+// When marshaling, values are converted to the Normalization's Location, if
+// any, before being marshaled as T.
+type Normalized[T Timestamp, N Normalizer] time.Time
+
+// Time returns the time.Time corresponding to the instant t.
+func (t Normalized[T, N]) Time() time.Time {
+	return time.Time(t)
+}
+
+// Set sets t to v, normalized with the Normalization declared by N.
+func (t *Normalized[T, N]) Set(v time.Time) error {
+	var n N
+	nt, err := n.Normalization().Normalize(v)
+	if err != nil {
+		return err
+	}
+
+	*t = Normalized[T, N](nt)
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (t Normalized[T, N]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.value())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (t *Normalized[T, N]) UnmarshalJSON(data []byte) error {
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	return t.Set(time.Time(v))
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (t Normalized[T, N]) MarshalYAML() (interface{}, error) {
+	return t.value(), nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (t *Normalized[T, N]) UnmarshalYAML(node *yaml.Node) error {
+	var v T
+	if err := node.Decode(&v); err != nil {
+		return err
+	}
+
+	return t.Set(time.Time(v))
+}
+
+// MarshalText implements the encoding.TextMarshaler interface, producing the
+// same value as MarshalJSON, without any JSON string quotes.
+func (t Normalized[T, N]) MarshalText() ([]byte, error) {
+	return marshalText(t.MarshalJSON())
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface, accepting
+// the same values as UnmarshalJSON does for JSON strings.
+func (t *Normalized[T, N]) UnmarshalText(text []byte) error {
+	return t.UnmarshalJSON(quoteText(text))
+}
+
+// Scan implements the sql.Scanner interface. It accepts time.Time values, as
+// well as string, []byte, int64 and float64 values which are unmarshaled with
+// UnmarshalText. A nil value sets t to the zero time.
+func (t *Normalized[T, N]) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*t = Normalized[T, N]{}
+
+		return nil
+	case time.Time:
+		return t.Set(v)
+	}
+
+	text, err := scanText(src)
+	if err != nil {
+		return err
+	}
+
+	return t.UnmarshalText(text)
+}
+
+// Value implements the driver.Valuer interface, returning t as a time.Time in
+// the location of N's Normalization, if any.
+func (t Normalized[T, N]) Value() (driver.Value, error) {
+	var n N
+
+	return n.Normalization().In(time.Time(t)), nil
+}
+
+// value returns t as T, in the location of N's Normalization, if any.
+func (t Normalized[T, N]) value() T {
+	var n N
+
+	return T(n.Normalization().In(time.Time(t)))
+}
+
+// marshalText returns the JSON value b, as returned by a MarshalJSON method,
+// as text by removing any string quotes.
+func marshalText(b []byte, err error) ([]byte, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	if s, err := strconv.Unquote(string(b)); err == nil {
+		return []byte(s), nil
+	}
+
+	return b, nil
+}
+
+// quoteText returns text as a JSON string, suitable for passing to an
+// UnmarshalJSON method which accepts string values.
+func quoteText(text []byte) []byte {
+	return []byte(strconv.Quote(string(text)))
+}
+
+// scanText returns the text form of src, as passed to a sql.Scanner's Scan
+// method. It supports string, []byte, int64 and float64 values.
+func scanText(src interface{}) ([]byte, error) {
+	switch v := src.(type) {
+	case string:
+		return []byte(v), nil
+	case []byte:
+		return v, nil
+	case int64:
+		return strconv.AppendInt(nil, v, 10), nil
+	case float64:
+		return strconv.AppendFloat(nil, v, 'f', -1, 64), nil
+	default:
+		return nil, fmt.Errorf("cannot scan %T into timestamp", src)
+	}
+}
@@ -0,0 +1,249 @@
+package ts
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestNormalization_Normalize(t *testing.T) {
+	utc8 := time.FixedZone("UTC+8", 8*60*60)
+	tm := time.Date(2022, 10, 29, 22, 40, 34, 934349503, utc8)
+
+	tests := []struct {
+		name    string
+		n       Normalization
+		t       time.Time
+		want    time.Time
+		wantErr error
+	}{
+		{
+			name: "zero value",
+			n:    Normalization{},
+			t:    tm,
+			want: tm,
+		},
+		{
+			name: "location",
+			n:    Normalization{Location: time.UTC},
+			t:    tm,
+			want: time.Date(2022, 10, 29, 14, 40, 34, 934349503, time.UTC),
+		},
+		{
+			name: "truncate to microsecond",
+			n:    Normalization{Precision: time.Microsecond},
+			t:    tm,
+			want: time.Date(2022, 10, 29, 22, 40, 34, 934349000, utc8),
+		},
+		{
+			name: "round to microsecond",
+			n:    Normalization{Precision: time.Microsecond, Round: true},
+			t:    tm,
+			want: time.Date(2022, 10, 29, 22, 40, 34, 934350000, utc8),
+		},
+		{
+			name: "UTC with microsecond precision",
+			n: Normalization{
+				Location:  time.UTC,
+				Precision: time.Microsecond,
+			},
+			t:    tm,
+			want: time.Date(2022, 10, 29, 14, 40, 34, 934349000, time.UTC),
+		},
+		{
+			name: "strict with allowed precision",
+			n:    Normalization{Precision: time.Millisecond, Strict: true},
+			t:    tm.Truncate(time.Millisecond),
+			want: time.Date(2022, 10, 29, 22, 40, 34, 934000000, utc8),
+		},
+		{
+			name:    "strict with excess precision",
+			n:       Normalization{Precision: time.Millisecond, Strict: true},
+			t:       tm,
+			wantErr: ErrExcessPrecision,
+		},
+		{
+			name: "within min and max",
+			n: Normalization{
+				Min: tm.Add(-time.Hour),
+				Max: tm.Add(time.Hour),
+			},
+			t:    tm,
+			want: tm,
+		},
+		{
+			name:    "before min",
+			n:       Normalization{Min: tm.Add(time.Nanosecond)},
+			t:       tm,
+			wantErr: &RangeError{},
+		},
+		{
+			name:    "after max",
+			n:       Normalization{Max: tm.Add(-time.Nanosecond)},
+			t:       tm,
+			wantErr: &RangeError{},
+		},
+		{
+			name: "strips monotonic clock reading",
+			n:    Normalization{},
+			t:    time.Now(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.n.Normalize(tt.t)
+
+			var rangeErr *RangeError
+			if errors.As(tt.wantErr, &rangeErr) {
+				assert.ErrorAs(t, err, &rangeErr)
+
+				return
+			} else if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+			require.NoError(t, err)
+
+			want := tt.want
+			if want.IsZero() {
+				want = tt.t.Round(0)
+			}
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+type microsecondUTC struct{}
+
+func (microsecondUTC) Normalization() Normalization {
+	return Normalization{Location: time.UTC, Precision: time.Microsecond}
+}
+
+type strictMicrosecond struct{}
+
+func (strictMicrosecond) Normalization() Normalization {
+	return Normalization{Precision: time.Microsecond, Strict: true}
+}
+
+func TestNormalized(t *testing.T) {
+	want := time.Date(2022, 10, 29, 14, 40, 34, 934349000, time.UTC)
+
+	var got Normalized[Nanosecond, microsecondUTC]
+	err := json.Unmarshal([]byte("1667054434934349003"), &got)
+	require.NoError(t, err)
+	assert.Equal(t, want, got.Time())
+
+	b, err := json.Marshal(got)
+	require.NoError(t, err)
+	assert.Equal(t, "1667054434934349000", string(b))
+
+	got = Normalized[Nanosecond, microsecondUTC]{}
+	err = yaml.Unmarshal([]byte("1667054434934349003"), &got)
+	require.NoError(t, err)
+	assert.Equal(t, want, got.Time())
+
+	b, err = yaml.Marshal(got)
+	require.NoError(t, err)
+	assert.Equal(t, "1667054434934349000\n", string(b))
+
+	var strict Normalized[Nanosecond, strictMicrosecond]
+	err = json.Unmarshal([]byte("1667054434934349003"), &strict)
+	assert.ErrorIs(t, err, ErrExcessPrecision)
+
+	err = yaml.Unmarshal([]byte("1667054434934349003"), &strict)
+	assert.ErrorIs(t, err, ErrExcessPrecision)
+
+	err = json.Unmarshal([]byte(`"nope"`), &strict)
+	assert.Error(t, err)
+}
+
+func TestNormalized_MarshalLocation(t *testing.T) {
+	utc8 := time.FixedZone("UTC+8", 8*60*60)
+	tm := time.Date(2022, 10, 29, 22, 40, 34, 0, utc8)
+
+	b, err := json.Marshal(Normalized[time.Time, microsecondUTC](tm))
+	require.NoError(t, err)
+	assert.Equal(t, `"2022-10-29T14:40:34Z"`, string(b))
+
+	b, err = json.Marshal(Normalized[time.Time, strictMicrosecond](tm))
+	require.NoError(t, err)
+	assert.Equal(t, `"2022-10-29T22:40:34+08:00"`, string(b))
+}
+
+func TestNormalized_Text(t *testing.T) {
+	want := time.Date(2022, 10, 29, 14, 40, 34, 934349000, time.UTC)
+
+	var got Normalized[Nanosecond, microsecondUTC]
+	err := got.UnmarshalText([]byte("1667054434934349003"))
+	require.NoError(t, err)
+	assert.Equal(t, want, got.Time())
+
+	b, err := got.MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, "1667054434934349000", string(b))
+
+	var str Normalized[NanosecondString, microsecondUTC]
+	err = str.UnmarshalText([]byte("1667054434934349003"))
+	require.NoError(t, err)
+
+	b, err = str.MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, "1667054434934349000", string(b))
+
+	var strict Normalized[Nanosecond, strictMicrosecond]
+	err = strict.UnmarshalText([]byte("1667054434934349003"))
+	assert.ErrorIs(t, err, ErrExcessPrecision)
+}
+
+func TestNormalized_SQL(t *testing.T) {
+	utc8 := time.FixedZone("UTC+8", 8*60*60)
+	want := time.Date(2022, 10, 29, 14, 40, 34, 934349000, time.UTC)
+
+	tests := []struct {
+		name    string
+		src     interface{}
+		want    time.Time
+		wantErr string
+	}{
+		{
+			name: "time",
+			src:  time.Date(2022, 10, 29, 22, 40, 34, 934349003, utc8),
+			want: want,
+		},
+		{name: "string", src: "1667054434934349003", want: want},
+		{name: "bytes", src: []byte("1667054434934349003"), want: want},
+		{name: "int64", src: int64(1667054434934349003), want: want},
+		{
+			name: "float64",
+			src:  float64(1667054434000000000),
+			want: want.Truncate(time.Second),
+		},
+		{name: "nil", src: nil},
+		{name: "unsupported", src: true, wantErr: "cannot scan bool"},
+		{name: "invalid", src: "nope", wantErr: "invalid numeric"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Normalized[Nanosecond, microsecondUTC](time.Now())
+			err := got.Scan(tt.src)
+
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.Time())
+
+			v, err := got.Value()
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, v)
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package ts
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ntpUnixOffset is the number of seconds between the NTP prime epoch of
+// January 1, 1900 UTC and the Unix epoch.
+const ntpUnixOffset = 2208988800
+
+var (
+	ntpEpochOrigin = time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// ntpMin and ntpMax are the bounds of the range which can be encoded as a
+	// 64-bit NTP timestamp according to the era rules of RFC 4330.
+	ntpMin = time.Unix(1<<31-ntpUnixOffset, 0).UTC()
+	ntpMax = time.Unix(1<<32+1<<31-ntpUnixOffset, -1).UTC()
+)
+
+var ntpEpoch = Epoch{
+	Origin: ntpEpochOrigin,
+	Unit:   time.Second,
+	Digits: 9,
+}
+
+// NTPTime is a wrapper around time.Time for marshaling to/from the 64-bit NTP
+// timestamp format, as used by NTP and SNTP packets.
+//
+// It marshals to binary as a big-endian 32-bit number of seconds since
+// January 1, 1900 UTC, followed by a 32-bit binary fraction of a second. As
+// the seconds field wraps around on February 7, 2036, the era is determined
+// by the most significant bit of the seconds field as described in RFC 4330:
+// when set, the time is between 1968 and 2036, and when unset, the time is
+// between 2036 and 2104. Times outside of this range cannot be marshaled.
+// Fractions are rounded to the nearest nanosecond and vice versa.
+//
+// It marshals to a JSON/YAML number representing the number of seconds since
+// January 1, 1900 UTC, with nanosecond precision and without any era wrap
+// around.
+//
+// It unmarshals from a JSON/YAML number, or numeric string, representing the
+// number of seconds since January 1, 1900 UTC. Decimal values are parsed
+// exactly, retaining precision down to the nanosecond.
+type NTPTime time.Time
+
+// Time returns the time.Time corresponding to the NTP instant n.
+func (n NTPTime) Time() time.Time {
+	return time.Time(n)
+}
+
+// Local returns the local time corresponding to the NTP instant n.
+func (n NTPTime) Local() NTPTime {
+	return NTPTime(time.Time(n).Local())
+}
+
+// GoString implements the fmt.GoStringer interface.
+func (n NTPTime) GoString() string {
+	return time.Time(n).GoString()
+}
+
+// IsDST reports whether the NTP instant n occurs within Daylight Saving Time.
+func (n NTPTime) IsDST() bool {
+	return time.Time(n).IsDST()
+}
+
+// IsZero returns true if the NTPTime is the zero value.
+func (n NTPTime) IsZero() bool {
+	return time.Time(n).IsZero()
+}
+
+// String calls time.Time.String.
+func (n NTPTime) String() string {
+	return time.Time(n).String()
+}
+
+// UTC returns a copy of the NTPTime with the location set to UTC.
+func (n NTPTime) UTC() NTPTime {
+	return NTPTime(time.Time(n).UTC())
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (n NTPTime) MarshalBinary() ([]byte, error) {
+	t := time.Time(n)
+	if t.Before(ntpMin) || t.After(ntpMax) {
+		return nil, &RangeError{Time: t, Min: ntpMin, Max: ntpMax}
+	}
+
+	sec := t.Unix() + ntpUnixOffset
+	frac := (uint64(t.Nanosecond())<<32 + uint64(time.Second)/2) /
+		uint64(time.Second)
+
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint32(b[0:4], uint32(sec))
+	binary.BigEndian.PutUint32(b[4:8], uint32(frac))
+
+	return b, nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (n *NTPTime) UnmarshalBinary(data []byte) error {
+	if len(data) != 8 {
+		return fmt.Errorf("invalid NTP timestamp length: %d", len(data))
+	}
+
+	sec := int64(binary.BigEndian.Uint32(data[0:4]))
+	frac := uint64(binary.BigEndian.Uint32(data[4:8]))
+
+	// Times with the most significant bit unset belong to the era starting
+	// on February 7, 2036.
+	if sec&(1<<31) == 0 {
+		sec += 1 << 32
+	}
+
+	nsec := int64((frac*uint64(time.Second) + 1<<31) >> 32)
+
+	*n = NTPTime(time.Unix(sec-ntpUnixOffset, nsec))
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (n NTPTime) MarshalJSON() ([]byte, error) {
+	return ntpEpoch.marshalJSON(time.Time(n))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (n *NTPTime) UnmarshalJSON(data []byte) error {
+	t, err := ntpEpoch.unmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+
+	*n = NTPTime(t)
+
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (n NTPTime) MarshalYAML() (interface{}, error) {
+	return ntpEpoch.marshalYAML(time.Time(n))
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (n *NTPTime) UnmarshalYAML(node *yaml.Node) error {
+	t, err := ntpEpoch.unmarshalYAML(node)
+	if err != nil {
+		return err
+	}
+
+	*n = NTPTime(t)
+
+	return nil
+}
@@ -0,0 +1,204 @@
+package ts
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestNTPTime_MarshalBinary(t *testing.T) {
+	tests := []struct {
+		name    string
+		t       time.Time
+		want    string
+		wantErr string
+	}{
+		{
+			name: "whole second",
+			t:    time.Date(2023, 10, 11, 16, 0, 0, 0, time.UTC),
+			want: "e8d1450000000000",
+		},
+		{
+			name: "half second",
+			t:    time.Date(2023, 10, 11, 16, 0, 0, 500000000, time.UTC),
+			want: "e8d1450080000000",
+		},
+		{
+			name: "nanoseconds",
+			t:    time.Date(2023, 10, 11, 16, 0, 0, 123456789, time.UTC),
+			want: "e8d145001f9add37",
+		},
+		{
+			name: "one nanosecond",
+			t:    time.Date(2023, 10, 11, 16, 0, 0, 1, time.UTC),
+			want: "e8d1450000000004",
+		},
+		{
+			name: "non-UTC location",
+			t: time.Date(
+				2023, 10, 12, 0, 0, 0, 0, time.FixedZone("UTC+8", 8*60*60),
+			),
+			want: "e8d1450000000000",
+		},
+		{
+			name: "start of era 0 range",
+			t:    time.Date(1968, 1, 20, 3, 14, 8, 0, time.UTC),
+			want: "8000000000000000",
+		},
+		{
+			name: "last second of era 0",
+			t:    time.Date(2036, 2, 7, 6, 28, 15, 0, time.UTC),
+			want: "ffffffff00000000",
+		},
+		{
+			name: "start of era 1",
+			t:    time.Date(2036, 2, 7, 6, 28, 16, 0, time.UTC),
+			want: "0000000000000000",
+		},
+		{
+			name: "era 1",
+			t:    time.Date(2050, 1, 1, 0, 0, 0, 0, time.UTC),
+			want: "1a24f48000000000",
+		},
+		{
+			name: "before range",
+			t:    time.Date(1960, 1, 1, 0, 0, 0, 0, time.UTC),
+			wantErr: "timestamp 1960-01-01T00:00:00Z is before " +
+				"1968-01-20T03:14:08Z",
+		},
+		{
+			name: "after range",
+			t:    time.Date(2110, 1, 1, 0, 0, 0, 0, time.UTC),
+			wantErr: "timestamp 2110-01-01T00:00:00Z is after " +
+				"2104-02-26T09:42:23.999999999Z",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := NTPTime(tt.t).MarshalBinary()
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+
+				var rangeErr *RangeError
+				assert.ErrorAs(t, err, &rangeErr)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, hex.EncodeToString(b))
+
+			var got NTPTime
+			err = got.UnmarshalBinary(b)
+			require.NoError(t, err)
+			assert.True(t, tt.t.Equal(got.Time()), got.Time().String())
+		})
+	}
+}
+
+func TestNTPTime_UnmarshalBinary(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    time.Time
+		wantErr string
+	}{
+		{
+			name: "era 0",
+			data: "e8d1450080000000",
+			want: time.Date(2023, 10, 11, 16, 0, 0, 500000000, time.UTC),
+		},
+		{
+			name: "era 1",
+			data: "0000000100000000",
+			want: time.Date(2036, 2, 7, 6, 28, 17, 0, time.UTC),
+		},
+		{
+			name: "smallest fraction rounds down",
+			data: "e8d1450000000001",
+			want: time.Date(2023, 10, 11, 16, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "fraction rounds to nearest nanosecond",
+			data: "e8d1450000000003",
+			want: time.Date(2023, 10, 11, 16, 0, 0, 1, time.UTC),
+		},
+		{
+			name: "largest fraction rounds up to next second",
+			data: "e8d14500ffffffff",
+			want: time.Date(2023, 10, 11, 16, 0, 1, 0, time.UTC),
+		},
+		{
+			name:    "too short",
+			data:    "e8d14500",
+			wantErr: "invalid NTP timestamp length: 4",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := hex.DecodeString(tt.data)
+			require.NoError(t, err)
+
+			var got NTPTime
+			err = got.UnmarshalBinary(data)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(got.Time()), got.Time().String())
+		})
+	}
+}
+
+func TestNTPTime_MarshalUnmarshalJSONYAML(t *testing.T) {
+	tests := []struct {
+		name string
+		t    time.Time
+		want string
+	}{
+		{
+			name: "era 0",
+			t:    time.Date(2023, 10, 11, 16, 0, 0, 123456789, time.UTC),
+			want: "3906028800.123456789",
+		},
+		{
+			name: "era 1",
+			t:    time.Date(2036, 2, 7, 6, 28, 16, 500000000, time.UTC),
+			want: "4294967296.500000000",
+		},
+		{
+			name: "prime epoch",
+			t:    time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC),
+			want: "0.000000000",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := json.Marshal(NTPTime(tt.t))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(b))
+
+			var jn NTPTime
+			err = json.Unmarshal(b, &jn)
+			require.NoError(t, err)
+			assert.True(t, tt.t.Equal(jn.Time()))
+
+			b, err = yaml.Marshal(NTPTime(tt.t))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want+"\n", string(b))
+
+			var yn NTPTime
+			err = yaml.Unmarshal(b, &yn)
+			require.NoError(t, err)
+			assert.True(t, tt.t.Equal(yn.Time()))
+		})
+	}
+}
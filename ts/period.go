@@ -0,0 +1,183 @@
+package ts
+
+import (
+	"fmt"
+	"time"
+)
+
+// Period is a calendar period which Timestamps can be truncated to with
+// StartOf and EndOf.
+type Period int
+
+// Calendar periods supported by StartOf and EndOf.
+const (
+	PeriodMinute Period = iota
+	PeriodHour
+	PeriodDay
+	PeriodWeek
+	PeriodMonth
+	PeriodQuarter
+	PeriodYear
+	PeriodFiscalQuarter
+	PeriodFiscalYear
+)
+
+// PeriodOption configures how StartOf and EndOf determine calendar periods.
+type PeriodOption func(*periodOptions)
+
+// WithWeekStart sets the first day of the week used by PeriodWeek. Defaults
+// to time.Monday.
+func WithWeekStart(d time.Weekday) PeriodOption {
+	return func(o *periodOptions) {
+		o.weekStart = d
+	}
+}
+
+// WithFiscalYearStart sets the first month of the fiscal year used by
+// PeriodFiscalQuarter and PeriodFiscalYear. Defaults to time.January.
+func WithFiscalYearStart(m time.Month) PeriodOption {
+	return func(o *periodOptions) {
+		o.fiscalYearStart = m
+	}
+}
+
+type periodOptions struct {
+	weekStart       time.Weekday
+	fiscalYearStart time.Month
+}
+
+// newPeriodOptions returns the periodOptions configured by opts. It panics
+// if opts configure an invalid week start or fiscal year start.
+func newPeriodOptions(opts []PeriodOption) periodOptions {
+	o := periodOptions{
+		weekStart:       time.Monday,
+		fiscalYearStart: time.January,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.weekStart < time.Sunday || o.weekStart > time.Saturday {
+		panic(fmt.Sprintf("ts: invalid week start %d", int(o.weekStart)))
+	}
+	if m := o.fiscalYearStart; m < time.January || m > time.December {
+		panic(fmt.Sprintf("ts: invalid fiscal year start %d", int(m)))
+	}
+
+	return o
+}
+
+// String returns the name of the period.
+func (p Period) String() string {
+	switch p {
+	case PeriodMinute:
+		return "minute"
+	case PeriodHour:
+		return "hour"
+	case PeriodDay:
+		return "day"
+	case PeriodWeek:
+		return "week"
+	case PeriodMonth:
+		return "month"
+	case PeriodQuarter:
+		return "quarter"
+	case PeriodYear:
+		return "year"
+	case PeriodFiscalQuarter:
+		return "fiscal quarter"
+	case PeriodFiscalYear:
+		return "fiscal year"
+	default:
+		return fmt.Sprintf("Period(%d)", int(p))
+	}
+}
+
+// StartOf returns the first instant of the calendar period p containing t.
+//
+// As opposed to Truncate, periods are based on the wall clock in t's location,
+// so PeriodDay returns local midnight rather than UTC midnight. Days which do
+// not start at midnight due to a daylight saving time transition start at the
+// first instant of the day which exists.
+//
+// It panics if p is not a valid Period, or opts configure an invalid week
+// start or fiscal year start.
+func StartOf[T Timestamp](t T, p Period, opts ...PeriodOption) T {
+	return T(periodStart(time.Time(t), p, 0, opts))
+}
+
+// EndOf returns the last instant of the calendar period p containing t, one
+// nanosecond before the start of the following period. It panics under the
+// same conditions as StartOf.
+func EndOf[T Timestamp](t T, p Period, opts ...PeriodOption) T {
+	return T(periodStart(time.Time(t), p, 1, opts).Add(-time.Nanosecond))
+}
+
+// periodStart returns the start of the n-th period of type p following the
+// one containing t.
+func periodStart(
+	t time.Time,
+	p Period,
+	n int,
+	opts []PeriodOption,
+) time.Time {
+	o := newPeriodOptions(opts)
+
+	t = t.Round(0)
+	y, m, d := t.Date()
+	loc := t.Location()
+
+	switch p {
+	case PeriodMinute:
+		t = t.Add(-time.Duration(t.Second())*time.Second -
+			time.Duration(t.Nanosecond()))
+
+		return t.Add(time.Duration(n) * time.Minute)
+	case PeriodHour:
+		t = t.Add(-time.Duration(t.Minute())*time.Minute -
+			time.Duration(t.Second())*time.Second -
+			time.Duration(t.Nanosecond()))
+
+		return t.Add(time.Duration(n) * time.Hour)
+	case PeriodDay:
+		return dayStart(y, m, d+n, loc)
+	case PeriodWeek:
+		offset := (int(t.Weekday()) - int(o.weekStart) + 7) % 7
+
+		return dayStart(y, m, d-offset+7*n, loc)
+	case PeriodMonth:
+		return dayStart(y, m+time.Month(n), 1, loc)
+	case PeriodQuarter:
+		m -= (m - 1) % 3
+
+		return dayStart(y, m+time.Month(3*n), 1, loc)
+	case PeriodYear:
+		return dayStart(y+n, time.January, 1, loc)
+	case PeriodFiscalQuarter:
+		m -= (m - o.fiscalYearStart + 12) % 3
+
+		return dayStart(y, m+time.Month(3*n), 1, loc)
+	case PeriodFiscalYear:
+		m -= (m - o.fiscalYearStart + 12) % 12
+
+		return dayStart(y+n, m, 1, loc)
+	default:
+		panic("ts: invalid period " + p.String())
+	}
+}
+
+// dayStart returns the first instant of the given date in loc, which is after
+// midnight when midnight is skipped by a daylight saving time transition.
+func dayStart(y int, m time.Month, d int, loc *time.Location) time.Time {
+	t := time.Date(y, m, d, 0, 0, 0, 0, loc)
+
+	// Skipped midnights may resolve to a time on the previous day.
+	_, _, wd := time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Date()
+	if t.Day() != wd {
+		h, min, sec := t.Clock()
+		t = t.Add(24*time.Hour - time.Duration(h)*time.Hour -
+			time.Duration(min)*time.Minute - time.Duration(sec)*time.Second)
+	}
+
+	return t
+}
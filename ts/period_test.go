@@ -0,0 +1,190 @@
+package ts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartOfEndOf(t *testing.T) {
+	nyc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	sao, err := time.LoadLocation("America/Sao_Paulo")
+	require.NoError(t, err)
+
+	// Wednesday.
+	tm := time.Date(2023, 8, 16, 14, 35, 42, 123456789, nyc)
+
+	tests := []struct {
+		name      string
+		t         time.Time
+		p         Period
+		weekStart time.Weekday
+		fyStart   time.Month
+		wantStart time.Time
+		wantEnd   time.Time
+	}{
+		{
+			name:      "minute",
+			t:         tm,
+			p:         PeriodMinute,
+			wantStart: time.Date(2023, 8, 16, 14, 35, 0, 0, nyc),
+			wantEnd:   time.Date(2023, 8, 16, 14, 35, 59, 999999999, nyc),
+		},
+		{
+			name:      "hour",
+			t:         tm,
+			p:         PeriodHour,
+			wantStart: time.Date(2023, 8, 16, 14, 0, 0, 0, nyc),
+			wantEnd:   time.Date(2023, 8, 16, 14, 59, 59, 999999999, nyc),
+		},
+		{
+			name:      "day in local time",
+			t:         tm,
+			p:         PeriodDay,
+			wantStart: time.Date(2023, 8, 16, 0, 0, 0, 0, nyc),
+			wantEnd:   time.Date(2023, 8, 16, 23, 59, 59, 999999999, nyc),
+		},
+		{
+			name:      "week starting Monday",
+			t:         tm,
+			p:         PeriodWeek,
+			weekStart: time.Monday,
+			wantStart: time.Date(2023, 8, 14, 0, 0, 0, 0, nyc),
+			wantEnd:   time.Date(2023, 8, 20, 23, 59, 59, 999999999, nyc),
+		},
+		{
+			name:      "week starting Sunday",
+			t:         tm,
+			p:         PeriodWeek,
+			weekStart: time.Sunday,
+			wantStart: time.Date(2023, 8, 13, 0, 0, 0, 0, nyc),
+			wantEnd:   time.Date(2023, 8, 19, 23, 59, 59, 999999999, nyc),
+		},
+		{
+			name:      "week starting on same weekday",
+			t:         tm,
+			p:         PeriodWeek,
+			weekStart: time.Wednesday,
+			wantStart: time.Date(2023, 8, 16, 0, 0, 0, 0, nyc),
+			wantEnd:   time.Date(2023, 8, 22, 23, 59, 59, 999999999, nyc),
+		},
+		{
+			name:      "month",
+			t:         tm,
+			p:         PeriodMonth,
+			wantStart: time.Date(2023, 8, 1, 0, 0, 0, 0, nyc),
+			wantEnd:   time.Date(2023, 8, 31, 23, 59, 59, 999999999, nyc),
+		},
+		{
+			name:      "february in leap year",
+			t:         time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC),
+			p:         PeriodMonth,
+			wantStart: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2024, 2, 29, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:      "quarter",
+			t:         tm,
+			p:         PeriodQuarter,
+			wantStart: time.Date(2023, 7, 1, 0, 0, 0, 0, nyc),
+			wantEnd:   time.Date(2023, 9, 30, 23, 59, 59, 999999999, nyc),
+		},
+		{
+			name:      "year",
+			t:         tm,
+			p:         PeriodYear,
+			wantStart: time.Date(2023, 1, 1, 0, 0, 0, 0, nyc),
+			wantEnd:   time.Date(2023, 12, 31, 23, 59, 59, 999999999, nyc),
+		},
+		{
+			name:      "fiscal year starting April",
+			t:         time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC),
+			p:         PeriodFiscalYear,
+			fyStart:   time.April,
+			wantStart: time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2023, 3, 31, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:      "fiscal year starting October",
+			t:         tm,
+			p:         PeriodFiscalYear,
+			fyStart:   time.October,
+			wantStart: time.Date(2022, 10, 1, 0, 0, 0, 0, nyc),
+			wantEnd:   time.Date(2023, 9, 30, 23, 59, 59, 999999999, nyc),
+		},
+		{
+			name:      "fiscal quarter starting February",
+			t:         time.Date(2023, 1, 15, 0, 0, 0, 0, time.UTC),
+			p:         PeriodFiscalQuarter,
+			fyStart:   time.February,
+			wantStart: time.Date(2022, 11, 1, 0, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2023, 1, 31, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:      "day with DST start",
+			t:         time.Date(2023, 3, 12, 12, 0, 0, 0, nyc),
+			p:         PeriodDay,
+			wantStart: time.Date(2023, 3, 12, 0, 0, 0, 0, nyc),
+			wantEnd:   time.Date(2023, 3, 12, 23, 59, 59, 999999999, nyc),
+		},
+		{
+			name:      "hour repeated by DST end",
+			t:         time.Date(2023, 11, 5, 5, 30, 0, 0, time.UTC).In(nyc),
+			p:         PeriodHour,
+			wantStart: time.Date(2023, 11, 5, 5, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2023, 11, 5, 5, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:      "day starting at 01:00 due to DST",
+			t:         time.Date(2018, 11, 4, 12, 0, 0, 0, sao),
+			p:         PeriodDay,
+			wantStart: time.Date(2018, 11, 4, 1, 0, 0, 0, sao),
+			wantEnd:   time.Date(2018, 11, 4, 23, 59, 59, 999999999, sao),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts []PeriodOption
+			if tt.p == PeriodWeek {
+				opts = append(opts, WithWeekStart(tt.weekStart))
+			}
+			if tt.fyStart != 0 {
+				opts = append(opts, WithFiscalYearStart(tt.fyStart))
+			}
+
+			start := StartOf(Millisecond(tt.t), tt.p, opts...)
+			end := EndOf(Millisecond(tt.t), tt.p, opts...)
+
+			assert.IsType(t, Millisecond{}, start)
+			assert.True(t,
+				tt.wantStart.Equal(start.Time()), start.Time().String(),
+			)
+			assert.True(t, tt.wantEnd.Equal(end.Time()), end.Time().String())
+			assert.Equal(t, tt.t.Location(), start.Time().Location())
+		})
+	}
+}
+
+func TestStartOfEndOf_InvalidPeriod(t *testing.T) {
+	assert.PanicsWithValue(t, "ts: invalid period Period(42)", func() {
+		StartOf(time.Now(), Period(42))
+	})
+	assert.PanicsWithValue(t, "ts: invalid period Period(-1)", func() {
+		EndOf(time.Now(), Period(-1))
+	})
+	assert.PanicsWithValue(t, "ts: invalid week start 7", func() {
+		StartOf(time.Now(), PeriodWeek, WithWeekStart(7))
+	})
+	assert.PanicsWithValue(t, "ts: invalid fiscal year start 0", func() {
+		EndOf(time.Now(), PeriodFiscalYear, WithFiscalYearStart(0))
+	})
+}
+
+func TestPeriod_String(t *testing.T) {
+	assert.Equal(t, "day", PeriodDay.String())
+	assert.Equal(t, "fiscal quarter", PeriodFiscalQuarter.String())
+	assert.Equal(t, "Period(-1)", Period(-1).String())
+}
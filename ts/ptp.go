@@ -0,0 +1,145 @@
+package ts
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ptpMaxSeconds is the largest value of the 48-bit seconds field of a PTP
+// timestamp.
+const ptpMaxSeconds = 1<<48 - 1
+
+var ptpEpoch = Epoch{
+	Origin: time.Unix(0, 0).UTC(),
+	Unit:   time.Second,
+	Digits: 9,
+}
+
+// PTPTime is a wrapper around time.Time for marshaling to/from the 80-bit
+// IEEE 1588 Precision Time Protocol timestamp format.
+//
+// It marshals to binary as a big-endian 48-bit number of seconds since the
+// PTP epoch of January 1, 1970 TAI, followed by a 32-bit number of
+// nanoseconds. PTP time follows TAI, and is converted from UTC using
+// LeapSeconds.
+//
+// It marshals to a JSON/YAML number representing the number of seconds since
+// the PTP epoch, with nanosecond precision.
+//
+// It unmarshals from a JSON/YAML number, or numeric string, representing the
+// number of seconds since the PTP epoch. Decimal values are parsed exactly,
+// retaining precision down to the nanosecond.
+type PTPTime time.Time
+
+// Time returns the time.Time corresponding to the PTP instant p.
+func (p PTPTime) Time() time.Time {
+	return time.Time(p)
+}
+
+// Local returns the local time corresponding to the PTP instant p.
+func (p PTPTime) Local() PTPTime {
+	return PTPTime(time.Time(p).Local())
+}
+
+// GoString implements the fmt.GoStringer interface.
+func (p PTPTime) GoString() string {
+	return time.Time(p).GoString()
+}
+
+// IsDST reports whether the PTP instant p occurs within Daylight Saving Time.
+func (p PTPTime) IsDST() bool {
+	return time.Time(p).IsDST()
+}
+
+// IsZero returns true if the PTPTime is the zero value.
+func (p PTPTime) IsZero() bool {
+	return time.Time(p).IsZero()
+}
+
+// String calls time.Time.String.
+func (p PTPTime) String() string {
+	return time.Time(p).String()
+}
+
+// UTC returns a copy of the PTPTime with the location set to UTC.
+func (p PTPTime) UTC() PTPTime {
+	return PTPTime(time.Time(p).UTC())
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (p PTPTime) MarshalBinary() ([]byte, error) {
+	t := time.Time(p)
+	tai := utcToTAI(t)
+	sec := tai.Unix()
+	if sec < 0 || sec > ptpMaxSeconds {
+		return nil, &RangeError{
+			Time: t,
+			Min:  taiToUTC(time.Unix(0, 0)).UTC(),
+			Max: taiToUTC(
+				time.Unix(ptpMaxSeconds, int64(time.Second)-1),
+			).UTC(),
+		}
+	}
+
+	b := make([]byte, 10)
+	binary.BigEndian.PutUint16(b[0:2], uint16(sec>>32))
+	binary.BigEndian.PutUint32(b[2:6], uint32(sec))
+	binary.BigEndian.PutUint32(b[6:10], uint32(tai.Nanosecond()))
+
+	return b, nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (p *PTPTime) UnmarshalBinary(data []byte) error {
+	if len(data) != 10 {
+		return fmt.Errorf("invalid PTP timestamp length: %d", len(data))
+	}
+
+	sec := int64(binary.BigEndian.Uint16(data[0:2]))<<32 |
+		int64(binary.BigEndian.Uint32(data[2:6]))
+	nsec := int64(binary.BigEndian.Uint32(data[6:10]))
+	if nsec >= int64(time.Second) {
+		return fmt.Errorf("invalid PTP timestamp nanoseconds: %d", nsec)
+	}
+
+	*p = PTPTime(taiToUTC(time.Unix(sec, nsec)))
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (p PTPTime) MarshalJSON() ([]byte, error) {
+	return ptpEpoch.marshalJSON(utcToTAI(time.Time(p)))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (p *PTPTime) UnmarshalJSON(data []byte) error {
+	t, err := ptpEpoch.unmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+
+	*p = PTPTime(taiToUTC(t))
+
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (p PTPTime) MarshalYAML() (interface{}, error) {
+	return ptpEpoch.marshalYAML(utcToTAI(time.Time(p)))
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (p *PTPTime) UnmarshalYAML(node *yaml.Node) error {
+	t, err := ptpEpoch.unmarshalYAML(node)
+	if err != nil {
+		return err
+	}
+
+	*p = PTPTime(taiToUTC(t))
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package ts
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestPTPTime_MarshalBinary(t *testing.T) {
+	tests := []struct {
+		name    string
+		t       time.Time
+		want    string
+		wantErr string
+	}{
+		{
+			name: "nanoseconds",
+			t:    time.Date(2023, 10, 11, 16, 0, 0, 123456789, time.UTC),
+			want: "00006526c6a5075bcd15",
+		},
+		{
+			name: "before 2017 leap second",
+			t:    time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC),
+			want: "00004b3d3b2200000000",
+		},
+		{
+			name: "PTP epoch",
+			t:    time.Date(1969, 12, 31, 23, 59, 50, 0, time.UTC),
+			want: "00000000000000000000",
+		},
+		{
+			name: "before PTP epoch",
+			t:    time.Date(1969, 12, 31, 23, 59, 49, 0, time.UTC),
+			wantErr: "timestamp 1969-12-31T23:59:49Z is before " +
+				"1969-12-31T23:59:50Z",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := PTPTime(tt.t).MarshalBinary()
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, hex.EncodeToString(b))
+
+			var got PTPTime
+			err = got.UnmarshalBinary(b)
+			require.NoError(t, err)
+			assert.True(t, tt.t.Equal(got.Time()), got.Time().String())
+		})
+	}
+}
+
+func TestPTPTime_UnmarshalBinary(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    time.Time
+		wantErr string
+	}{
+		{
+			name: "seconds and nanoseconds",
+			data: "00006526c6a51dcd6500",
+			want: time.Date(2023, 10, 11, 16, 0, 0, 500000000, time.UTC),
+		},
+		{
+			name: "upper seconds bits",
+			data: "00010000000000000000",
+			want: time.Unix(1<<32-37, 0),
+		},
+		{
+			name:    "nanoseconds overflow",
+			data:    "00006526c6a53b9aca00",
+			wantErr: "invalid PTP timestamp nanoseconds: 1000000000",
+		},
+		{
+			name:    "too long",
+			data:    "00006526c6a51dcd650000",
+			wantErr: "invalid PTP timestamp length: 11",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := hex.DecodeString(tt.data)
+			require.NoError(t, err)
+
+			var got PTPTime
+			err = got.UnmarshalBinary(data)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(got.Time()), got.Time().String())
+		})
+	}
+}
+
+func TestPTPTime_MarshalUnmarshalJSONYAML(t *testing.T) {
+	pt := time.Date(2023, 10, 11, 16, 0, 0, 123456789, time.UTC)
+	want := "1697040037.123456789"
+
+	b, err := json.Marshal(PTPTime(pt))
+	require.NoError(t, err)
+	assert.Equal(t, want, string(b))
+
+	var jp PTPTime
+	err = json.Unmarshal(b, &jp)
+	require.NoError(t, err)
+	assert.True(t, pt.Equal(jp.Time()))
+
+	b, err = yaml.Marshal(PTPTime(pt))
+	require.NoError(t, err)
+	assert.Equal(t, want+"\n", string(b))
+
+	var yp PTPTime
+	err = yaml.Unmarshal(b, &yp)
+	require.NoError(t, err)
+	assert.True(t, pt.Equal(yp.Time()))
+}
@@ -0,0 +1,93 @@
+package ts
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// ErrOverflow is returned when a numeric timestamp is too large to be
+// represented.
+var ErrOverflow = errors.New("numeric timestamp overflow")
+
+// ErrInvalidUnit is returned by FromUnix when given a unit which is neither a
+// whole number of seconds, nor evenly divides a second.
+var ErrInvalidUnit = errors.New("invalid timestamp unit")
+
+// RangeError is returned when a timestamp falls outside of the valid range
+// configured by a Normalization's Min and Max fields.
+type RangeError struct {
+	Time time.Time
+	Min  time.Time
+	Max  time.Time
+}
+
+// Error implements the error interface.
+func (e *RangeError) Error() string {
+	switch {
+	case !e.Min.IsZero() && e.Time.Before(e.Min):
+		return fmt.Sprintf(
+			"timestamp %s is before %s",
+			e.Time.Format(time.RFC3339Nano), e.Min.Format(time.RFC3339Nano),
+		)
+	case !e.Max.IsZero() && e.Time.After(e.Max):
+		return fmt.Sprintf(
+			"timestamp %s is after %s",
+			e.Time.Format(time.RFC3339Nano), e.Max.Format(time.RFC3339Nano),
+		)
+	default:
+		return fmt.Sprintf(
+			"timestamp %s is out of range",
+			e.Time.Format(time.RFC3339Nano),
+		)
+	}
+}
+
+// FromUnix returns a Timestamp for v, a numeric Unix timestamp in the given
+// unit. It returns an error wrapping ErrOverflow if v cannot be represented as
+// an int64 number of seconds, as accepted by time.Unix, rather than silently
+// returning an incorrect time.
+//
+// The unit must be either a whole number of seconds, or evenly divide a
+// second, otherwise an error wrapping ErrInvalidUnit is returned.
+func FromUnix[T Timestamp](v int64, unit time.Duration) (T, error) {
+	t, err := unixTime(v, unit)
+	if err != nil {
+		return T(time.Time{}), err
+	}
+
+	return T(t), nil
+}
+
+// checkUnixRange returns an error wrapping ErrOverflow if t is before lo or
+// after hi, the range of times a numeric timestamp can represent.
+func checkUnixRange(t, lo, hi time.Time) error {
+	if t.Before(lo) || t.After(hi) {
+		return fmt.Errorf(
+			"%w: %s", ErrOverflow, t.UTC().Format(time.RFC3339Nano),
+		)
+	}
+
+	return nil
+}
+
+func unixTime(v int64, unit time.Duration) (time.Time, error) {
+	if unit <= 0 || (unit%time.Second != 0 && time.Second%unit != 0) {
+		return time.Time{}, fmt.Errorf("%w: %s", ErrInvalidUnit, unit)
+	}
+
+	var sec, nsec int64
+	if unit >= time.Second {
+		m := int64(unit / time.Second)
+		if v > math.MaxInt64/m || v < math.MinInt64/m {
+			return time.Time{}, fmt.Errorf("%w: %d", ErrOverflow, v)
+		}
+		sec = v * m
+	} else {
+		n := int64(time.Second / unit)
+		sec, nsec = v/n, v%n*int64(unit)
+	}
+
+	return time.Unix(sec, nsec), nil
+}
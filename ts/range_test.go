@@ -0,0 +1,196 @@
+package ts
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestFromUnix(t *testing.T) {
+	tests := []struct {
+		name    string
+		v       int64
+		unit    time.Duration
+		want    time.Time
+		wantErr error
+	}{
+		{
+			name: "seconds",
+			v:    1697040000,
+			unit: time.Second,
+			want: time.Unix(1697040000, 0),
+		},
+		{
+			name: "negative milliseconds",
+			v:    -1500,
+			unit: time.Millisecond,
+			want: time.Unix(-2, 500000000),
+		},
+		{
+			name: "100 nanosecond ticks",
+			v:    16970400001234567,
+			unit: 100 * time.Nanosecond,
+			want: time.Unix(1697040000, 123456700),
+		},
+		{
+			name: "minutes",
+			v:    28284000,
+			unit: time.Minute,
+			want: time.Unix(1697040000, 0),
+		},
+		{
+			name: "max nanoseconds",
+			v:    math.MaxInt64,
+			unit: time.Nanosecond,
+			want: time.Unix(0, math.MaxInt64),
+		},
+		{
+			name: "max seconds",
+			v:    math.MaxInt64,
+			unit: time.Second,
+			want: time.Unix(math.MaxInt64, 0),
+		},
+		{
+			name: "min seconds",
+			v:    math.MinInt64,
+			unit: time.Second,
+			want: time.Unix(math.MinInt64, 0),
+		},
+		{
+			name:    "overflowing minutes",
+			v:       math.MaxInt64/60 + 1,
+			unit:    time.Minute,
+			wantErr: ErrOverflow,
+		},
+		{
+			name:    "underflowing hours",
+			v:       math.MinInt64/3600 - 1,
+			unit:    time.Hour,
+			wantErr: ErrOverflow,
+		},
+		{
+			name:    "zero unit",
+			v:       1,
+			unit:    0,
+			wantErr: ErrInvalidUnit,
+		},
+		{
+			name:    "negative unit",
+			v:       1,
+			unit:    -time.Second,
+			wantErr: ErrInvalidUnit,
+		},
+		{
+			name:    "unit not dividing a second",
+			v:       1,
+			unit:    7 * time.Millisecond,
+			wantErr: ErrInvalidUnit,
+		},
+		{
+			name:    "fractional number of seconds",
+			v:       1,
+			unit:    1500 * time.Millisecond,
+			wantErr: ErrInvalidUnit,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromUnix[Nanosecond](tt.v, tt.unit)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, time.Time(got))
+		})
+	}
+}
+
+func TestUnmarshal_Overflow(t *testing.T) {
+	tests := []struct {
+		name   string
+		s      string
+		target func() interface{}
+	}{
+		{
+			name:   "Second from large float",
+			s:      "1e30",
+			target: func() interface{} { return new(Second) },
+		},
+		{
+			name:   "Second from large integer",
+			s:      "92233720368547758070",
+			target: func() interface{} { return new(Second) },
+		},
+		{
+			name:   "Millisecond from large float",
+			s:      "-1e19",
+			target: func() interface{} { return new(Millisecond) },
+		},
+		{
+			name:   "Nanosecond from large integer string",
+			s:      `"9223372036854775808"`,
+			target: func() interface{} { return new(Nanosecond) },
+		},
+		{
+			name:   "FloatSecond from large float",
+			s:      "1e300",
+			target: func() interface{} { return new(FloatSecond) },
+		},
+		{
+			name:   "FloatSecond from infinite float",
+			s:      "1e400",
+			target: func() interface{} { return new(FloatSecond) },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+" JSON", func(t *testing.T) {
+			err := json.Unmarshal([]byte(tt.s), tt.target())
+
+			assert.ErrorIs(t, err, ErrOverflow)
+		})
+		t.Run(tt.name+" YAML", func(t *testing.T) {
+			err := yaml.Unmarshal([]byte(tt.s), tt.target())
+
+			assert.ErrorContains(t, err, ErrOverflow.Error())
+		})
+	}
+}
+
+type validRange struct{}
+
+func (validRange) Normalization() Normalization {
+	return Normalization{
+		Min: time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
+		Max: time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestUnmarshal_ValidRange(t *testing.T) {
+	var ms Normalized[Millisecond, validRange]
+	err := json.Unmarshal([]byte("1697040000123"), &ms)
+	require.NoError(t, err)
+
+	// Seconds mistakenly sent as milliseconds.
+	err = json.Unmarshal([]byte("1697040000"), &ms)
+	require.NoError(t, err)
+
+	// Microseconds mistakenly sent as milliseconds.
+	err = json.Unmarshal([]byte("1697040000123456"), &ms)
+	var rangeErr *RangeError
+	require.True(t, errors.As(err, &rangeErr))
+	assert.Equal(t, validRange{}.Normalization().Max, rangeErr.Max)
+	assert.Contains(t, err.Error(), "is after 2100-01-01T00:00:00Z")
+
+	err = yaml.Unmarshal([]byte("-1"), &ms)
+	require.True(t, errors.As(err, &rangeErr))
+	assert.Contains(t, err.Error(), "is before 1970-01-01T00:00:00Z")
+}
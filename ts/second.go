@@ -65,7 +65,12 @@ func (s *Second) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
-	*s = UnixSecond(i)
+	t, err := unixTime(i, time.Second)
+	if err != nil {
+		return err
+	}
+
+	*s = Second(t)
 
 	return nil
 }
@@ -82,7 +87,12 @@ func (s *Second) UnmarshalYAML(node *yaml.Node) error {
 		return err
 	}
 
-	*s = UnixSecond(i)
+	t, err := unixTime(i, time.Second)
+	if err != nil {
+		return err
+	}
+
+	*s = Second(t)
 
 	return nil
 }
@@ -0,0 +1,75 @@
+package ts
+
+import (
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SecondString is a wrapper around time.Time for marshaling to/from
+// JSON/YAML as second-based Unix timestamps encoded as strings, which keeps
+// large values intact when decoded by JavaScript and other consumers that
+// parse numbers as float64.
+//
+// It marshals to a JSON/YAML string containing the same integer value as
+// Second.
+//
+// It unmarshals from a JSON/YAML string or number representing the number of
+// seconds since the Unix time epoch, exactly like Second.
+type SecondString time.Time
+
+// Time returns the time.Time corresponding to the second string instant s.
+func (s SecondString) Time() time.Time {
+	return time.Time(s)
+}
+
+// Local returns the local time corresponding to the second string instant s.
+func (s SecondString) Local() SecondString {
+	return SecondString(time.Time(s).Local())
+}
+
+// GoString implements the fmt.GoStringer interface.
+func (s SecondString) GoString() string {
+	return time.Time(s).GoString()
+}
+
+// IsDST reports whether the second string instant s occurs within Daylight
+// Saving Time.
+func (s SecondString) IsDST() bool {
+	return time.Time(s).IsDST()
+}
+
+// IsZero returns true if the SecondString is the zero value.
+func (s SecondString) IsZero() bool {
+	return time.Time(s).IsZero()
+}
+
+// String calls time.Time.String.
+func (s SecondString) String() string {
+	return time.Time(s).String()
+}
+
+// UTC returns a copy of the SecondString with the location set to UTC.
+func (s SecondString) UTC() SecondString {
+	return SecondString(time.Time(s).UTC())
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (s SecondString) MarshalJSON() ([]byte, error) {
+	return marshalStringJSON(Second(s).MarshalJSON())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (s *SecondString) UnmarshalJSON(data []byte) error {
+	return (*Second)(s).UnmarshalJSON(data)
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (s SecondString) MarshalYAML() (interface{}, error) {
+	return marshalStringYAML(Second(s).MarshalJSON())
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (s *SecondString) UnmarshalYAML(node *yaml.Node) error {
+	return (*Second)(s).UnmarshalYAML(node)
+}
@@ -0,0 +1,187 @@
+package ts
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+var stringTestTime = time.Date(2023, 10, 11, 16, 0, 0, 123456789, time.UTC)
+
+func TestStringTypes_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		v    interface{}
+		want string
+	}{
+		{v: SecondString(stringTestTime), want: `"1697040000"`},
+		{v: MillisecondString(stringTestTime), want: `"1697040000123"`},
+		{v: MicrosecondString(stringTestTime), want: `"1697040000123456"`},
+		{v: NanosecondString(stringTestTime), want: `"1697040000123456789"`},
+		{v: FloatSecondString(stringTestTime), want: `"1697040000.123457"`},
+		{
+			v:    FloatMillisecondString(stringTestTime),
+			want: `"1697040000123.457"`,
+		},
+		{
+			v:    FileTimeString(stringTestTime),
+			want: `"133415136001234567"`,
+		},
+		{v: TicksString(stringTestTime), want: `"638326368001234567"`},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%T", tt.v), func(t *testing.T) {
+			b, err := json.Marshal(tt.v)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(b))
+
+			b, err = json.Marshal([]interface{}{tt.v})
+			require.NoError(t, err)
+			assert.Equal(t, "["+tt.want+"]", string(b))
+
+			b, err = json.Marshal(map[string]interface{}{"t": tt.v})
+			require.NoError(t, err)
+			assert.Equal(t, `{"t":`+tt.want+"}", string(b))
+
+			b, err = yaml.Marshal(tt.v)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want+"\n", string(b))
+		})
+	}
+}
+
+func TestStringTypes_Unmarshal(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		target  func() interface{}
+		want    time.Time
+		wantErr string
+	}{
+		{
+			name:   "SecondString from string",
+			s:      `"1697040000"`,
+			target: func() interface{} { return new(SecondString) },
+			want:   stringTestTime.Truncate(time.Second),
+		},
+		{
+			name:   "SecondString from number",
+			s:      `1697040000`,
+			target: func() interface{} { return new(SecondString) },
+			want:   stringTestTime.Truncate(time.Second),
+		},
+		{
+			name:   "MillisecondString from string",
+			s:      `"1697040000123"`,
+			target: func() interface{} { return new(MillisecondString) },
+			want:   stringTestTime.Truncate(time.Millisecond),
+		},
+		{
+			name:   "MicrosecondString from number",
+			s:      `1697040000123456`,
+			target: func() interface{} { return new(MicrosecondString) },
+			want:   stringTestTime.Truncate(time.Microsecond),
+		},
+		{
+			name:   "NanosecondString from string",
+			s:      `"1697040000123456789"`,
+			target: func() interface{} { return new(NanosecondString) },
+			want:   stringTestTime,
+		},
+		{
+			name:   "FloatSecondString from string",
+			s:      `"1697040000.123456789"`,
+			target: func() interface{} { return new(FloatSecondString) },
+			want:   stringTestTime,
+		},
+		{
+			name:   "FloatMillisecondString from number",
+			s:      `1697040000123.456789`,
+			target: func() interface{} { return new(FloatMillisecondString) },
+			want:   stringTestTime,
+		},
+		{
+			name:   "FileTimeString from string",
+			s:      `"133415136001234567"`,
+			target: func() interface{} { return new(FileTimeString) },
+			want:   stringTestTime.Truncate(100 * time.Nanosecond),
+		},
+		{
+			name:   "TicksString from number",
+			s:      `638326368001234567`,
+			target: func() interface{} { return new(TicksString) },
+			want:   stringTestTime.Truncate(100 * time.Nanosecond),
+		},
+		{
+			name:    "NanosecondString from invalid string",
+			s:       `"nope"`,
+			target:  func() interface{} { return new(NanosecondString) },
+			wantErr: "invalid numeric timestamp",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+" JSON", func(t *testing.T) {
+			v := tt.target()
+			err := json.Unmarshal([]byte(tt.s), v)
+
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(stringTypeTime(v)))
+		})
+		t.Run(tt.name+" YAML", func(t *testing.T) {
+			v := tt.target()
+			err := yaml.Unmarshal([]byte(tt.s), v)
+
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(stringTypeTime(v)))
+		})
+	}
+}
+
+func TestStringTypes_UnmarshalSlice(t *testing.T) {
+	var got []NanosecondString
+	err := json.Unmarshal(
+		[]byte(`["1697040000123456789", 1697040000123456789]`), &got,
+	)
+	require.NoError(t, err)
+
+	require.Len(t, got, 2)
+	assert.True(t, stringTestTime.Equal(got[0].Time()))
+	assert.True(t, stringTestTime.Equal(got[1].Time()))
+}
+
+func stringTypeTime(v interface{}) time.Time {
+	switch x := v.(type) {
+	case *SecondString:
+		return x.Time()
+	case *MillisecondString:
+		return x.Time()
+	case *MicrosecondString:
+		return x.Time()
+	case *NanosecondString:
+		return x.Time()
+	case *FloatSecondString:
+		return x.Time()
+	case *FloatMillisecondString:
+		return x.Time()
+	case *FileTimeString:
+		return x.Time()
+	case *TicksString:
+		return x.Time()
+	}
+
+	return time.Time{}
+}
@@ -0,0 +1,172 @@
+package ts
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// tai64Base is the TAI64 label of the TAI instant 1970-01-01 00:00:00 TAI.
+const tai64Base = 1 << 62
+
+// TAI64N is a wrapper around time.Time for marshaling to/from TAI64N labels, as
+// used by daemontools, s6 and runit logs, e.g. "@4000000065268bf81234abcd".
+//
+// It marshals to text, JSON and YAML as a string holding a "@" followed by 24
+// hexadecimal digits. The first 16 digits are the TAI64 label, 2^62 plus the
+// number of TAI seconds since 1970-01-01 00:00:00 TAI, and the last 8 digits
+// are the number of nanoseconds. It marshals to binary as the same 12 bytes,
+// big-endian. UTC is converted to TAI using LeapSeconds.
+//
+// It unmarshals from the same text, JSON, YAML and binary forms. Labels
+// without nanoseconds, holding only 16 hexadecimal digits, are also accepted.
+type TAI64N time.Time
+
+// Time returns the time.Time corresponding to the TAI64N instant t.
+func (t TAI64N) Time() time.Time {
+	return time.Time(t)
+}
+
+// Local returns the local time corresponding to the TAI64N instant t.
+func (t TAI64N) Local() TAI64N {
+	return TAI64N(time.Time(t).Local())
+}
+
+// GoString implements the fmt.GoStringer interface.
+func (t TAI64N) GoString() string {
+	return time.Time(t).GoString()
+}
+
+// IsDST reports whether the TAI64N instant t occurs within Daylight Saving
+// Time.
+func (t TAI64N) IsDST() bool {
+	return time.Time(t).IsDST()
+}
+
+// IsZero returns true if the TAI64N is the zero value.
+func (t TAI64N) IsZero() bool {
+	return time.Time(t).IsZero()
+}
+
+// String calls time.Time.String.
+func (t TAI64N) String() string {
+	return time.Time(t).String()
+}
+
+// UTC returns a copy of the TAI64N with the location set to UTC.
+func (t TAI64N) UTC() TAI64N {
+	return TAI64N(time.Time(t).UTC())
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (t TAI64N) MarshalBinary() ([]byte, error) {
+	tai := UTCToTAI(time.Time(t))
+	sec := tai.Unix()
+	if sec >= tai64Base || sec < -tai64Base {
+		return nil, fmt.Errorf("%w: %s", ErrOverflow, time.Time(t))
+	}
+
+	b := make([]byte, 12)
+	binary.BigEndian.PutUint64(b[0:8], uint64(tai64Base+sec))
+	binary.BigEndian.PutUint32(b[8:12], uint32(tai.Nanosecond()))
+
+	return b, nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (t *TAI64N) UnmarshalBinary(data []byte) error {
+	if len(data) != 8 && len(data) != 12 {
+		return fmt.Errorf("invalid TAI64N label length: %d", len(data))
+	}
+
+	label := binary.BigEndian.Uint64(data[0:8])
+	if label >= 1<<63 {
+		return fmt.Errorf("invalid TAI64N label: %x", data)
+	}
+
+	var nsec int64
+	if len(data) == 12 {
+		nsec = int64(binary.BigEndian.Uint32(data[8:12]))
+		if nsec >= int64(time.Second) {
+			return fmt.Errorf("invalid TAI64N label: %x", data)
+		}
+	}
+
+	tai := time.Unix(int64(label)-tai64Base, nsec)
+	*t = TAI64N(TAIToUTC(tai))
+
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (t TAI64N) MarshalText() ([]byte, error) {
+	b, err := t.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte("@" + hex.EncodeToString(b)), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (t *TAI64N) UnmarshalText(text []byte) error {
+	s := string(text)
+	if !strings.HasPrefix(s, "@") {
+		return fmt.Errorf("invalid TAI64N label: %s", s)
+	}
+
+	b, err := hex.DecodeString(s[1:])
+	if err != nil || (len(b) != 8 && len(b) != 12) {
+		return fmt.Errorf("invalid TAI64N label: %s", s)
+	}
+
+	return t.UnmarshalBinary(b)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (t TAI64N) MarshalJSON() ([]byte, error) {
+	b, err := t.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(strconv.Quote(string(b))), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (t *TAI64N) UnmarshalJSON(data []byte) error {
+	s, err := strconv.Unquote(string(data))
+	if err != nil {
+		return fmt.Errorf("invalid TAI64N label: %s", string(data))
+	}
+
+	return t.UnmarshalText([]byte(s))
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (t TAI64N) MarshalYAML() (interface{}, error) {
+	b, err := t.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+
+	return string(b), nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (t *TAI64N) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.ScalarNode || node.Tag != "!!str" {
+		return &yaml.TypeError{Errors: []string{"invalid TAI64N label"}}
+	}
+
+	if err := t.UnmarshalText([]byte(node.Value)); err != nil {
+		return &yaml.TypeError{Errors: []string{err.Error()}}
+	}
+
+	return nil
+}
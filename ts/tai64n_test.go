@@ -0,0 +1,165 @@
+package ts
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestTAI64N_Marshal(t *testing.T) {
+	tests := []struct {
+		name string
+		t    time.Time
+		want string
+	}{
+		{
+			name: "Unix epoch",
+			t:    time.Unix(0, 0),
+			want: "@400000000000000a00000000",
+		},
+		{
+			name: "after leap second",
+			t:    time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC),
+			want: "@40000000586846a500000000",
+		},
+		{
+			name: "nanoseconds",
+			t:    time.Date(2023, 10, 11, 16, 0, 0, 123456789, time.UTC),
+			want: "@400000006526c6a5075bcd15",
+		},
+		{
+			name: "before TAI64 epoch",
+			t:    time.Date(1969, 12, 31, 23, 59, 0, 0, time.UTC),
+			want: "@3fffffffffffffce00000000",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := TAI64N(tt.t).MarshalText()
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(b))
+
+			var tt64 TAI64N
+			err = tt64.UnmarshalText(b)
+			require.NoError(t, err)
+			assert.True(t, tt.t.Equal(tt64.Time()))
+
+			b, err = json.Marshal(TAI64N(tt.t))
+			require.NoError(t, err)
+			assert.Equal(t, `"`+tt.want+`"`, string(b))
+
+			var jt TAI64N
+			err = json.Unmarshal(b, &jt)
+			require.NoError(t, err)
+			assert.True(t, tt.t.Equal(jt.Time()))
+
+			b, err = yaml.Marshal(TAI64N(tt.t))
+			require.NoError(t, err)
+			assert.Equal(t, "'"+tt.want+"'\n", string(b))
+
+			var yt TAI64N
+			err = yaml.Unmarshal(b, &yt)
+			require.NoError(t, err)
+			assert.True(t, tt.t.Equal(yt.Time()))
+
+			b, err = TAI64N(tt.t).MarshalBinary()
+			require.NoError(t, err)
+			assert.Len(t, b, 12)
+
+			var bt TAI64N
+			err = bt.UnmarshalBinary(b)
+			require.NoError(t, err)
+			assert.True(t, tt.t.Equal(bt.Time()))
+		})
+	}
+}
+
+func TestTAI64N_Unmarshal(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    time.Time
+		wantErr string
+	}{
+		{
+			name: "TAI64 label without nanoseconds",
+			s:    "@40000000586846a5",
+			want: time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "uppercase",
+			s:    "@400000006526C6A5075BCD15",
+			want: time.Date(2023, 10, 11, 16, 0, 0, 123456789, time.UTC),
+		},
+		{
+			name: "leap second",
+			s:    "@40000000586846a41dcd6500",
+			want: time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "missing @",
+			s:       "400000006526c6a5075bcd15",
+			wantErr: "invalid TAI64N label: 400000006526c6a5075bcd15",
+		},
+		{
+			name:    "invalid length",
+			s:       "@400000006526c6a5075b",
+			wantErr: "invalid TAI64N label: @400000006526c6a5075b",
+		},
+		{
+			name:    "invalid hex",
+			s:       "@400000006526c6a5075bcdxx",
+			wantErr: "invalid TAI64N label: @400000006526c6a5075bcdxx",
+		},
+		{
+			name:    "reserved label",
+			s:       "@800000006526c6a500000000",
+			wantErr: "invalid TAI64N label: 800000006526c6a500000000",
+		},
+		{
+			name:    "nanoseconds overflow",
+			s:       "@400000006526c6a53b9aca00",
+			wantErr: "invalid TAI64N label: 400000006526c6a53b9aca00",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+" JSON", func(t *testing.T) {
+			var got TAI64N
+			err := json.Unmarshal([]byte(`"`+tt.s+`"`), &got)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(got.Time()), got.Time().String())
+		})
+		t.Run(tt.name+" YAML", func(t *testing.T) {
+			var got TAI64N
+			err := yaml.Unmarshal([]byte(`"`+tt.s+`"`), &got)
+
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(got.Time()), got.Time().String())
+		})
+	}
+}
+
+func TestTAI64N_UnmarshalInvalidType(t *testing.T) {
+	var jt TAI64N
+	err := json.Unmarshal([]byte("1234"), &jt)
+	assert.EqualError(t, err, "invalid TAI64N label: 1234")
+
+	var yt TAI64N
+	err = yaml.Unmarshal([]byte("1234"), &yt)
+	assert.ErrorContains(t, err, "invalid TAI64N label")
+}
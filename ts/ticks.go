@@ -0,0 +1,92 @@
+package ts
+
+import (
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+var ticksEpoch = Epoch{
+	Origin: time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC),
+	Unit:   100 * time.Nanosecond,
+}
+
+// Ticks is a wrapper around time.Time for marshaling to/from JSON/YAML as
+// .NET DateTime ticks.
+//
+// It marshals to a JSON/YAML number representing the number of
+// 100-nanosecond intervals since January 1, 0001 UTC.
+//
+// It unmarshals from a JSON/YAML number, or numeric string, representing the
+// number of 100-nanosecond intervals since January 1, 0001 UTC.
+type Ticks time.Time
+
+// Time returns the time.Time corresponding to the ticks instant tk.
+func (tk Ticks) Time() time.Time {
+	return time.Time(tk)
+}
+
+// Local returns the local time corresponding to the ticks instant tk.
+func (tk Ticks) Local() Ticks {
+	return Ticks(time.Time(tk).Local())
+}
+
+// GoString implements the fmt.GoStringer interface.
+func (tk Ticks) GoString() string {
+	return time.Time(tk).GoString()
+}
+
+// IsDST reports whether the ticks instant t occurs within Daylight Saving
+// Time.
+func (tk Ticks) IsDST() bool {
+	return time.Time(tk).IsDST()
+}
+
+// IsZero returns true if the Ticks is the zero value.
+func (tk Ticks) IsZero() bool {
+	return time.Time(tk).IsZero()
+}
+
+// String calls time.Time.String.
+func (tk Ticks) String() string {
+	return time.Time(tk).String()
+}
+
+// UTC returns a copy of the Ticks with the location set to UTC.
+func (tk Ticks) UTC() Ticks {
+	return Ticks(time.Time(tk).UTC())
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (tk Ticks) MarshalJSON() ([]byte, error) {
+	return ticksEpoch.marshalJSON(time.Time(tk))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (tk *Ticks) UnmarshalJSON(data []byte) error {
+	t, err := ticksEpoch.unmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+
+	*tk = Ticks(t)
+
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (tk Ticks) MarshalYAML() (interface{}, error) {
+	return ticksEpoch.marshalYAML(time.Time(tk))
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (tk *Ticks) UnmarshalYAML(node *yaml.Node) error {
+	t, err := ticksEpoch.unmarshalYAML(node)
+	if err != nil {
+		return err
+	}
+
+	*tk = Ticks(t)
+
+	return nil
+}
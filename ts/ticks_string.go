@@ -0,0 +1,77 @@
+package ts
+
+import (
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TicksString is a wrapper around time.Time for marshaling to/from
+// JSON/YAML as .NET DateTime ticks encoded as strings, which keeps large
+// values intact when decoded by JavaScript and other consumers that parse
+// numbers as float64.
+//
+// It marshals to a JSON/YAML string containing the same integer value as
+// Ticks.
+//
+// It unmarshals from a JSON/YAML string or number representing the number of
+// 100-nanosecond intervals since January 1, 0001 UTC, exactly like Ticks.
+type TicksString time.Time
+
+// Time returns the time.Time corresponding to the ticks string instant
+// tk.
+func (tk TicksString) Time() time.Time {
+	return time.Time(tk)
+}
+
+// Local returns the local time corresponding to the ticks string
+// instant tk.
+func (tk TicksString) Local() TicksString {
+	return TicksString(time.Time(tk).Local())
+}
+
+// GoString implements the fmt.GoStringer interface.
+func (tk TicksString) GoString() string {
+	return time.Time(tk).GoString()
+}
+
+// IsDST reports whether the ticks string instant tk occurs within
+// Daylight Saving Time.
+func (tk TicksString) IsDST() bool {
+	return time.Time(tk).IsDST()
+}
+
+// IsZero returns true if the TicksString is the zero value.
+func (tk TicksString) IsZero() bool {
+	return time.Time(tk).IsZero()
+}
+
+// String calls time.Time.String.
+func (tk TicksString) String() string {
+	return time.Time(tk).String()
+}
+
+// UTC returns a copy of the TicksString with the location set to UTC.
+func (tk TicksString) UTC() TicksString {
+	return TicksString(time.Time(tk).UTC())
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (tk TicksString) MarshalJSON() ([]byte, error) {
+	return marshalStringJSON(Ticks(tk).MarshalJSON())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (tk *TicksString) UnmarshalJSON(data []byte) error {
+	return (*Ticks)(tk).UnmarshalJSON(data)
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (tk TicksString) MarshalYAML() (interface{}, error) {
+	return marshalStringYAML(Ticks(tk).MarshalJSON())
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (tk *TicksString) UnmarshalYAML(node *yaml.Node) error {
+	return (*Ticks)(tk).UnmarshalYAML(node)
+}
@@ -1,8 +1,10 @@
 // Package ts provides wrapper types around time.Time, with support for
 // JSON/YAML marshaling to/from numeric Unix timestamps of various precisions.
 //
-// Unmarshaling supports both numeric and string values. Marshaling always
-// produces a integer value.
+// Unmarshaling supports both numeric and string values. Marshaling produces an
+// integer value, apart from FloatSecond and FloatMillisecond which produce a
+// decimal value with a fixed number of fractional digits. Types with a String
+// suffix produce the same values, but encoded as strings.
 package ts
 
 import (
@@ -12,9 +14,37 @@ import (
 )
 
 // Timestamp is a type constraint that matches against time.Time, Second,
-// Millisecond, Microsecond, and Nanosecond.
+// Millisecond, Microsecond, Nanosecond, FloatSecond, FloatMillisecond, their
+// string-encoded variants, FloatSecondOf and FloatMillisecondOf with any
+// Digits, FileTime, Ticks, their string-encoded variants, CocoaSecond,
+// GPSSecond, ExcelSerial, NTPTime, PTPTime, and TAI64N.
 type Timestamp interface {
-	time.Time | Second | Millisecond | Microsecond | Nanosecond
+	time.Time | Second | Millisecond | Microsecond | Nanosecond |
+		FloatSecond | FloatMillisecond | floatSecondOf | floatMillisecondOf |
+		SecondString | MillisecondString | MicrosecondString |
+		NanosecondString | FloatSecondString | FloatMillisecondString |
+		FileTime | Ticks | FileTimeString | TicksString |
+		CocoaSecond | GPSSecond | ExcelSerial | NTPTime | PTPTime | TAI64N
+}
+
+// floatSecondOf is a type constraint that matches FloatSecondOf with any
+// Digits.
+type floatSecondOf interface {
+	FloatSecondOf[Digits0] | FloatSecondOf[Digits1] | FloatSecondOf[Digits2] |
+		FloatSecondOf[Digits3] | FloatSecondOf[Digits4] |
+		FloatSecondOf[Digits5] | FloatSecondOf[Digits6] |
+		FloatSecondOf[Digits7] | FloatSecondOf[Digits8] |
+		FloatSecondOf[Digits9]
+}
+
+// floatMillisecondOf is a type constraint that matches FloatMillisecondOf
+// with any Digits.
+type floatMillisecondOf interface {
+	FloatMillisecondOf[Digits0] | FloatMillisecondOf[Digits1] |
+		FloatMillisecondOf[Digits2] | FloatMillisecondOf[Digits3] |
+		FloatMillisecondOf[Digits4] | FloatMillisecondOf[Digits5] |
+		FloatMillisecondOf[Digits6] | FloatMillisecondOf[Digits7] |
+		FloatMillisecondOf[Digits8] | FloatMillisecondOf[Digits9]
 }
 
 // Duration is a type constraint that matches against time.Duration and
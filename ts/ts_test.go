@@ -24,6 +24,15 @@ func TestAdd(t *testing.T) {
 
 	testAdd[Nanosecond, time.Duration](t)
 	testAdd[Nanosecond, dur.Duration](t)
+
+	testAdd[FloatSecond, time.Duration](t)
+	testAdd[FloatSecond, dur.Duration](t)
+
+	testAdd[FloatMillisecond, time.Duration](t)
+	testAdd[FloatMillisecond, dur.Duration](t)
+
+	testAdd[FloatSecondOf[Digits3], time.Duration](t)
+	testAdd[FloatMillisecondOf[Digits6], dur.Duration](t)
 }
 
 func testAdd[T Timestamp, D Duration](t *testing.T) {
@@ -118,6 +127,12 @@ func TestSub(t *testing.T) {
 	testSub[Nanosecond, Millisecond](t)
 	testSub[Nanosecond, Microsecond](t)
 	testSub[Nanosecond, Nanosecond](t)
+
+	testSub[FloatSecond, time.Time](t)
+	testSub[FloatSecond, Nanosecond](t)
+	testSub[FloatMillisecond, FloatSecond](t)
+	testSub[Second, FloatMillisecond](t)
+	testSub[FloatSecondOf[Digits9], FloatMillisecondOf[Digits0]](t)
 }
 
 func testSub[T, U Timestamp](t *testing.T) {
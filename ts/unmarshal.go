@@ -1,7 +1,9 @@
 package ts
 
 import (
+	"errors"
 	"fmt"
+	"math/big"
 	"strconv"
 
 	"gopkg.in/yaml.v3"
@@ -15,12 +17,12 @@ func unmarshalBytes(data []byte) (int64, error) {
 
 	i, err := strconv.ParseInt(string(data), 10, 64)
 	if err != nil {
-		var f float64
-		f, err = strconv.ParseFloat(string(data), 64)
-		i = int64(f)
+		i, err = parseFloor(string(data))
 	}
 
-	if err != nil {
+	if errors.Is(err, strconv.ErrRange) {
+		return 0, fmt.Errorf("%w: %s", ErrOverflow, string(data))
+	} else if err != nil {
 		return 0, fmt.Errorf("invalid numeric timestamp: %s", string(data))
 	}
 
@@ -36,16 +38,55 @@ func unmarshalYAMLNode(node *yaml.Node) (int64, error) {
 	case "!!int", "!!str":
 		i, err = strconv.ParseInt(node.Value, 10, 64)
 	case "!!float":
-		var f float64
-		f, err = strconv.ParseFloat(node.Value, 64)
-		i = int64(f)
+		i, err = parseFloor(node.Value)
 	default:
 		invalid = true
 	}
 
-	if err != nil || invalid {
+	if errors.Is(err, strconv.ErrRange) {
+		return 0, &yaml.TypeError{Errors: []string{ErrOverflow.Error()}}
+	} else if err != nil || invalid {
 		return 0, &yaml.TypeError{Errors: []string{"invalid numeric timestamp"}}
 	}
 
 	return i, nil
 }
+
+func unmarshalDecimalBytes(data []byte) (*big.Rat, error) {
+	s, err := strconv.Unquote(string(data))
+	if err == nil {
+		data = []byte(s)
+	}
+
+	r, err := parseDecimal(string(data))
+	if errors.Is(err, strconv.ErrRange) {
+		return nil, fmt.Errorf("%w: %s", ErrOverflow, string(data))
+	} else if err != nil {
+		return nil, fmt.Errorf("invalid numeric timestamp: %s", string(data))
+	}
+
+	return r, nil
+}
+
+func unmarshalDecimalYAMLNode(node *yaml.Node) (*big.Rat, error) {
+	var r *big.Rat
+	var err error
+	var invalid bool
+
+	switch node.Tag {
+	case "!!int", "!!float", "!!str":
+		r, err = parseDecimal(node.Value)
+	default:
+		invalid = true
+	}
+
+	if errors.Is(err, strconv.ErrRange) {
+		return nil, &yaml.TypeError{Errors: []string{ErrOverflow.Error()}}
+	} else if err != nil || invalid {
+		return nil, &yaml.TypeError{
+			Errors: []string{"invalid numeric timestamp"},
+		}
+	}
+
+	return r, nil
+}
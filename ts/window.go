@@ -0,0 +1,236 @@
+package ts
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/jimeh/go-tyme/dur"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// ErrNoWindowSize is returned by WindowOf and WindowsOf for WindowSpecs
+	// without a Size.
+	ErrNoWindowSize = errors.New("window spec has no size")
+
+	// ErrNoWindowGap is returned by Sessions for WindowSpecs without a Gap.
+	ErrNoWindowGap = errors.New("window spec has no gap")
+)
+
+// WindowSpec describes how timestamps are grouped into windows for
+// aggregation. It can be unmarshaled from JSON/YAML configuration, with
+// durations in any format supported by dur.Duration:
+//
+//	size: 1m
+//	slide: 10s
+//
+// Windows are aligned to the Unix epoch shifted by Offset. Size and Slide
+// describe tumbling and hopping windows used with WindowOf and WindowsOf,
+// while Gap describes session windows used with Sessions.
+type WindowSpec struct {
+	// Size is the length of each window.
+	Size dur.Duration `json:"size,omitempty" yaml:"size,omitempty"`
+
+	// Slide is the interval between the start of consecutive windows. When
+	// zero, it defaults to Size, resulting in tumbling windows. Values smaller
+	// than Size result in overlapping hopping windows.
+	Slide dur.Duration `json:"slide,omitempty" yaml:"slide,omitempty"`
+
+	// Offset shifts the alignment of windows away from the Unix epoch, for
+	// example to align daily windows to a time zone other than UTC.
+	Offset dur.Duration `json:"offset,omitempty" yaml:"offset,omitempty"`
+
+	// Gap is the maximum duration of inactivity within a session window.
+	Gap dur.Duration `json:"gap,omitempty" yaml:"gap,omitempty"`
+}
+
+// Interval is a half-open time interval, including Start and excluding End.
+type Interval[T Timestamp] struct {
+	Start T `json:"start" yaml:"start"`
+	End   T `json:"end" yaml:"end"`
+}
+
+// Contains reports whether t is within the interval.
+func (i Interval[T]) Contains(t T) bool {
+	tt := time.Time(t)
+
+	return !tt.Before(time.Time(i.Start)) && tt.Before(time.Time(i.End))
+}
+
+// Duration returns the length of the interval.
+func (i Interval[T]) Duration() dur.Duration {
+	return Sub(i.End, i.Start)
+}
+
+// IsZero reports whether i is the zero Interval.
+func (i Interval[T]) IsZero() bool {
+	return time.Time(i.Start).IsZero() && time.Time(i.End).IsZero()
+}
+
+// Validate returns an error if the WindowSpec is invalid.
+func (s WindowSpec) Validate() error {
+	switch {
+	case s.Size < 0 || s.Slide < 0 || s.Gap < 0:
+		return errors.New("window durations must not be negative")
+	case s.Size == 0 && s.Gap == 0:
+		return errors.New("window size or gap must be set")
+	case s.Size == 0 && s.Slide != 0:
+		return errors.New("window slide requires a size")
+	}
+
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, validating the
+// resulting WindowSpec.
+func (s *WindowSpec) UnmarshalJSON(data []byte) error {
+	type spec WindowSpec
+	var v spec
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	if err := WindowSpec(v).Validate(); err != nil {
+		return err
+	}
+	*s = WindowSpec(v)
+
+	return nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface, validating the
+// resulting WindowSpec.
+func (s *WindowSpec) UnmarshalYAML(node *yaml.Node) error {
+	type spec WindowSpec
+	var v spec
+	if err := node.Decode(&v); err != nil {
+		return err
+	}
+
+	if err := WindowSpec(v).Validate(); err != nil {
+		return &yaml.TypeError{Errors: []string{err.Error()}}
+	}
+	*s = WindowSpec(v)
+
+	return nil
+}
+
+func (s WindowSpec) validateSize() error {
+	if err := s.Validate(); err != nil {
+		return err
+	}
+	if s.Size == 0 {
+		return ErrNoWindowSize
+	}
+
+	return nil
+}
+
+func (s WindowSpec) slide() time.Duration {
+	if s.Slide > 0 {
+		return time.Duration(s.Slide)
+	}
+
+	return time.Duration(s.Size)
+}
+
+// WindowOf returns the latest starting window of spec which starts at or
+// before t. For tumbling windows, and hopping windows with a Slide no larger
+// than Size, it is the most recent window containing t. It returns an error if
+// spec is invalid, or ErrNoWindowSize if it has no Size.
+func WindowOf[T Timestamp](t T, spec WindowSpec) (Interval[T], error) {
+	if err := spec.validateSize(); err != nil {
+		return Interval[T]{}, err
+	}
+
+	start := windowStart(
+		time.Time(t), spec.slide(), time.Duration(spec.Offset),
+	)
+
+	return Interval[T]{
+		Start: T(start),
+		End:   T(start.Add(time.Duration(spec.Size))),
+	}, nil
+}
+
+// WindowsOf returns all windows of spec which contain t, ordered by start
+// time. Tumbling windows always result in a single window, while hopping
+// windows may result in multiple, or none when Slide is larger than Size. It
+// returns an error if spec is invalid, or ErrNoWindowSize if it has no Size.
+func WindowsOf[T Timestamp](t T, spec WindowSpec) ([]Interval[T], error) {
+	if err := spec.validateSize(); err != nil {
+		return nil, err
+	}
+
+	tt := time.Time(t)
+	slide := spec.slide()
+	size := time.Duration(spec.Size)
+
+	var windows []Interval[T]
+	start := windowStart(tt, slide, time.Duration(spec.Offset))
+	for ; start.Add(size).After(tt); start = start.Add(-slide) {
+		windows = append(windows, Interval[T]{
+			Start: T(start),
+			End:   T(start.Add(size)),
+		})
+	}
+
+	for i, j := 0, len(windows)-1; i < j; i, j = i+1, j-1 {
+		windows[i], windows[j] = windows[j], windows[i]
+	}
+
+	return windows, nil
+}
+
+// Sessions groups times into session windows of spec, ordered by start time.
+// Consecutive times no further apart than Gap belong to the same session, which
+// starts at its earliest time, and ends Gap after its latest time. The order
+// of times does not matter. It returns an error if spec is invalid, or
+// ErrNoWindowGap if it has no Gap.
+func Sessions[T Timestamp](times []T, spec WindowSpec) ([]Interval[T], error) {
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	if spec.Gap == 0 {
+		return nil, ErrNoWindowGap
+	}
+
+	sorted := make([]time.Time, len(times))
+	for i, t := range times {
+		sorted[i] = time.Time(t)
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Before(sorted[j])
+	})
+
+	gap := time.Duration(spec.Gap)
+
+	var windows []Interval[T]
+	for i, t := range sorted {
+		last := len(windows) - 1
+		if i > 0 && !t.After(sorted[i-1].Add(gap)) {
+			windows[last].End = T(t.Add(gap))
+
+			continue
+		}
+
+		windows = append(windows, Interval[T]{Start: T(t), End: T(t.Add(gap))})
+	}
+
+	return windows, nil
+}
+
+// windowStart returns the latest instant at or before t which is a multiple of
+// step after the Unix epoch shifted by offset.
+func windowStart(t time.Time, step, offset time.Duration) time.Time {
+	t = t.Round(0)
+
+	n := new(big.Int).Mul(big.NewInt(t.Unix()), big.NewInt(int64(time.Second)))
+	n.Add(n, big.NewInt(int64(t.Nanosecond())-int64(offset)))
+	n.Mod(n, big.NewInt(int64(step)))
+
+	return t.Add(-time.Duration(n.Int64()))
+}
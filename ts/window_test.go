@@ -0,0 +1,266 @@
+package ts
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/jimeh/go-tyme/dur"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestWindowSpec_Unmarshal(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		yaml    string
+		want    WindowSpec
+		wantErr string
+	}{
+		{
+			name: "hopping",
+			json: `{"size":"1m","slide":"10s","offset":"-30s"}`,
+			yaml: "size: 1m\nslide: 10s\noffset: -30s\n",
+			want: WindowSpec{
+				Size:   dur.Duration(time.Minute),
+				Slide:  dur.Duration(10 * time.Second),
+				Offset: dur.Duration(-30 * time.Second),
+			},
+		},
+		{
+			name: "session with numeric seconds",
+			json: `{"gap":300}`,
+			yaml: "gap: 300\n",
+			want: WindowSpec{Gap: dur.Duration(5 * time.Minute)},
+		},
+		{
+			name:    "empty",
+			json:    `{}`,
+			yaml:    "{}\n",
+			wantErr: "window size or gap must be set",
+		},
+		{
+			name:    "negative size",
+			json:    `{"size":"-1m"}`,
+			yaml:    "size: -1m\n",
+			wantErr: "window durations must not be negative",
+		},
+		{
+			name:    "slide without size",
+			json:    `{"slide":"1m","gap":"1m"}`,
+			yaml:    "slide: 1m\ngap: 1m\n",
+			wantErr: "window slide requires a size",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+" JSON", func(t *testing.T) {
+			var got WindowSpec
+			err := json.Unmarshal([]byte(tt.json), &got)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+		t.Run(tt.name+" YAML", func(t *testing.T) {
+			var got WindowSpec
+			err := yaml.Unmarshal([]byte(tt.yaml), &got)
+
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestWindowSpec_Marshal(t *testing.T) {
+	spec := WindowSpec{
+		Size:  dur.Duration(time.Minute),
+		Slide: dur.Duration(10 * time.Second),
+	}
+
+	b, err := json.Marshal(spec)
+	require.NoError(t, err)
+	assert.Equal(t, `{"size":"1m0s","slide":"10s"}`, string(b))
+
+	b, err = yaml.Marshal(spec)
+	require.NoError(t, err)
+	assert.Equal(t, "size: 1m0s\nslide: 10s\n", string(b))
+}
+
+func TestWindowOf(t *testing.T) {
+	tests := []struct {
+		name      string
+		t         time.Time
+		spec      WindowSpec
+		wantStart time.Time
+		wantEnd   time.Time
+	}{
+		{
+			name:      "tumbling",
+			t:         time.Date(2023, 10, 11, 16, 7, 42, 500, time.UTC),
+			spec:      WindowSpec{Size: dur.Duration(5 * time.Minute)},
+			wantStart: time.Date(2023, 10, 11, 16, 5, 0, 0, time.UTC),
+			wantEnd:   time.Date(2023, 10, 11, 16, 10, 0, 0, time.UTC),
+		},
+		{
+			name:      "on boundary",
+			t:         time.Date(2023, 10, 11, 16, 5, 0, 0, time.UTC),
+			spec:      WindowSpec{Size: dur.Duration(5 * time.Minute)},
+			wantStart: time.Date(2023, 10, 11, 16, 5, 0, 0, time.UTC),
+			wantEnd:   time.Date(2023, 10, 11, 16, 10, 0, 0, time.UTC),
+		},
+		{
+			name: "hopping",
+			t:    time.Date(2023, 10, 11, 16, 7, 42, 0, time.UTC),
+			spec: WindowSpec{
+				Size:  dur.Duration(5 * time.Minute),
+				Slide: dur.Duration(time.Minute),
+			},
+			wantStart: time.Date(2023, 10, 11, 16, 7, 0, 0, time.UTC),
+			wantEnd:   time.Date(2023, 10, 11, 16, 12, 0, 0, time.UTC),
+		},
+		{
+			name: "daily with offset",
+			t:    time.Date(2023, 10, 11, 3, 0, 0, 0, time.UTC),
+			spec: WindowSpec{
+				Size:   dur.Duration(24 * time.Hour),
+				Offset: dur.Duration(-8 * time.Hour),
+			},
+			wantStart: time.Date(2023, 10, 10, 16, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2023, 10, 11, 16, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "before Unix epoch",
+			t:         time.Date(1969, 12, 31, 23, 59, 59, 0, time.UTC),
+			spec:      WindowSpec{Size: dur.Duration(time.Hour)},
+			wantStart: time.Date(1969, 12, 31, 23, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "far future",
+			t:         time.Date(3000, 1, 1, 0, 30, 0, 0, time.UTC),
+			spec:      WindowSpec{Size: dur.Duration(time.Hour)},
+			wantStart: time.Date(3000, 1, 1, 0, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(3000, 1, 1, 1, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := WindowOf(Millisecond(tt.t), tt.spec)
+			require.NoError(t, err)
+
+			assert.True(t, Equal(tt.wantStart, got.Start), got.Start.String())
+			assert.True(t, Equal(tt.wantEnd, got.End), got.End.String())
+			assert.True(t, got.Contains(Millisecond(tt.t)))
+			assert.Equal(t, tt.spec.Size, got.Duration())
+		})
+	}
+}
+
+func TestWindowsOf(t *testing.T) {
+	tm := Millisecond(time.Date(2023, 10, 11, 16, 7, 42, 0, time.UTC))
+	at := func(min int) Millisecond {
+		return Millisecond(time.Date(2023, 10, 11, 16, min, 0, 0, time.UTC))
+	}
+
+	tests := []struct {
+		name string
+		spec WindowSpec
+		want []Interval[Millisecond]
+	}{
+		{
+			name: "tumbling",
+			spec: WindowSpec{Size: dur.Duration(5 * time.Minute)},
+			want: []Interval[Millisecond]{{Start: at(5), End: at(10)}},
+		},
+		{
+			name: "hopping",
+			spec: WindowSpec{
+				Size:  dur.Duration(6 * time.Minute),
+				Slide: dur.Duration(2 * time.Minute),
+			},
+			want: []Interval[Millisecond]{
+				{Start: at(2), End: at(8)},
+				{Start: at(4), End: at(10)},
+				{Start: at(6), End: at(12)},
+			},
+		},
+		{
+			name: "slide larger than size",
+			spec: WindowSpec{
+				Size:  dur.Duration(time.Minute),
+				Slide: dur.Duration(5 * time.Minute),
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := WindowsOf(tm, tt.spec)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestWindowOf_InvalidSpec(t *testing.T) {
+	gap := WindowSpec{Gap: dur.Duration(time.Second)}
+
+	got, err := WindowOf(time.Now(), gap)
+	assert.ErrorIs(t, err, ErrNoWindowSize)
+	assert.True(t, got.IsZero())
+
+	windows, err := WindowsOf(time.Now(), gap)
+	assert.ErrorIs(t, err, ErrNoWindowSize)
+	assert.Nil(t, windows)
+
+	_, err = WindowOf(time.Now(), WindowSpec{})
+	assert.EqualError(t, err, "window size or gap must be set")
+
+	_, err = WindowsOf(time.Now(), WindowSpec{
+		Size:  dur.Duration(time.Second),
+		Slide: dur.Duration(-time.Second),
+	})
+	assert.EqualError(t, err, "window durations must not be negative")
+}
+
+func TestSessions(t *testing.T) {
+	at := func(min int) Second {
+		return Second(time.Date(2023, 10, 11, 16, min, 0, 0, time.UTC))
+	}
+	spec := WindowSpec{Gap: dur.Duration(5 * time.Minute)}
+
+	got, err := Sessions(
+		[]Second{at(20), at(0), at(3), at(8), at(30), at(14)}, spec,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []Interval[Second]{
+		{Start: at(0), End: at(13)},
+		{Start: at(14), End: at(19)},
+		{Start: at(20), End: at(25)},
+		{Start: at(30), End: at(35)},
+	}, got)
+
+	got, err = Sessions([]Second{}, spec)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	_, err = Sessions(
+		[]Second{at(0)}, WindowSpec{Size: dur.Duration(time.Minute)},
+	)
+	assert.ErrorIs(t, err, ErrNoWindowGap)
+
+	_, err = Sessions([]Second{at(0)}, WindowSpec{})
+	assert.EqualError(t, err, "window size or gap must be set")
+}
@@ -6,5 +6,11 @@
 // github.com/araddon/dateparse package.
 //
 // Marshaling always produces a string in RFC 3339 format, by simply formatting
-// the Time with the time.RFC3339Nano layout.
+// the Time with the time.RFC3339Nano layout. The TimeSecond, TimeMilli,
+// TimeMicro and TimeNano types instead produce a fixed number of fractional
+// second digits.
+//
+// The Timestamp constraint matches all wrapper types in this package and the
+// ts package, allowing generic functions like Convert and Compare to work
+// across them.
 package tyme
@@ -0,0 +1,227 @@
+package tyme
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jimeh/go-tyme/dur"
+	"github.com/jimeh/go-tyme/ts"
+	"gopkg.in/yaml.v3"
+)
+
+// Interval is a half-open time interval, including Start and excluding End.
+type Interval = ts.Interval[time.Time]
+
+// Window is a recurring window of time, like a maintenance window, made up of
+// occurrences which start according to a Cron schedule, and last for a fixed
+// duration. It can be unmarshaled from JSON/YAML configuration, with either a
+// cron expression, or a clock time and an optional weekday, and an optional
+// location:
+//
+//	weekday: sunday
+//	at: "02:00"
+//	duration: 3h
+//	location: Europe/Berlin
+//
+// Windows without a weekday occur every day. Windows are marshaled with a
+// cron expression.
+type Window struct {
+	// Schedule determines the start of each occurrence, and the location
+	// occurrences are in.
+	Schedule Cron
+
+	// Duration is the length of each occurrence.
+	Duration dur.Duration
+}
+
+type windowConfig struct {
+	Cron     string       `json:"cron,omitempty" yaml:"cron,omitempty"`
+	Weekday  string       `json:"weekday,omitempty" yaml:"weekday,omitempty"`
+	At       string       `json:"at,omitempty" yaml:"at,omitempty"`
+	Duration dur.Duration `json:"duration" yaml:"duration"`
+	Location string       `json:"location,omitempty" yaml:"location,omitempty"`
+}
+
+// Active returns the occurrence of the window which t is within, and whether
+// t is within an occurrence at all.
+func (w Window) Active(t time.Time) (Interval, bool) {
+	// Cron.Prev is exclusive, so look just after t to include occurrences
+	// starting at t.
+	start := w.Schedule.Prev(t.Add(time.Nanosecond))
+	if start.IsZero() {
+		return Interval{}, false
+	}
+
+	i := w.occurrence(start)
+	if !i.Contains(t) {
+		return Interval{}, false
+	}
+
+	return i, true
+}
+
+// Next returns the first occurrence of the window which starts after t, or
+// the zero Interval if there is none.
+func (w Window) Next(t time.Time) Interval {
+	start := w.Schedule.Next(t)
+	if start.IsZero() {
+		return Interval{}
+	}
+
+	return w.occurrence(start)
+}
+
+// Remaining returns the time remaining of the occurrence of the window which
+// t is within, or zero if t is not within an occurrence.
+func (w Window) Remaining(t time.Time) dur.Duration {
+	i, ok := w.Active(t)
+	if !ok {
+		return 0
+	}
+
+	return dur.Duration(i.End.Sub(t))
+}
+
+// Validate returns an error if the Window has no schedule, or no positive
+// duration.
+func (w Window) Validate() error {
+	switch {
+	case w.Schedule.IsZero():
+		return errors.New("window schedule must be set")
+	case w.Duration <= 0:
+		return errors.New("window duration must be positive")
+	}
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (w Window) MarshalJSON() ([]byte, error) {
+	return json.Marshal(w.config())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, validating the
+// resulting Window.
+func (w *Window) UnmarshalJSON(data []byte) error {
+	var c windowConfig
+	if err := json.Unmarshal(data, &c); err != nil {
+		return err
+	}
+
+	nw, err := c.window()
+	if err != nil {
+		return err
+	}
+	*w = nw
+
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (w Window) MarshalYAML() (interface{}, error) {
+	return w.config(), nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface, validating the
+// resulting Window.
+func (w *Window) UnmarshalYAML(node *yaml.Node) error {
+	var c windowConfig
+	if err := node.Decode(&c); err != nil {
+		return err
+	}
+
+	nw, err := c.window()
+	if err != nil {
+		return &yaml.TypeError{Errors: []string{err.Error()}}
+	}
+	*w = nw
+
+	return nil
+}
+
+func (w Window) occurrence(start time.Time) Interval {
+	return Interval{Start: start, End: start.Add(time.Duration(w.Duration))}
+}
+
+func (w Window) config() windowConfig {
+	c := windowConfig{Cron: w.Schedule.String(), Duration: w.Duration}
+	if strings.HasPrefix(c.Cron, "CRON_TZ=") ||
+		strings.HasPrefix(c.Cron, "TZ=") {
+		_, c.Cron, _ = strings.Cut(c.Cron, " ")
+		c.Cron = strings.TrimSpace(c.Cron)
+		c.Location = w.Schedule.Location().String()
+	}
+
+	return c
+}
+
+func (c windowConfig) window() (Window, error) {
+	expr := c.Cron
+	switch {
+	case expr != "" && (c.At != "" || c.Weekday != ""):
+		return Window{}, errors.New(
+			"window must have either a cron expression or a clock time",
+		)
+	case expr == "" && c.At == "":
+		return Window{}, errors.New(
+			"window must have a cron expression or a clock time",
+		)
+	case expr == "":
+		at, err := time.Parse("15:04:05", c.At)
+		if err != nil {
+			at, err = time.Parse("15:04", c.At)
+		}
+		if err != nil {
+			return Window{}, fmt.Errorf("invalid window clock time %q", c.At)
+		}
+
+		weekday := "*"
+		if c.Weekday != "" {
+			wd, err := parseWeekday(c.Weekday)
+			if err != nil {
+				return Window{}, err
+			}
+			weekday = fmt.Sprint(int(wd))
+		}
+
+		expr = fmt.Sprintf("%d %d %d * * %s",
+			at.Second(), at.Minute(), at.Hour(), weekday)
+	}
+
+	if c.Location != "" {
+		if strings.Contains(expr, "TZ=") {
+			return Window{}, errors.New(
+				"window must not have both a location and a cron time zone",
+			)
+		}
+		expr = "CRON_TZ=" + c.Location + " " + expr
+	}
+
+	schedule, err := ParseCron(expr)
+	if err != nil {
+		return Window{}, err
+	}
+
+	w := Window{Schedule: schedule, Duration: c.Duration}
+	if err := w.Validate(); err != nil {
+		return Window{}, err
+	}
+
+	return w, nil
+}
+
+// parseWeekday parses the full or three letter English name of a day of the
+// week, ignoring case.
+func parseWeekday(s string) (time.Weekday, error) {
+	for wd := time.Sunday; wd <= time.Saturday; wd++ {
+		name := wd.String()
+		if strings.EqualFold(s, name) || strings.EqualFold(s, name[:3]) {
+			return wd, nil
+		}
+	}
+
+	return 0, fmt.Errorf("invalid weekday %q", s)
+}
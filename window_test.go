@@ -0,0 +1,255 @@
+package tyme
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/jimeh/go-tyme/dur"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestInterval(t *testing.T) {
+	i := Interval{
+		Start: time.Date(2023, 10, 15, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 10, 15, 3, 0, 0, 0, time.UTC),
+	}
+
+	assert.True(t, i.Contains(i.Start))
+	assert.True(t, i.Contains(i.Start.Add(time.Hour)))
+	assert.False(t, i.Contains(i.End))
+	assert.False(t, i.Contains(i.Start.Add(-time.Nanosecond)))
+	assert.Equal(t, dur.Duration(3*time.Hour), i.Duration())
+	assert.False(t, i.IsZero())
+	assert.True(t, Interval{}.IsZero())
+}
+
+func TestWindow(t *testing.T) {
+	var w Window
+	err := yaml.Unmarshal([]byte(`weekday: sunday
+at: "02:00"
+duration: 3h
+location: Europe/Berlin
+`), &w)
+	require.NoError(t, err)
+
+	berlin := w.Schedule.Location()
+	utcAt := func(m time.Month, d, h int) time.Time {
+		return time.Date(2023, m, d, h, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name          string
+		t             time.Time
+		wantActive    Interval
+		wantNext      Interval
+		wantRemaining dur.Duration
+	}{
+		{
+			name: "before occurrence",
+			t:    utcAt(10, 14, 23),
+			wantNext: Interval{
+				Start: utcAt(10, 15, 0).In(berlin),
+				End:   utcAt(10, 15, 3).In(berlin),
+			},
+		},
+		{
+			name: "start of occurrence",
+			t:    utcAt(10, 15, 0),
+			wantActive: Interval{
+				Start: utcAt(10, 15, 0).In(berlin),
+				End:   utcAt(10, 15, 3).In(berlin),
+			},
+			wantNext: Interval{
+				Start: utcAt(10, 22, 0).In(berlin),
+				End:   utcAt(10, 22, 3).In(berlin),
+			},
+			wantRemaining: dur.Duration(3 * time.Hour),
+		},
+		{
+			name: "within occurrence",
+			t:    utcAt(10, 15, 1),
+			wantActive: Interval{
+				Start: utcAt(10, 15, 0).In(berlin),
+				End:   utcAt(10, 15, 3).In(berlin),
+			},
+			wantNext: Interval{
+				Start: utcAt(10, 22, 0).In(berlin),
+				End:   utcAt(10, 22, 3).In(berlin),
+			},
+			wantRemaining: dur.Duration(2 * time.Hour),
+		},
+		{
+			name: "end of occurrence",
+			t:    utcAt(10, 15, 3),
+			wantNext: Interval{
+				Start: utcAt(10, 22, 0).In(berlin),
+				End:   utcAt(10, 22, 3).In(berlin),
+			},
+		},
+		{
+			name: "repeated start time",
+			t:    utcAt(10, 23, 0),
+			wantNext: Interval{
+				Start: utcAt(10, 29, 0).In(berlin),
+				End:   utcAt(10, 29, 3).In(berlin),
+			},
+		},
+		{
+			name: "after daylight saving time",
+			t:    utcAt(11, 5, 2),
+			wantActive: Interval{
+				Start: utcAt(11, 5, 1).In(berlin),
+				End:   utcAt(11, 5, 4).In(berlin),
+			},
+			wantNext: Interval{
+				Start: utcAt(11, 12, 1).In(berlin),
+				End:   utcAt(11, 12, 4).In(berlin),
+			},
+			wantRemaining: dur.Duration(2 * time.Hour),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			active, ok := w.Active(tt.t)
+
+			assert.Equal(t, !tt.wantActive.IsZero(), ok)
+			assert.Equal(t, tt.wantActive, active)
+			assert.Equal(t, tt.wantNext, w.Next(tt.t))
+			assert.Equal(t, tt.wantRemaining, w.Remaining(tt.t))
+		})
+	}
+}
+
+func TestWindow_Zero(t *testing.T) {
+	var w Window
+	now := time.Date(2023, 10, 15, 0, 0, 0, 0, time.UTC)
+
+	_, ok := w.Active(now)
+	assert.False(t, ok)
+	assert.True(t, w.Next(now).IsZero())
+	assert.Zero(t, w.Remaining(now))
+	assert.EqualError(t, w.Validate(), "window schedule must be set")
+
+	w.Schedule = MustParseCron("@daily")
+	assert.EqualError(t, w.Validate(), "window duration must be positive")
+}
+
+func TestWindow_MarshalUnmarshal(t *testing.T) {
+	tests := []struct {
+		name     string
+		json     string
+		want     string
+		wantYAML string
+		wantErr  string
+	}{
+		{
+			name: "weekday and clock time",
+			json: `{"weekday":"sun","at":"02:00","duration":"3h",` +
+				`"location":"Europe/Berlin"}`,
+			want: `{"cron":"0 0 2 * * 0","duration":"3h0m0s",` +
+				`"location":"Europe/Berlin"}`,
+			wantYAML: "cron: 0 0 2 * * 0\nduration: 3h0m0s\n" +
+				"location: Europe/Berlin\n",
+		},
+		{
+			name:     "daily clock time with seconds",
+			json:     `{"at":"23:30:15","duration":"30m"}`,
+			want:     `{"cron":"15 30 23 * * *","duration":"30m0s"}`,
+			wantYAML: "cron: 15 30 23 * * *\nduration: 30m0s\n",
+		},
+		{
+			name:     "cron",
+			json:     `{"cron":"0 22 * * 1-5","duration":1800}`,
+			want:     `{"cron":"0 22 * * 1-5","duration":"30m0s"}`,
+			wantYAML: "cron: 0 22 * * 1-5\nduration: 30m0s\n",
+		},
+		{
+			name: "cron with location",
+			json: `{"cron":"@weekly","duration":"1h",` +
+				`"location":"America/New_York"}`,
+			want: `{"cron":"@weekly","duration":"1h0m0s",` +
+				`"location":"America/New_York"}`,
+			wantYAML: "cron: '@weekly'\nduration: 1h0m0s\n" +
+				"location: America/New_York\n",
+		},
+		{
+			name:    "missing schedule",
+			json:    `{"duration":"1h"}`,
+			wantErr: "window must have a cron expression or a clock time",
+		},
+		{
+			name: "cron and clock time",
+			json: `{"cron":"@daily","at":"02:00","duration":"1h"}`,
+			wantErr: "window must have either a cron expression " +
+				"or a clock time",
+		},
+		{
+			name:    "missing duration",
+			json:    `{"cron":"@daily"}`,
+			wantErr: "window duration must be positive",
+		},
+		{
+			name:    "invalid clock time",
+			json:    `{"at":"25:00","duration":"1h"}`,
+			wantErr: `invalid window clock time "25:00"`,
+		},
+		{
+			name:    "invalid weekday",
+			json:    `{"weekday":"caturday","at":"02:00","duration":"1h"}`,
+			wantErr: `invalid weekday "caturday"`,
+		},
+		{
+			name: "invalid cron",
+			json: `{"cron":"61 * * * *","duration":"1h"}`,
+			wantErr: `invalid cron expression "61 * * * *": ` +
+				`invalid minute "61"`,
+		},
+		{
+			name: "unknown location",
+			json: `{"cron":"@daily","duration":"1h",` +
+				`"location":"Nowhere/Town"}`,
+			wantErr: `invalid cron expression ` +
+				`"CRON_TZ=Nowhere/Town @daily": ` +
+				"unknown time zone Nowhere/Town",
+		},
+		{
+			name: "location and cron time zone",
+			json: `{"cron":"TZ=UTC @daily","duration":"1h",` +
+				`"location":"Europe/Berlin"}`,
+			wantErr: "window must not have both a location " +
+				"and a cron time zone",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var w Window
+			err := json.Unmarshal([]byte(tt.json), &w)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+
+				err = yaml.Unmarshal([]byte(tt.json), &w)
+				assert.ErrorContains(t, err, tt.wantErr)
+
+				return
+			}
+			require.NoError(t, err)
+
+			b, err := json.Marshal(w)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(b))
+
+			b, err = yaml.Marshal(w)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantYAML, string(b))
+
+			var got Window
+			require.NoError(t, yaml.Unmarshal(b, &got))
+			assert.Equal(t, w.Duration, got.Duration)
+			assert.Equal(t, w.Schedule.Location(), got.Schedule.Location())
+		})
+	}
+}